@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/feed"
+)
+
+func main() {
+	var (
+		listen   string
+		interval time.Duration
+		buffer   int
+		origins  string
+	)
+
+	// The config file and environment set the API host and timeout
+	if _, err := config.Setup(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load config: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	flag.StringVar(&listen, "listen", "127.0.0.1:8082", "Address to listen on")
+	flag.DurationVar(&interval, "interval", 30*time.Second, "How often each subscribed topic is polled")
+	flag.IntVar(&buffer, "buffer", 16, "How many events a subscriber can fall behind by before it's disconnected")
+	flag.StringVar(&origins, "allowed-origins", "", "Comma-separated origins whose pages may open WebSockets, or * for any")
+	flag.String("config", "", "Path to a config file")
+	flag.Parse()
+
+	var allowedOrigins []string
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowedOrigins = append(allowedOrigins, origin)
+		}
+	}
+
+	hub := feed.NewHub(feed.Options{
+		Interval: interval,
+		Buffer:   buffer,
+		OnError: func(topic string, err error) {
+			log.Printf("Polling %s failed: %v", topic, err)
+		},
+		AllowedOrigins: allowedOrigins,
+	})
+
+	http.Handle("/feed", hub)
+
+	log.Printf("Serving feed on http://%s/feed", listen)
+	log.Fatal(http.ListenAndServe(listen, nil))
+}
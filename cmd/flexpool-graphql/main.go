@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/gql"
+)
+
+// playground is a GraphiQL page pointed at /graphql, loaded from a CDN so the binary has nothing to deploy alongside it.
+const playground = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>flexpool GraphQL</title>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css">
+  <style>body { margin: 0; height: 100vh; } #graphiql { height: 100vh; }</style>
+</head>
+<body>
+  <div id="graphiql"></div>
+  <script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+  <script>
+    const fetcher = GraphiQL.createFetcher({ url: '/graphql' });
+    ReactDOM.createRoot(document.getElementById('graphiql')).render(React.createElement(GraphiQL, { fetcher }));
+  </script>
+</body>
+</html>
+`
+
+func main() {
+	var (
+		listen  string
+		options gql.Options
+	)
+
+	// The config file and environment set the API host and timeout
+	if _, err := config.Setup(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load config: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	flag.StringVar(&listen, "listen", "127.0.0.1:8083", "Address to listen on")
+	flag.IntVar(&options.MaxDepth, "max-depth", gql.DefaultMaxDepth, "How deeply a query's fields can be nested")
+	flag.IntVar(&options.MaxParallelism, "max-parallelism", gql.DefaultMaxParallelism, "How many resolvers a query runs at once")
+	flag.IntVar(&options.MaxAddresses, "max-addresses", gql.DefaultMaxAddresses, "How many distinct miner addresses a query can ask about")
+	flag.String("config", "", "Path to a config file")
+	flag.Parse()
+
+	http.Handle("/graphql", gql.NewHandler(options))
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, playground)
+	})
+
+	log.Printf("Serving GraphQL on http://%s/graphql, with a playground at http://%s/", listen, listen)
+	log.Fatal(http.ListenAndServe(listen, nil))
+}
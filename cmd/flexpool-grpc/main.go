@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	var (
+		listen  string
+		options rpc.Options
+	)
+
+	// The config file and environment set the API host and timeout
+	if _, err := config.Setup(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load config: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	flag.StringVar(&listen, "listen", "127.0.0.1:50051", "Address to listen on")
+	flag.DurationVar(&options.CacheTTL, "cache-ttl", 30*time.Second, "How long API responses are cached")
+	flag.DurationVar(&options.WatchInterval, "watch-interval", 30*time.Second, "How often streams poll for changes")
+	flag.String("config", "", "Path to a config file")
+	flag.Parse()
+
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to listen: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	server := rpc.NewServer(options)
+	defer server.Close()
+
+	grpcServer := grpc.NewServer()
+	server.Register(grpcServer)
+
+	// Reflection lets tools like grpcurl list and call the services without the .proto file
+	reflection.Register(grpcServer)
+
+	// Free memory used by responses nobody has asked for again
+	go func() {
+		for range time.Tick(options.CacheTTL) {
+			server.Purge()
+		}
+	}()
+
+	log.Printf("Serving gRPC on %s", listen)
+	log.Fatal(grpcServer.Serve(listener))
+}
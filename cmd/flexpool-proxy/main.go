@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/proxy"
+)
+
+func main() {
+	var (
+		listen   string
+		options  proxy.Options
+		interval time.Duration
+	)
+
+	flag.StringVar(&listen, "listen", "127.0.0.1:8081", "Address to listen on")
+	flag.StringVar(&options.Upstream, "upstream", api.APIHost, "Base URL of the API to forward to")
+	flag.DurationVar(&options.TTL, "ttl", 30*time.Second, "How long responses are cached")
+	flag.DurationVar(&options.StaleTTL, "stale-ttl", 10*time.Minute, "How long expired responses are kept to serve if the upstream fails")
+	flag.Float64Var(&options.Rate, "rate", 2, "Maximum upstream requests per second (0 for unlimited)")
+	flag.IntVar(&options.Burst, "burst", 10, "Maximum upstream requests made at once before rate limiting starts")
+	flag.DurationVar(&options.MaxWait, "max-wait", proxy.DefaultMaxWait, "How long an upstream request waits for the rate limiter")
+	flag.Parse()
+
+	p, err := proxy.New(options)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to start proxy: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	// Free memory used by responses nobody has asked for again
+	interval = options.TTL + options.StaleTTL
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	go func() {
+		for range time.Tick(interval) {
+			p.Purge()
+		}
+	}()
+
+	log.Printf("Proxying %s on http://%s (stats at %s)", options.Upstream, listen, proxy.StatsPath)
+	log.Fatal(http.ListenAndServe(listen, p))
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/web"
+)
+
+// addressFlag collects repeated -address flags of the form name=0x... or just 0x...
+type addressFlag map[string]string
+
+func (a addressFlag) String() string {
+	return fmt.Sprint(map[string]string(a))
+}
+
+func (a addressFlag) Set(value string) error {
+	name, address := value, value
+	if i := strings.Index(value, "="); i >= 0 {
+		name, address = value[:i], value[i+1:]
+	}
+
+	a[name] = address
+
+	return nil
+}
+
+func main() {
+	var (
+		listen   string
+		username string
+		password string
+		cacheTTL time.Duration
+	)
+
+	// Flags fall back to the config file and environment
+	cfg, err := config.Setup(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load config: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	// The cache TTL has already been validated
+	defaultTTL, _ := time.ParseDuration(cfg.Web.CacheTTL)
+
+	addresses := make(addressFlag)
+
+	flag.StringVar(&listen, "listen", cfg.Web.Listen, "Address to listen on")
+	flag.StringVar(&username, "username", cfg.Web.Username, "Basic auth username (auth is disabled when empty)")
+	flag.StringVar(&password, "password", cfg.Web.Password, "Basic auth password")
+	flag.DurationVar(&cacheTTL, "cache-ttl", defaultTTL, "How long API responses are cached")
+	flag.Var(addresses, "address", "Address to serve, as name=0x... (repeatable, defaults to the config's addresses)")
+	flag.String("config", "", "Path to a config file")
+	flag.Parse()
+
+	if len(addresses) == 0 {
+		for name, address := range cfg.Addresses {
+			addresses[name] = address
+		}
+
+		if len(addresses) == 0 && cfg.DefaultAddress != "" {
+			addresses[cfg.DefaultAddress] = cfg.Address(cfg.DefaultAddress)
+		}
+	}
+
+	if len(addresses) == 0 {
+		fmt.Fprintf(os.Stderr, "No addresses given, exiting.\n")
+		os.Exit(1)
+	}
+
+	if (username == "") != (password == "") {
+		fmt.Fprintf(os.Stderr, "Username and password must be given together.\n")
+		os.Exit(1)
+	}
+
+	server := web.NewServer(web.Options{
+		Addresses: addresses,
+		Username:  username,
+		Password:  password,
+		CacheTTL:  cacheTTL,
+	})
+
+	// Free memory used by addresses that aren't being looked at anymore
+	go func() {
+		for range time.Tick(cacheTTL) {
+			server.Purge()
+		}
+	}()
+
+	if username == "" && !strings.HasPrefix(listen, "127.0.0.1:") && !strings.HasPrefix(listen, "localhost:") {
+		log.Printf("Warning: serving on %s without basic auth", listen)
+	}
+
+	log.Printf("Serving dashboard for %d address(es) on http://%s", len(addresses), listen)
+	log.Fatal(http.ListenAndServe(listen, server))
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/format"
+	"github.com/cryptogenic/goflexpool/pkg/utils/blocks"
+)
+
+// runBlocks runs the blocks command, printing the pool's blocks, or a miner's if an address is given, after filtering
+// and sorting them. With --summary or --regions, the aggregated stats of each server or region are printed instead.
+func runBlocks(args []string, cfg config.Config, output string, stdout io.Writer, stderr io.Writer) int {
+	var (
+		filter                  blocks.Filter
+		pages                   int
+		confirmed, since, until string
+		sortKey                 string
+		ascending, summary      bool
+		regions                 bool
+		minNumber, maxNumber    uint
+	)
+
+	flags := flag.NewFlagSet("flexpool blocks", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&output, "output", output, "Output format: table, json, csv or yaml")
+	flags.String("config", "", "Path to a config file")
+	flags.IntVar(&pages, "pages", 5, "Maximum number of pages to fetch, or 0 for all of them")
+	flags.StringVar(&filter.Type, "type", "", "Only show blocks of this type: block or uncle")
+	flags.StringVar(&confirmed, "confirmed", "", "Only show confirmed (true) or unconfirmed (false) blocks")
+	flags.StringVar(&filter.ServerName, "server", "", "Only show blocks mined through this server, such as eu1")
+	flags.StringVar(&filter.Miner, "miner", "", "Only show blocks mined by this address")
+	flags.StringVar(&since, "since", "", "Only show blocks from this time: a date, RFC 3339 time, or duration ago such as 24h")
+	flags.StringVar(&until, "until", "", "Only show blocks up to this time, in the same formats as --since, with a date including the whole day")
+	flags.UintVar(&minNumber, "min-number", 0, "Only show blocks with at least this number")
+	flags.UintVar(&maxNumber, "max-number", 0, "Only show blocks with at most this number")
+	flags.StringVar(&sortKey, "sort", blocks.SortNumber, "Sort by number, luck, reward or round-time")
+	flags.BoolVar(&ascending, "ascending", false, "Sort in ascending rather than descending order")
+	flags.BoolVar(&summary, "summary", false, "Print block counts, rewards, fee share and uncle rate per server instead")
+	flags.BoolVar(&regions, "regions", false, "Print per-region stats alongside each region's share of the pool hashrate instead")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: flexpool blocks [address] [flags]\n\n")
+		fmt.Fprintf(stderr, "Pool blocks, or the blocks mined by an address, filtered and sorted\n\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+
+		return ExitUsage
+	}
+
+	if len(positional) > 1 {
+		return usageError(stderr, "blocks takes at most 1 argument: [address]")
+	}
+
+	if output == format.Text || !format.Valid(output) {
+		return usageError(stderr, "unknown output format %q, must be one of table, json, csv or yaml", output)
+	}
+
+	if err = filter.Validate(); err != nil {
+		return usageError(stderr, "%v", err)
+	}
+
+	if summary && regions {
+		return usageError(stderr, "--summary and --regions can't be used together")
+	}
+
+	if !blocks.ValidSortKey(sortKey) {
+		return usageError(stderr, "%v", blocks.ErrInvalidSortKey)
+	}
+
+	if confirmed != "" {
+		value, err := strconv.ParseBool(confirmed)
+		if err != nil {
+			return usageError(stderr, "--confirmed must be true or false")
+		}
+
+		filter.Confirmed = &value
+	}
+
+	now := time.Now()
+
+	if filter.Since, err = parseTime(since, now, false); err != nil {
+		return usageError(stderr, "invalid --since: %v", err)
+	}
+
+	if filter.Until, err = parseTime(until, now, true); err != nil {
+		return usageError(stderr, "invalid --until: %v", err)
+	}
+
+	filter.MinNumber = minNumber
+	filter.MaxNumber = maxNumber
+
+	if filter.Miner != "" {
+		filter.Miner = cfg.Address(filter.Miner)
+	}
+
+	source := blocks.PoolPages()
+	if len(positional) == 1 {
+		source = blocks.MinerPages(cfg.Address(positional[0]))
+	}
+
+	selected, err := blocks.Fetch(source, pages, filter)
+	if err != nil {
+		fmt.Fprintf(stderr, "flexpool: blocks failed: %v\n", err)
+		return ExitError
+	}
+
+	var result interface{} = selected
+
+	switch {
+	case summary:
+		result = blocks.Aggregate(selected)
+	case regions:
+		chart, err := api.PoolGetHashrateChart()
+		if err != nil {
+			fmt.Fprintf(stderr, "flexpool: blocks failed: %v\n", err)
+			return ExitError
+		}
+
+		result = blocks.AggregateRegions(selected, chart)
+	default:
+		blocks.Sort(selected, sortKey, !ascending)
+	}
+
+	if err = format.Write(stdout, output, result); err != nil {
+		fmt.Fprintf(stderr, "flexpool: unable to write output: %v\n", err)
+		return ExitError
+	}
+
+	return ExitOK
+}
+
+// parseTime takes a time flag's value, the current time and whether the value ends a range, and parses the value as an
+// RFC 3339 time, a date, or a duration before now. A date is the start of the day, or its last moment when it ends a
+// range, so the whole day is included. Returns the time and nil on success, a zero time and nil for an empty value, or a
+// zero time and error on failure.
+func parseTime(value string, now time.Time, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+
+		return t, nil
+	}
+
+	ago, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q isn't a date, RFC 3339 time or duration", value)
+	}
+
+	return now.Add(-ago), nil
+}
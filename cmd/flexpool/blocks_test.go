@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2021, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		end   bool
+		want  time.Time
+	}{
+		{"Empty", "", false, time.Time{}},
+		{"Date", "2021-03-01", false, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"DateEnd", "2021-03-01", true, time.Date(2021, 3, 1, 23, 59, 59, 999999999, time.UTC)},
+		{"RFC3339End", "2021-03-01T06:00:00Z", true, time.Date(2021, 3, 1, 6, 0, 0, 0, time.UTC)},
+		{"Duration", "24h", false, time.Date(2021, 3, 9, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseTime(test.value, now, test.end)
+			if err != nil || !got.Equal(test.want) {
+				t.Errorf("parseTime(%q, %v) = %v, %v, want %v", test.value, test.end, got, err, test.want)
+			}
+		})
+	}
+
+	if _, err := parseTime("yesterday", now, false); err == nil {
+		t.Error("parseTime accepted \"yesterday\"")
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+// command describes a single leaf subcommand, such as "miner balance". Args lists the names of the positional arguments
+// it takes, and Paged commands accept a -page flag. Run is given the positional arguments and page, and returns the
+// result to render.
+type command struct {
+	Summary string
+	Args    []string
+	Paged   bool
+	Run     func(args []string, page int) (interface{}, error)
+}
+
+// commandGroup contains the subcommands of a top-level command, along with the order they're listed in usage output.
+type commandGroup struct {
+	Summary  string
+	Order    []string
+	Commands map[string]command
+}
+
+// addressArgs and workerArgs are the positional arguments for miner and worker commands.
+var (
+	addressArgs = []string{"address"}
+	workerArgs  = []string{"address", "worker"}
+)
+
+// groupOrder is the order top-level commands are listed in usage output.
+var groupOrder = []string{"miner", "worker", "pool"}
+
+// groups maps each top-level command to its subcommands, covering every function in the api package.
+var groups = map[string]commandGroup{
+	"miner": {
+		Summary: "Query a miner by wallet address",
+		Order: []string{"balance", "current", "daily", "stats", "worker-count", "workers", "chart", "payments",
+			"payment-count", "payment-chart", "blocks", "block-count", "details", "estimated-revenue", "round-share",
+			"total-paid", "total-donated"},
+		Commands: map[string]command{
+			"balance": {"Unpaid balance in gwei", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetBalance(args[0])
+			}},
+			"current": {"Current effective and reported hashrate", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetCurrent(args[0])
+			}},
+			"daily": {"Hashrate and shares over the last 24 hours", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetDaily(args[0])
+			}},
+			"stats": {"Current and daily stats", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetStats(args[0])
+			}},
+			"worker-count": {"Online and offline worker counts", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetWorkerCount(args[0])
+			}},
+			"workers": {"List of workers", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetWorkers(args[0])
+			}},
+			"chart": {"Hashrate and share chart data", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetChart(args[0])
+			}},
+			"payments": {"Page of payments", addressArgs, true, func(args []string, page int) (interface{}, error) {
+				data, err := api.MinerGetPayments(args[0], page)
+				return data.Data, err
+			}},
+			"payment-count": {"Number of payments", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetPaymentCount(args[0])
+			}},
+			"payment-chart": {"Payment chart data", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetPaymentChart(args[0])
+			}},
+			"blocks": {"Page of blocks mined", addressArgs, true, func(args []string, page int) (interface{}, error) {
+				data, err := api.MinerGetBlocks(args[0], page)
+				return data.Data, err
+			}},
+			"block-count": {"Number of blocks mined", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetBlockCount(args[0])
+			}},
+			"details": {"Payout threshold, donation and meta details", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetDetails(args[0])
+			}},
+			"estimated-revenue": {"Estimated daily revenue in gwei", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetEstimatedDailyRevenue(args[0])
+			}},
+			"round-share": {"Current round share percentage", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetRoundShare(args[0])
+			}},
+			"total-paid": {"Total paid in gwei", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetTotalPaid(args[0])
+			}},
+			"total-donated": {"Total donated in gwei", addressArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.MinerGetTotalDonated(args[0])
+			}},
+		},
+	},
+	"worker": {
+		Summary: "Query a single worker of a miner",
+		Order:   []string{"current", "daily", "stats", "chart"},
+		Commands: map[string]command{
+			"current": {"Current effective and reported hashrate", workerArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.WorkerGetCurrent(args[0], args[1])
+			}},
+			"daily": {"Hashrate and shares over the last 24 hours", workerArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.WorkerGetDaily(args[0], args[1])
+			}},
+			"stats": {"Current and daily stats", workerArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.WorkerGetStats(args[0], args[1])
+			}},
+			"chart": {"Hashrate and share chart data", workerArgs, false, func(args []string, page int) (interface{}, error) {
+				return api.WorkerGetChart(args[0], args[1])
+			}},
+		},
+	},
+	"pool": {
+		Summary: "Query pool-wide stats",
+		Order: []string{"hashrate", "hashrate-chart", "miners-online", "workers-online", "blocks", "block-count",
+			"top-miners", "top-donators", "luck", "current-luck", "average-block-reward"},
+		Commands: map[string]command{
+			"hashrate": {"Hashrate per region", nil, false, func(args []string, page int) (interface{}, error) {
+				return api.PoolGetHashrate()
+			}},
+			"hashrate-chart": {"Hashrate chart data per region", nil, false, func(args []string, page int) (interface{}, error) {
+				return api.PoolGetHashrateChart()
+			}},
+			"miners-online": {"Number of active miners", nil, false, func(args []string, page int) (interface{}, error) {
+				return api.PoolGetMinersOnline()
+			}},
+			"workers-online": {"Number of active workers", nil, false, func(args []string, page int) (interface{}, error) {
+				return api.PoolGetWorkersOnline()
+			}},
+			"blocks": {"Page of blocks mined by the pool", nil, true, func(args []string, page int) (interface{}, error) {
+				data, err := api.PoolGetBlocks(page)
+				return data.Data, err
+			}},
+			"block-count": {"Confirmed and unconfirmed block counts", nil, false, func(args []string, page int) (interface{}, error) {
+				return api.PoolGetBlockCount()
+			}},
+			"top-miners": {"Top miners by hashrate", nil, false, func(args []string, page int) (interface{}, error) {
+				return api.PoolGetTopMiners()
+			}},
+			"top-donators": {"Top donators", nil, false, func(args []string, page int) (interface{}, error) {
+				return api.PoolGetTopDonators()
+			}},
+			"luck": {"Average luck and round time", nil, false, func(args []string, page int) (interface{}, error) {
+				return api.PoolGetAverageLuckRoundTime()
+			}},
+			"current-luck": {"Current round luck percentage", nil, false, func(args []string, page int) (interface{}, error) {
+				return api.PoolGetCurrentLuck()
+			}},
+			"average-block-reward": {"Average block reward in gwei", nil, false, func(args []string, page int) (interface{}, error) {
+				return api.PoolGetAverageBlockReward()
+			}},
+		},
+	},
+}
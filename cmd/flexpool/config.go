@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cryptogenic/goflexpool/pkg/config"
+)
+
+// runConfig runs the config subcommands. defaultPath is the config file found from the command line and environment, and
+// cfg and cfgErr are the result of loading it.
+func runConfig(args []string, defaultPath string, cfg config.Config, cfgErr error, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		return usageError(stderr, "config takes a subcommand: validate or show")
+	}
+
+	flags := flag.NewFlagSet("flexpool config "+args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.String("config", "", "Path to a config file")
+
+	positional, err := parseInterspersed(flags, args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+
+		return ExitUsage
+	}
+
+	switch args[0] {
+	case "validate":
+		if len(positional) > 1 {
+			return usageError(stderr, "config validate takes at most 1 argument: [file]")
+		}
+
+		name := defaultPath
+		if len(positional) == 1 {
+			name = positional[0]
+		}
+
+		if _, err = config.Load(name); err != nil {
+			var problems config.Errors
+			if errors.As(err, &problems) {
+				for _, problem := range problems {
+					fmt.Fprintln(stderr, problem)
+				}
+			} else {
+				fmt.Fprintf(stderr, "flexpool: %v\n", err)
+			}
+
+			return ExitError
+		}
+
+		fmt.Fprintf(stdout, "%s: ok\n", name)
+	case "show":
+		if len(positional) > 0 {
+			return usageError(stderr, "config show takes no arguments")
+		}
+
+		if cfgErr != nil {
+			fmt.Fprintf(stderr, "flexpool: unable to load config: %v\n", cfgErr)
+			return ExitError
+		}
+
+		// Don't print secrets to the terminal
+		if cfg.Web.Password != "" {
+			cfg.Web.Password = "********"
+		}
+
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+
+		if err = encoder.Encode(cfg); err != nil {
+			fmt.Fprintf(stderr, "flexpool: unable to write output: %v\n", err)
+			return ExitError
+		}
+	default:
+		return usageError(stderr, "unknown config command %q", args[0])
+	}
+
+	return ExitOK
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/format"
+	"github.com/cryptogenic/goflexpool/pkg/utils/grouping"
+)
+
+// runGroups runs the groups command, printing aggregated stats for each of a miner's worker groups from the config file,
+// or the merged chart of a single group.
+func runGroups(args []string, cfg config.Config, output string, stdout io.Writer, stderr io.Writer) int {
+	var chartGroup string
+
+	flags := flag.NewFlagSet("flexpool groups", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&output, "output", output, "Output format: table, json, csv or yaml")
+	flags.StringVar(&chartGroup, "chart", "", "Print the merged chart data of this group instead of stats")
+	flags.String("config", "", "Path to a config file")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: flexpool groups <address> [--chart group]\n\n")
+		fmt.Fprintf(stderr, "Worker stats aggregated by the groups in the config file\n\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+
+		return ExitUsage
+	}
+
+	if len(positional) != 1 {
+		return usageError(stderr, "groups takes 1 argument(s): <address>")
+	}
+
+	if output == format.Text || !format.Valid(output) {
+		return usageError(stderr, "unknown output format %q, must be one of table, json, csv or yaml", output)
+	}
+
+	address := cfg.Address(positional[0])
+
+	grouper, err := grouping.FromConfig(cfg, address)
+	if err != nil {
+		fmt.Fprintf(stderr, "flexpool: invalid groups in config: %v\n", err)
+		return ExitError
+	}
+
+	report, err := grouper.Fetch(address, chartGroup != "")
+	if err != nil {
+		fmt.Fprintf(stderr, "flexpool: groups failed: %v\n", err)
+		return ExitError
+	}
+
+	var result interface{} = report.Groups
+
+	if chartGroup != "" {
+		chart, ok := report.Charts[chartGroup]
+		if !ok {
+			return usageError(stderr, "unknown group %q", chartGroup)
+		}
+
+		result = chart
+	}
+
+	if err = format.Write(stdout, output, result); err != nil {
+		fmt.Fprintf(stderr, "flexpool: unable to write output: %v\n", err)
+		return ExitError
+	}
+
+	return ExitOK
+}
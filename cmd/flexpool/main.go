@@ -0,0 +1,240 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/format"
+)
+
+// Exit codes returned by the flexpool command.
+const (
+	ExitOK    = 0 // The command succeeded
+	ExitError = 1 // The API request or output failed
+	ExitUsage = 2 // The command line was invalid
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run parses the command line and executes the requested subcommand, writing results to stdout and errors to stderr.
+// Returns the exit code for the process.
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	var output string
+
+	// A broken config file is only reported once we know the command isn't "config validate"
+	cfg, cfgErr := config.Setup(args)
+
+	// The config's text format is the same layout as table, which is what every subcommand calls it
+	defaultOutput := format.Table
+	if cfg.Output.Format != "" && cfg.Output.Format != format.Text {
+		defaultOutput = cfg.Output.Format
+	}
+
+	global := flag.NewFlagSet("flexpool", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.StringVar(&output, "output", defaultOutput, "Output format: table, json, csv or yaml")
+	global.String("config", "", "Path to a config file")
+	global.Usage = func() {
+		printUsage(stderr)
+	}
+
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+
+		return ExitUsage
+	}
+
+	original := args
+	args = global.Args()
+
+	if len(args) == 0 {
+		printUsage(stderr)
+		return ExitUsage
+	}
+
+	if args[0] == "help" {
+		printUsage(stdout)
+		return ExitOK
+	}
+
+	if args[0] == "config" {
+		defaultPath, _ := config.Path(original)
+		return runConfig(args[1:], defaultPath, cfg, cfgErr, stdout, stderr)
+	}
+
+	if cfgErr != nil {
+		fmt.Fprintf(stderr, "flexpool: unable to load config: %v\n", cfgErr)
+		return ExitError
+	}
+
+	if args[0] == "top" {
+		return runTop(args[1:], cfg, stdout, stderr)
+	}
+
+	if args[0] == "blocks" {
+		return runBlocks(args[1:], cfg, output, stdout, stderr)
+	}
+
+	if args[0] == "groups" {
+		return runGroups(args[1:], cfg, output, stdout, stderr)
+	}
+
+	group, ok := groups[args[0]]
+	if !ok {
+		return usageError(stderr, "unknown command %q", args[0])
+	}
+
+	if len(args) < 2 {
+		printGroupUsage(stderr, args[0], group)
+		return ExitUsage
+	}
+
+	cmd, ok := group.Commands[args[1]]
+	if !ok {
+		return usageError(stderr, "unknown %s command %q", args[0], args[1])
+	}
+
+	// Each subcommand accepts --output too, defaulting to the global value, so flags can go before or after it
+	name := args[0] + " " + args[1]
+	page := 0
+
+	flags := flag.NewFlagSet("flexpool "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&output, "output", output, "Output format: table, json, csv or yaml")
+	flags.String("config", "", "Path to a config file")
+
+	if cmd.Paged {
+		flags.IntVar(&page, "page", 0, "Page number, starting at 0")
+	}
+
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: flexpool %s %s\n\n%s\n\n", name, formatArgs(cmd.Args), cmd.Summary)
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(flags, args[2:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+
+		return ExitUsage
+	}
+
+	if len(positional) != len(cmd.Args) {
+		return usageError(stderr, "%s takes %d argument(s): %s", name, len(cmd.Args), formatArgs(cmd.Args))
+	}
+
+	if output == format.Text || !format.Valid(output) {
+		return usageError(stderr, "unknown output format %q, must be one of table, json, csv or yaml", output)
+	}
+
+	// Addresses can be given as names from the config file
+	for i, arg := range cmd.Args {
+		if arg == "address" {
+			positional[i] = cfg.Address(positional[i])
+		}
+	}
+
+	result, err := cmd.Run(positional, page)
+	if err != nil {
+		fmt.Fprintf(stderr, "flexpool: %s failed: %v\n", name, err)
+		return ExitError
+	}
+
+	if err = format.Write(stdout, output, result); err != nil {
+		fmt.Fprintf(stderr, "flexpool: unable to write output: %v\n", err)
+		return ExitError
+	}
+
+	return ExitOK
+}
+
+// parseInterspersed parses flags that may appear before, between or after positional arguments, which the flag package
+// doesn't support on its own. Returns the positional arguments in order.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// usageError prints a usage error message to stderr and returns ExitUsage.
+func usageError(stderr io.Writer, message string, args ...interface{}) int {
+	fmt.Fprintf(stderr, "flexpool: "+message+"\n", args...)
+	fmt.Fprintf(stderr, "Run 'flexpool help' for usage.\n")
+
+	return ExitUsage
+}
+
+// formatArgs formats positional argument names for usage output.
+func formatArgs(args []string) string {
+	formatted := make([]string, len(args))
+
+	for i, arg := range args {
+		formatted[i] = "<" + arg + ">"
+	}
+
+	return strings.Join(formatted, " ")
+}
+
+// printUsage prints the top-level usage, listing every command.
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: flexpool [--output table|json|csv|yaml] [--config file] <command> <subcommand> [arguments]\n\n")
+
+	for _, groupName := range groupOrder {
+		printGroupUsage(w, groupName, groups[groupName])
+	}
+
+	fmt.Fprintf(w, "top - Live dashboard of a miner's workers\n")
+	fmt.Fprintf(w, "  %-48s %s\n", "<address> [--interval 30s] [--stale-threshold 0.05]", "Refresh worker stats until q is pressed")
+
+	fmt.Fprintf(w, "\nblocks - Pool or miner blocks, filtered, sorted and aggregated\n")
+	fmt.Fprintf(w, "  %-48s %s\n", "[address] [--type block|uncle] [--server eu1] ...", "Blocks matching the filters, newest first")
+	fmt.Fprintf(w, "  %-48s %s\n", "[address] --summary", "Counts, rewards, fee share and uncle rate per server")
+	fmt.Fprintf(w, "  %-48s %s\n", "[address] --regions", "Per-region stats against each region's hashrate share")
+
+	fmt.Fprintf(w, "\ngroups - Worker stats aggregated by the groups in the config file\n")
+	fmt.Fprintf(w, "  %-48s %s\n", "<address> [--chart group]", "Stats per group, or a group's merged chart data")
+
+	fmt.Fprintf(w, "\nconfig - Config file commands\n")
+	fmt.Fprintf(w, "  %-48s %s\n", "validate [file]", "Check a config file, reporting problems with line numbers")
+	fmt.Fprintf(w, "  %-48s %s\n", "show", "Print the config after environment variable overrides")
+}
+
+// printGroupUsage prints the subcommands of a single top-level command.
+func printGroupUsage(w io.Writer, groupName string, group commandGroup) {
+	fmt.Fprintf(w, "%s - %s\n", groupName, group.Summary)
+
+	for _, name := range group.Order {
+		cmd := group.Commands[name]
+		usage := strings.TrimSpace(name + " " + formatArgs(cmd.Args))
+
+		if cmd.Paged {
+			usage += " [--page n]"
+		}
+
+		fmt.Fprintf(w, "  %-48s %s\n", usage, cmd.Summary)
+	}
+
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	// Stand in for the flexpool API, failing the miners online count
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := "null"
+
+		switch r.URL.Path {
+		case "/miner/" + address + "/balance":
+			result = "2000000000"
+		case "/pool/hashrate":
+			result = `{"as": 1, "au": 2, "eu": 3, "sa": 4, "us": 5, "total": 15}`
+		case "/pool/minersOnline":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error": {"code": 500, "message": "internal error"}, "result": null}`))
+
+			return
+		}
+
+		w.Write([]byte(`{"error": null, "result": ` + result + `}`))
+	}))
+	defer upstream.Close()
+
+	dir := t.TempDir()
+	write := func(name string, data string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatalf("WriteFile failed with: %v", err)
+		}
+
+		return path
+	}
+
+	setenv := func(key string, value string) {
+		previous, ok := os.LookupEnv(key)
+		os.Setenv(key, value)
+
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(key, previous)
+			} else {
+				os.Unsetenv(key)
+			}
+		})
+	}
+
+	// Every run reads the same config, rather than whatever is in the user's config directory
+	setenv("FLEXPOOL_CONFIG", write("config.json", `{"addresses": {"home": "`+address+`"}}`))
+	setenv("FLEXPOOL_API_HOST", upstream.URL)
+	setenv("FLEXPOOL_OUTPUT_FORMAT", "")
+
+	yamlConfig := write("yaml.json", `{"output": {"format": "yaml"}}`)
+	badConfig := write("bad.json", `{"addresses": {"home": "0x12"}}`)
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{"NoArgs", nil, ExitUsage, "", "Usage: flexpool"},
+		{"Help", []string{"help"}, ExitOK, "Usage: flexpool", ""},
+		{"HelpFlag", []string{"-h"}, ExitOK, "", "Usage: flexpool"},
+		{"UnknownFlag", []string{"--bogus"}, ExitUsage, "", "flag provided but not defined"},
+		{"UnknownCommand", []string{"nope"}, ExitUsage, "", `unknown command "nope"`},
+		{"MissingSubcommand", []string{"pool"}, ExitUsage, "", "pool - Query pool-wide stats"},
+		{"UnknownSubcommand", []string{"pool", "nope"}, ExitUsage, "", `unknown pool command "nope"`},
+		{"ExtraArgument", []string{"pool", "hashrate", "extra"}, ExitUsage, "", "pool hashrate takes 0 argument(s)"},
+		{"MissingArgument", []string{"miner", "balance"}, ExitUsage, "", "miner balance takes 1 argument(s)"},
+		{"UnknownOutput", []string{"--output", "xml", "pool", "hashrate"}, ExitUsage, "", `unknown output format "xml"`},
+		{"TextOutput", []string{"pool", "hashrate", "--output", "text"}, ExitUsage, "", `unknown output format "text"`},
+		{"Table", []string{"pool", "hashrate"}, ExitOK, "total", ""},
+		{"JSONAfterArgs", []string{"pool", "hashrate", "--output", "json"}, ExitOK, `"total": 15`, ""},
+		{"NamedAddress", []string{"miner", "balance", "home", "--output", "json"}, ExitOK, "2", ""},
+		{"ConfigOutput", []string{"--config", yamlConfig, "pool", "hashrate"}, ExitOK, "total: 15", ""},
+		{"APIError", []string{"pool", "miners-online"}, ExitError, "", "pool miners-online failed: api: internal error"},
+		{"BrokenConfig", []string{"--config", badConfig, "pool", "hashrate"}, ExitError, "", "unable to load config"},
+		{"ConfigValidate", []string{"config", "validate"}, ExitOK, "config.json: ok", ""},
+		{"ConfigValidateBroken", []string{"config", "validate", badConfig}, ExitError, "", `"0x12" is not a valid address`},
+		{"ConfigMissingSubcommand", []string{"config"}, ExitUsage, "", "config takes a subcommand"},
+		{"BlocksInvalidType", []string{"blocks", "--type", "orphan"}, ExitUsage, "", "block type must be block or uncle"},
+		{"BlocksSummaryAndRegions", []string{"blocks", "--summary", "--regions"}, ExitUsage, "", "can't be used together"},
+		{"BlocksInvalidSince", []string{"blocks", "--since", "yesterday"}, ExitUsage, "", "invalid --since"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			if code := run(test.args, &stdout, &stderr); code != test.code {
+				t.Errorf("run(%q) = %d, want %d\nstdout: %s\nstderr: %s", test.args, code, test.code, stdout.String(), stderr.String())
+			}
+
+			if !strings.Contains(stdout.String(), test.stdout) {
+				t.Errorf("stdout = %q, want it to contain %q", stdout.String(), test.stdout)
+			}
+
+			if !strings.Contains(stderr.String(), test.stderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), test.stderr)
+			}
+		})
+	}
+}
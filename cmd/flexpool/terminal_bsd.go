@@ -0,0 +1,54 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package main
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// Terminal ioctl requests for darwin and the BSDs.
+const (
+	ioctlGetTermios = syscall.TIOCGETA
+	ioctlSetTermios = syscall.TIOCSETA
+)
+
+// makeRaw puts the terminal into raw mode so key presses are read immediately and not echoed. Returns a function that
+// restores the previous mode and nil on success, or nil and error if the file descriptor isn't a terminal.
+func makeRaw(fd int) (func(), error) {
+	var previous syscall.Termios
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlGetTermios, uintptr(unsafe.Pointer(&previous))); errno != 0 {
+		return nil, errno
+	}
+
+	raw := previous
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlSetTermios, uintptr(unsafe.Pointer(&raw))); errno != 0 {
+		return nil, errno
+	}
+
+	return func() {
+		syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlSetTermios, uintptr(unsafe.Pointer(&previous)))
+	}, nil
+}
+
+// terminalSize returns the width and height of the terminal in characters.
+func terminalSize(fd int) (int, int, error) {
+	var size struct {
+		rows, cols, xPixels, yPixels uint16
+	}
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size))); errno != 0 {
+		return 0, 0, errno
+	}
+
+	return int(size.cols), int(size.rows), nil
+}
@@ -0,0 +1,22 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package main
+
+import (
+	"errors"
+)
+
+// errNoRawMode is returned on platforms where the terminal can't be put into raw mode. The top command still works, but
+// keys have to be followed by enter.
+var errNoRawMode = errors.New("raw terminal mode is not supported on this platform")
+
+// makeRaw is unsupported on this platform.
+func makeRaw(fd int) (func(), error) {
+	return nil, errNoRawMode
+}
+
+// terminalSize is unsupported on this platform, so callers fall back to a default size.
+func terminalSize(fd int) (int, int, error) {
+	return 0, 0, errNoRawMode
+}
@@ -0,0 +1,718 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/utils"
+)
+
+// ANSI escape sequences used by the top command.
+const (
+	ansiAltScreenOn  = "\x1b[?1049h"
+	ansiAltScreenOff = "\x1b[?1049l"
+	ansiHideCursor   = "\x1b[?25l"
+	ansiShowCursor   = "\x1b[?25h"
+	ansiClear        = "\x1b[H\x1b[2J"
+	ansiReset        = "\x1b[0m"
+	ansiBold         = "\x1b[1m"
+	ansiReverse      = "\x1b[7m"
+	ansiRed          = "\x1b[31m"
+	ansiYellow       = "\x1b[33m"
+	ansiDim          = "\x1b[2m"
+)
+
+// Default terminal size used when it can't be detected.
+const (
+	defaultTerminalWidth  = 120
+	defaultTerminalHeight = 30
+)
+
+// topConcurrency is how many workers the top command fetches stats and charts for at once.
+const topConcurrency = 4
+
+// topKey identifies a key press the top command responds to.
+type topKey int
+
+// Keys handled by the top command.
+const (
+	keyNone topKey = iota
+	keyQuit
+	keyUp
+	keyDown
+	keyEnter
+	keyBack
+	keySort
+	keyReverse
+	keyRefresh
+)
+
+// sparkBlocks are the characters used to draw sparklines, from lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// topColumn is a sortable column of the worker table.
+type topColumn struct {
+	title string
+	less  func(a, b api.MinerWorker) bool
+}
+
+// topColumns are the sortable columns of the worker table, in display order.
+var topColumns = []topColumn{
+	{"NAME", func(a, b api.MinerWorker) bool { return a.Name < b.Name }},
+	{"EFFECTIVE", func(a, b api.MinerWorker) bool { return a.EffectiveHashrate < b.EffectiveHashrate }},
+	{"REPORTED", func(a, b api.MinerWorker) bool { return a.ReportedHashrate < b.ReportedHashrate }},
+	{"VALID", func(a, b api.MinerWorker) bool { return a.ValidShares < b.ValidShares }},
+	{"STALE%", func(a, b api.MinerWorker) bool { return staleRatio(a) < staleRatio(b) }},
+	{"INVALID", func(a, b api.MinerWorker) bool { return a.InvalidShares < b.InvalidShares }},
+	{"LAST SEEN", func(a, b api.MinerWorker) bool { return a.LastSeen < b.LastSeen }},
+}
+
+// topSnapshot contains the data shown by the top command. Each refresh starts from the previous snapshot, so a failed
+// request leaves the last good value in place and records the error instead. UpdatedAt is the last refresh where any
+// request succeeded, and AttemptedAt the last refresh at all.
+type topSnapshot struct {
+	Workers     []api.MinerWorker
+	Current     api.WorkerCurrentStats
+	Balance     uint
+	RoundShare  float64
+	Stats       map[string]api.WorkerStats
+	Charts      map[string][]api.WorkerChartData
+	UpdatedAt   time.Time
+	AttemptedAt time.Time
+	Errors      []string
+}
+
+// topView contains the state of the top command's display.
+type topView struct {
+	address        string
+	interval       time.Duration
+	staleThreshold float64
+	snapshot       topSnapshot
+	loading        bool
+	sortColumn     int
+	reverse        bool
+	selected       int
+	detail         bool
+	rawMode        bool
+}
+
+// runTop runs the interactive top command for a single miner, refreshing on an interval until the user quits.
+func runTop(args []string, cfg config.Config, stdout io.Writer, stderr io.Writer) int {
+	view := topView{sortColumn: 1, reverse: true}
+
+	flags := flag.NewFlagSet("flexpool top", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.DurationVar(&view.interval, "interval", 30*time.Second, "How often to refresh data from the API")
+	flags.Float64Var(&view.staleThreshold, "stale-threshold", cfg.Thresholds.MaxStaleRatio, "Stale share ratio above which a worker is highlighted")
+	flags.String("config", "", "Path to a config file")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: flexpool top <address> [--interval 30s] [--stale-threshold 0.05]\n\n")
+		fmt.Fprintf(stderr, "Live-updating dashboard of a miner's workers\n\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+
+		return ExitUsage
+	}
+
+	if len(positional) != 1 {
+		return usageError(stderr, "top takes 1 argument(s): <address>")
+	}
+
+	if view.interval < time.Second {
+		return usageError(stderr, "interval must be at least 1s")
+	}
+
+	view.address = cfg.Address(positional[0])
+
+	// Ctrl+C without raw mode, or a SIGTERM, quits through the same path as q so the terminal is restored
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Without raw mode the dashboard still works, but keys need to be followed by enter
+	if restore, err := makeRaw(int(os.Stdin.Fd())); err == nil {
+		view.rawMode = true
+		defer restore()
+	}
+
+	fmt.Fprint(stdout, ansiAltScreenOn+ansiHideCursor)
+	defer fmt.Fprint(stdout, ansiShowCursor+ansiAltScreenOff)
+
+	keys := make(chan topKey)
+	go readKeys(os.Stdin, keys)
+
+	updates := make(chan topSnapshot, 1)
+	refresh := func() {
+		view.loading = true
+
+		go func(previous topSnapshot) {
+			updates <- fetchSnapshot(ctx, view.address, previous)
+		}(view.snapshot)
+	}
+
+	refresh()
+
+	ticker := time.NewTicker(view.interval)
+	defer ticker.Stop()
+
+	for {
+		width, height, err := terminalSize(int(os.Stdout.Fd()))
+		if err != nil || width <= 0 || height <= 0 {
+			width, height = defaultTerminalWidth, defaultTerminalHeight
+		}
+
+		fmt.Fprint(stdout, ansiClear+view.render(width, height))
+
+		select {
+		case <-ctx.Done():
+			return ExitOK
+		case key := <-keys:
+			if key == keyQuit {
+				return ExitOK
+			}
+
+			if key == keyRefresh && !view.loading {
+				refresh()
+			}
+
+			view.handleKey(key)
+		case snapshot := <-updates:
+			view.snapshot = snapshot
+			view.loading = false
+		case <-ticker.C:
+			if !view.loading {
+				refresh()
+			}
+		}
+	}
+}
+
+// fetchSnapshot takes a context, a mining wallet address and the previous snapshot, and refreshes every value shown by
+// the top command. Worker stats and charts are fetched in parallel, and any request that fails keeps its last good value.
+func fetchSnapshot(ctx context.Context, address string, previous topSnapshot) topSnapshot {
+	snapshot := previous
+	snapshot.Errors = nil
+	snapshot.AttemptedAt = time.Now()
+
+	client := api.DefaultClient.WithContext(ctx)
+	miners := client.Miner()
+	succeeded := false
+
+	record := func(what string, err error) {
+		snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("%s: %v", what, err))
+	}
+
+	details, err := api.GetWorkerDetails(ctx, miners, client.Worker(), address, api.WorkerDetailsOptions{Concurrency: topConcurrency})
+	if err != nil {
+		record("workers", err)
+	} else {
+		succeeded = true
+		snapshot.Workers = make([]api.MinerWorker, 0, len(details))
+		snapshot.Stats = make(map[string]api.WorkerStats, len(details))
+		snapshot.Charts = make(map[string][]api.WorkerChartData, len(details))
+
+		names := make([]string, 0, len(details))
+		for name := range details {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			worker := details[name]
+			snapshot.Workers = append(snapshot.Workers, worker.Worker)
+
+			if worker.Err == nil {
+				snapshot.Stats[name], snapshot.Charts[name] = worker.Stats, worker.Chart
+				continue
+			}
+
+			// A failed worker keeps its previous stats and chart, if it had any
+			record("worker "+name, worker.Err)
+
+			if stats, ok := previous.Stats[name]; ok {
+				snapshot.Stats[name] = stats
+			}
+
+			if chart, ok := previous.Charts[name]; ok {
+				snapshot.Charts[name] = chart
+			}
+		}
+	}
+
+	if current, err := miners.GetCurrent(address); err == nil {
+		snapshot.Current = current
+		succeeded = true
+	} else {
+		record("current hashrate", err)
+	}
+
+	if balance, err := miners.GetBalance(address); err == nil {
+		snapshot.Balance = balance
+		succeeded = true
+	} else {
+		record("balance", err)
+	}
+
+	if roundShare, err := miners.GetRoundShare(address); err == nil {
+		snapshot.RoundShare = roundShare
+		succeeded = true
+	} else {
+		record("round share", err)
+	}
+
+	if succeeded {
+		snapshot.UpdatedAt = snapshot.AttemptedAt
+	}
+
+	return snapshot
+}
+
+// readKeys reads key presses from r and sends them to keys until r is closed.
+func readKeys(r io.Reader, keys chan<- topKey) {
+	buf := make([]byte, 16)
+
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			keys <- keyQuit
+			return
+		}
+
+		input := string(buf[:n])
+
+		// Without raw mode every key arrives followed by a newline, which shouldn't also open the detail view
+		if len(input) > 1 {
+			input = strings.TrimSuffix(input, "\n")
+		}
+
+		// Arrow keys arrive as escape sequences, everything else is handled byte by byte
+		switch input {
+		case "\x1b[A", "\x1bOA":
+			keys <- keyUp
+			continue
+		case "\x1b[B", "\x1bOB":
+			keys <- keyDown
+			continue
+		case "\x1b":
+			keys <- keyBack
+			continue
+		}
+
+		if strings.HasPrefix(input, "\x1b") {
+			continue
+		}
+
+		for _, b := range []byte(input) {
+			if key := parseKey(b); key != keyNone {
+				keys <- key
+			}
+		}
+	}
+}
+
+// parseKey maps a single byte of input to a topKey.
+func parseKey(b byte) topKey {
+	switch b {
+	case 'q', 'Q', 3: // 3 is ctrl+c, which doesn't raise a signal in raw mode
+		return keyQuit
+	case 'k':
+		return keyUp
+	case 'j':
+		return keyDown
+	case '\r', '\n':
+		return keyEnter
+	case 'b', 127:
+		return keyBack
+	case 's':
+		return keySort
+	case 'r':
+		return keyReverse
+	case 'u':
+		return keyRefresh
+	}
+
+	return keyNone
+}
+
+// handleKey updates the view for a key press.
+func (v *topView) handleKey(key topKey) {
+	switch key {
+	case keyUp:
+		if v.selected > 0 {
+			v.selected--
+		}
+	case keyDown:
+		if v.selected < len(v.snapshot.Workers)-1 {
+			v.selected++
+		}
+	case keyEnter:
+		v.detail = len(v.snapshot.Workers) > 0
+	case keyBack:
+		v.detail = false
+	case keySort:
+		v.sortColumn = (v.sortColumn + 1) % len(topColumns)
+	case keyReverse:
+		v.reverse = !v.reverse
+	}
+}
+
+// sortedWorkers returns the workers sorted by the selected column.
+func (v *topView) sortedWorkers() []api.MinerWorker {
+	workers := append([]api.MinerWorker(nil), v.snapshot.Workers...)
+	less := topColumns[v.sortColumn].less
+
+	sort.SliceStable(workers, func(i, j int) bool {
+		if v.reverse {
+			return less(workers[j], workers[i])
+		}
+
+		return less(workers[i], workers[j])
+	})
+
+	if v.selected >= len(workers) {
+		v.selected = len(workers) - 1
+	}
+
+	if v.selected < 0 {
+		v.selected = 0
+	}
+
+	return workers
+}
+
+// render draws the whole screen for the given terminal size.
+func (v *topView) render(width int, height int) string {
+	var lines []string
+
+	snapshot := v.snapshot
+	online := 0
+	for _, worker := range snapshot.Workers {
+		if worker.Online {
+			online++
+		}
+	}
+
+	lines = append(lines, ansiBold+truncate(fmt.Sprintf("flexpool top - %s", v.address), width)+ansiReset)
+	lines = append(lines, truncate(fmt.Sprintf("Balance: %.8f eth   Round share: %.8f%%   Hashrate: %s effective, %s reported   Workers: %d online, %d offline",
+		utils.ConvertGweiToEth(snapshot.Balance),
+		snapshot.RoundShare,
+		formatMHs(snapshot.Current.EffectiveHashrate),
+		formatMHs(snapshot.Current.ReportedHashrate),
+		online,
+		len(snapshot.Workers)-online), width))
+
+	status := "Loading..."
+	switch {
+	case !snapshot.UpdatedAt.IsZero():
+		status = fmt.Sprintf("Updated %s, refreshing every %s", snapshot.UpdatedAt.Format("15:04:05"), v.interval)
+	case !snapshot.AttemptedAt.IsZero():
+		status = fmt.Sprintf("No successful update yet, retrying every %s", v.interval)
+	}
+
+	if len(snapshot.Errors) > 0 {
+		status += fmt.Sprintf(" - %d request(s) failed at %s", len(snapshot.Errors), snapshot.AttemptedAt.Format("15:04:05"))
+	}
+
+	if v.loading && !snapshot.AttemptedAt.IsZero() {
+		status += " (refreshing...)"
+	}
+
+	lines = append(lines, ansiDim+truncate(status, width)+ansiReset)
+
+	for _, message := range snapshot.Errors {
+		lines = append(lines, ansiRed+truncate("API error, showing last good data - "+message, width)+ansiReset)
+	}
+
+	lines = append(lines, "")
+
+	workers := v.sortedWorkers()
+	if v.detail && len(workers) > 0 {
+		lines = append(lines, v.renderDetail(workers[v.selected], width)...)
+	} else {
+		lines = append(lines, v.renderTable(workers, width, height-len(lines)-2)...)
+	}
+
+	help := "up/down select   enter details   s sort   r reverse   u refresh   q quit"
+	if v.detail {
+		help = "esc/b back   u refresh   q quit"
+	}
+
+	if !v.rawMode {
+		help += "   (press enter after each key)"
+	}
+
+	if len(lines) > height-1 {
+		lines = lines[:height-1]
+	}
+
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, ansiDim+truncate(help, width)+ansiReset)
+
+	return strings.Join(lines, "\r\n")
+}
+
+// renderTable draws the worker table, scrolling to keep the selected worker within maxRows rows.
+func (v *topView) renderTable(workers []api.MinerWorker, width int, maxRows int) []string {
+	if len(workers) == 0 {
+		return []string{"No workers found."}
+	}
+
+	nameWidth := 4
+	for _, worker := range workers {
+		if l := utf8.RuneCountInString(worker.Name); l > nameWidth {
+			nameWidth = l
+		}
+	}
+
+	if nameWidth > 24 {
+		nameWidth = 24
+	}
+
+	rowFormat := fmt.Sprintf("%%-%ds  %%12s  %%12s  %%8s  %%7s  %%8s  %%9s  ", nameWidth)
+	fixedWidth := nameWidth + 12 + 12 + 8 + 7 + 8 + 9 + 14
+	sparkWidth := width - fixedWidth
+	if sparkWidth > 48 {
+		sparkWidth = 48
+	}
+
+	titles := make([]interface{}, len(topColumns))
+	for i, column := range topColumns {
+		titles[i] = column.title
+		if i == v.sortColumn {
+			arrow := "^"
+			if v.reverse {
+				arrow = "v"
+			}
+
+			titles[i] = column.title + arrow
+		}
+	}
+
+	header := fmt.Sprintf(rowFormat, titles...)
+	if sparkWidth > 0 {
+		header += "CHART"
+	}
+
+	lines := []string{ansiBold + truncate(header, width) + ansiReset}
+
+	// Scroll so the selected row is always visible
+	if maxRows < 2 {
+		maxRows = 2
+	}
+
+	first := 0
+	if v.selected >= first+maxRows-1 {
+		first = v.selected - maxRows + 2
+	}
+
+	for i := first; i < len(workers) && i < first+maxRows-1; i++ {
+		worker := workers[i]
+
+		row := fmt.Sprintf(rowFormat,
+			truncate(worker.Name, nameWidth),
+			formatMHs(worker.EffectiveHashrate),
+			formatMHs(worker.ReportedHashrate),
+			fmt.Sprint(worker.ValidShares),
+			fmt.Sprintf("%.2f", staleRatio(worker)*100),
+			fmt.Sprint(worker.InvalidShares),
+			formatLastSeen(worker.LastSeen))
+
+		if sparkWidth > 0 {
+			row += sparkline(chartHashrates(v.snapshot.Charts[worker.Name], false), sparkWidth)
+		}
+
+		lines = append(lines, v.colour(worker, i == v.selected)+truncate(row, width)+ansiReset)
+	}
+
+	return lines
+}
+
+// renderDetail draws the detail view for a single worker.
+func (v *topView) renderDetail(worker api.MinerWorker, width int) []string {
+	chart := v.snapshot.Charts[worker.Name]
+	effective := chartHashrates(chart, false)
+	reported := chartHashrates(chart, true)
+
+	status := "online"
+	if !worker.Online {
+		status = "offline"
+	}
+
+	totalShares := worker.ValidShares + worker.StaleShares + worker.InvalidShares
+	invalidRatio := 0.0
+	if totalShares > 0 {
+		invalidRatio = float64(worker.InvalidShares) / float64(totalShares)
+	}
+
+	lines := []string{
+		v.colour(worker, false) + ansiBold + truncate(fmt.Sprintf("Worker %s (%s)", worker.Name, status), width) + ansiReset,
+		"",
+		truncate(fmt.Sprintf("Effective hashrate: %s   Reported hashrate: %s", formatMHs(worker.EffectiveHashrate), formatMHs(worker.ReportedHashrate)), width),
+		truncate(fmt.Sprintf("Shares: %d valid, %d stale (%.2f%%), %d invalid (%.2f%%)",
+			worker.ValidShares, worker.StaleShares, staleRatio(worker)*100, worker.InvalidShares, invalidRatio*100), width),
+		truncate(fmt.Sprintf("Last seen: %s   Duplicate workers merged: %d", formatLastSeen(worker.LastSeen), worker.DuplicateWorkersMerged), width),
+	}
+
+	if stats, ok := v.snapshot.Stats[worker.Name]; ok {
+		daily := stats.Daily
+		lines = append(lines, truncate(fmt.Sprintf("Last 24 hours: %s effective, %s reported, %d valid, %d stale, %d invalid shares",
+			formatMHs(daily.EffectiveHashrate), formatMHs(daily.ReportedHashrate), daily.ValidShares, daily.StaleShares, daily.InvalidShares), width))
+	}
+
+	lines = append(lines, "")
+
+	if len(chart) == 0 {
+		return append(lines, "No chart data available.")
+	}
+
+	minimum, average, maximum := summarise(effective)
+	sparkWidth := width - 12
+
+	lines = append(lines,
+		truncate(fmt.Sprintf("Chart (%d points): min %s, avg %s, max %s", len(chart), formatMHs(uint(minimum)), formatMHs(uint(average)), formatMHs(uint(maximum))), width),
+		"Effective: "+sparkline(effective, sparkWidth),
+		"Reported:  "+sparkline(reported, sparkWidth))
+
+	return lines
+}
+
+// colour returns the escape sequence to start a worker's row - red when offline, yellow when its stale ratio is above the
+// threshold, and reversed when selected.
+func (v *topView) colour(worker api.MinerWorker, selected bool) string {
+	colour := ""
+
+	if !worker.Online {
+		colour = ansiRed
+	} else if staleRatio(worker) > v.staleThreshold {
+		colour = ansiYellow
+	}
+
+	if selected {
+		colour += ansiReverse
+	}
+
+	return colour
+}
+
+// staleRatio returns the fraction of a worker's shares that were stale.
+func staleRatio(worker api.MinerWorker) float64 {
+	total := worker.ValidShares + worker.StaleShares + worker.InvalidShares
+	if total == 0 {
+		return 0
+	}
+
+	return float64(worker.StaleShares) / float64(total)
+}
+
+// chartHashrates returns the effective or reported hashrates of chart data, oldest first.
+func chartHashrates(chart []api.WorkerChartData, reported bool) []float64 {
+	points := append([]api.WorkerChartData(nil), chart...)
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp < points[j].Timestamp
+	})
+
+	values := make([]float64, len(points))
+	for i, point := range points {
+		values[i] = float64(point.EffectiveHashrate)
+		if reported {
+			values[i] = float64(point.ReportedHashrate)
+		}
+	}
+
+	return values
+}
+
+// sparkline draws the last width values as a sparkline scaled between their minimum and maximum.
+func sparkline(values []float64, width int) string {
+	if width <= 0 || len(values) == 0 {
+		return ""
+	}
+
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	minimum, _, maximum := summarise(values)
+
+	var builder strings.Builder
+	for _, value := range values {
+		level := 0
+		if maximum > minimum {
+			level = int((value - minimum) / (maximum - minimum) * float64(len(sparkBlocks)-1))
+		}
+
+		builder.WriteRune(sparkBlocks[level])
+	}
+
+	return builder.String()
+}
+
+// summarise returns the minimum, average and maximum of a slice of values.
+func summarise(values []float64) (float64, float64, float64) {
+	if len(values) == 0 {
+		return 0, 0, 0
+	}
+
+	minimum, maximum, total := math.Inf(1), math.Inf(-1), 0.0
+	for _, value := range values {
+		minimum = math.Min(minimum, value)
+		maximum = math.Max(maximum, value)
+		total += value
+	}
+
+	return minimum, total / float64(len(values)), maximum
+}
+
+// formatMHs formats a hashrate in hashes per second as MH/s.
+func formatMHs(hashrate uint) string {
+	return fmt.Sprintf("%.1f MH/s", float64(hashrate)/math.Pow10(utils.MegaPow10Exponential))
+}
+
+// formatLastSeen formats a unix timestamp as a time of day, or a date if it wasn't today.
+func formatLastSeen(timestamp int) string {
+	if timestamp == 0 {
+		return "never"
+	}
+
+	seen := time.Unix(int64(timestamp), 0)
+	if time.Since(seen) > 24*time.Hour {
+		return seen.Format("2006-01-02")
+	}
+
+	return seen.Format("15:04:05")
+}
+
+// truncate shortens a string to at most width runes.
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+
+	return string([]rune(s)[:width])
+}
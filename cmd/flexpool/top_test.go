@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+func TestTop(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	const (
+		worker = `"online": true, "duplicate_workers_merged": 0, "reported_hashrate": 2, "effective_hashrate": 1, ` +
+			`"valid_shares": 10, "stale_shares": 1, "invalid_shares": 0, "last_seen": 1000`
+		hashrates = `"effective_hashrate": 1, "reported_hashrate": 2`
+		shares    = `"valid_shares": 10, "stale_shares": 1, "invalid_shares": 0`
+	)
+
+	var (
+		mu   sync.Mutex
+		down bool
+	)
+
+	// Serves two workers, with the second's stats and the balance always failing
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		failing := down
+		mu.Unlock()
+
+		results := map[string]string{
+			"/miner/" + address + "/workers":     `[{"name": "rig2", ` + worker + `}, {"name": "rig1", ` + worker + `}]`,
+			"/miner/" + address + "/current":     `{` + hashrates + `}`,
+			"/miner/" + address + "/roundShare":  `0.5`,
+			"/worker/" + address + "/rig1/stats": `{"current": {` + hashrates + `}, "daily": {` + hashrates + `, ` + shares + `}}`,
+			"/worker/" + address + "/rig1/chart": `[{"timestamp": 1, ` + hashrates + `, "average_effective_hashrate": 1, ` + shares + `}]`,
+		}
+
+		result, ok := results[r.URL.Path]
+		if failing || !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error": {"code": 500, "message": "internal error"}, "result": null}`))
+
+			return
+		}
+
+		w.Write([]byte(`{"error": null, "result": ` + result + `}`))
+	}))
+	defer upstream.Close()
+
+	host := api.DefaultClient.Host
+	api.DefaultClient.Host = upstream.URL
+	defer func() { api.DefaultClient.Host = host }()
+
+	setDown := func(value bool) {
+		mu.Lock()
+		down = value
+		mu.Unlock()
+	}
+
+	t.Run("Partial", func(t *testing.T) {
+		setDown(false)
+		snapshot := fetchSnapshot(context.Background(), address, topSnapshot{})
+
+		if len(snapshot.Workers) != 2 || snapshot.Workers[0].Name != "rig1" || snapshot.Workers[1].Name != "rig2" {
+			t.Errorf("Workers = %+v, want rig1 and rig2 sorted by name", snapshot.Workers)
+		}
+
+		if len(snapshot.Charts["rig1"]) != 1 || snapshot.Stats["rig1"].Daily.ValidShares != 10 {
+			t.Errorf("rig1 stats = %+v, chart = %+v, want both fetched", snapshot.Stats["rig1"], snapshot.Charts["rig1"])
+		}
+
+		if _, ok := snapshot.Stats["rig2"]; ok {
+			t.Errorf("rig2 stats = %+v, want none after a failed first fetch", snapshot.Stats["rig2"])
+		}
+
+		if len(snapshot.Errors) != 2 || !strings.HasPrefix(snapshot.Errors[0], "worker rig2: stats: ") ||
+			!strings.HasPrefix(snapshot.Errors[1], "balance: ") {
+			t.Errorf("Errors = %q, want rig2's stats and the balance", snapshot.Errors)
+		}
+
+		// A partial refresh still counts as an update
+		if snapshot.UpdatedAt.IsZero() || !snapshot.UpdatedAt.Equal(snapshot.AttemptedAt) {
+			t.Errorf("UpdatedAt = %v, want the attempt at %v", snapshot.UpdatedAt, snapshot.AttemptedAt)
+		}
+
+		view := topView{address: address, snapshot: snapshot}
+		if screen := view.render(200, 30); !strings.Contains(screen, "Updated ") || !strings.Contains(screen, "2 request(s) failed") {
+			t.Errorf("render = %q, want the update time and failures", screen)
+		}
+	})
+
+	t.Run("Outage", func(t *testing.T) {
+		setDown(false)
+		previous := fetchSnapshot(context.Background(), address, topSnapshot{})
+
+		setDown(true)
+		snapshot := fetchSnapshot(context.Background(), address, previous)
+
+		if !snapshot.UpdatedAt.Equal(previous.UpdatedAt) {
+			t.Errorf("UpdatedAt = %v, want the last good update at %v", snapshot.UpdatedAt, previous.UpdatedAt)
+		}
+
+		if len(snapshot.Workers) != 2 || len(snapshot.Charts["rig1"]) != 1 || snapshot.RoundShare != 0.5 {
+			t.Errorf("snapshot = %+v, want the last good data kept", snapshot)
+		}
+
+		if len(snapshot.Errors) != 4 {
+			t.Errorf("Errors = %q, want every request to fail", snapshot.Errors)
+		}
+	})
+
+	t.Run("NeverUpdated", func(t *testing.T) {
+		setDown(true)
+		snapshot := fetchSnapshot(context.Background(), address, topSnapshot{})
+
+		if !snapshot.UpdatedAt.IsZero() || snapshot.AttemptedAt.IsZero() {
+			t.Errorf("UpdatedAt = %v, AttemptedAt = %v, want only an attempt", snapshot.UpdatedAt, snapshot.AttemptedAt)
+		}
+
+		view := topView{address: address, snapshot: snapshot}
+		if screen := view.render(200, 30); strings.Contains(screen, "Loading...") || !strings.Contains(screen, "No successful update yet") {
+			t.Errorf("render = %q, want no successful update instead of loading", screen)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		setDown(false)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		snapshot := fetchSnapshot(ctx, address, topSnapshot{})
+		if !snapshot.UpdatedAt.IsZero() || len(snapshot.Errors) != 4 {
+			t.Errorf("snapshot = %+v, want every request cancelled", snapshot)
+		}
+	})
+}
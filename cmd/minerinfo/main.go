@@ -3,152 +3,125 @@ package main
 import (
 	"flag"
 	"fmt"
-	"github.com/cryptogenic/goflexpool/pkg/api"
-	"github.com/cryptogenic/goflexpool/pkg/utils"
 	"os"
-	"time"
+	"strings"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/format"
 )
 
+// formats are the output formats this binary can write.
+var formats = []string{format.Text, format.JSON, format.CSV}
+
 func main() {
 	var (
-		err                error
-		balanceGwei        uint
-		metaDetails        api.MinerDetails
-		roundShare         float64
-		dailyEstimatedGwei uint
-		totalPaidGwei      uint
-		totalDonatedGwei   uint
-		workers            []api.MinerWorker
-		paymentData        api.MinerPaymentData
-		blockData          api.MinerBlockData
+		report      format.MinerReport
+		metaDetails api.MinerDetails
+		paymentData api.MinerPaymentData
+		blockData   api.MinerBlockData
 
 		minerAddress string
+		outputFormat string
 	)
 
+	// Flags fall back to the config file and environment
+	cfg, err := config.Setup(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load config: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	// Formats this binary can't write, such as yaml, keep the text layout when they come from the config
+	defaultFormat := format.Text
+	if format.Supported(cfg.Output.Format, formats...) {
+		defaultFormat = cfg.Output.Format
+	}
+
 	// Take an address to check from argument
-	flag.StringVar(&minerAddress, "address", "", "Mining wallet address")
+	flag.StringVar(&minerAddress, "address", cfg.DefaultAddress, "Mining wallet address, or a name from the config file")
+	flag.StringVar(&outputFormat, "format", defaultFormat, "Output format: text, json or csv")
+	flag.String("config", "", "Path to a config file")
 	flag.Parse()
 
+	minerAddress = cfg.Address(minerAddress)
+
 	if minerAddress == "" {
-		fmt.Printf("No address given, exiting.\n")
+		fmt.Fprintf(os.Stderr, "No address given, exiting.\n")
 		os.Exit(1)
 	}
 
-	// Get balance
-	if balanceGwei, err = api.MinerGetBalance(minerAddress); err != nil {
-		fmt.Printf("Unable to get wallet balance: %v\n", err.Error())
+	if !format.Supported(outputFormat, formats...) {
+		fmt.Fprintf(os.Stderr, "Unknown format '%s', must be one of %s.\n", outputFormat, strings.Join(formats, ", "))
 		os.Exit(1)
 	}
 
-	balanceEth := utils.ConvertGweiToEth(balanceGwei)
+	report.Address = minerAddress
+
+	// Get balance
+	if report.BalanceGwei, err = api.MinerGetBalance(minerAddress); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to get wallet balance: %v\n", err.Error())
+		os.Exit(1)
+	}
 
 	// Get meta details
 	if metaDetails, err = api.MinerGetDetails(minerAddress); err != nil {
-		fmt.Printf("Unable to get wallet details: %v\n", err.Error())
+		fmt.Fprintf(os.Stderr, "Unable to get wallet details: %v\n", err.Error())
 		os.Exit(1)
 	}
 
+	report.MinPayoutThresholdGwei = metaDetails.MinPayoutThreshold
+	report.PoolDonation = metaDetails.PoolDonation
+
 	// Get round share
-	if roundShare, err = api.MinerGetRoundShare(minerAddress); err != nil {
-		fmt.Printf("Unable to get round share: %v\n", err.Error())
+	if report.RoundShare, err = api.MinerGetRoundShare(minerAddress); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to get round share: %v\n", err.Error())
 		os.Exit(1)
 	}
 
 	// Get estimated daily eth
-	if dailyEstimatedGwei, err = api.MinerGetEstimatedDailyRevenue(minerAddress); err != nil {
-		fmt.Printf("Unable to get estimated daily revenue: %v\n", err.Error())
+	if report.EstimatedDailyRevenueGwei, err = api.MinerGetEstimatedDailyRevenue(minerAddress); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to get estimated daily revenue: %v\n", err.Error())
 		os.Exit(1)
 	}
 
-	dailyEstimatedEth := dailyEstimatedGwei
-
 	// Get total paid
-	if totalPaidGwei, err = api.MinerGetTotalPaid(minerAddress); err != nil {
-		fmt.Printf("Unable to get total paid: %v\n", err.Error())
+	if report.TotalPaidGwei, err = api.MinerGetTotalPaid(minerAddress); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to get total paid: %v\n", err.Error())
 		os.Exit(1)
 	}
 
-	totalPaidEth := totalPaidGwei
-
 	// Get total donate
-	if totalDonatedGwei, err = api.MinerGetTotalDonated(minerAddress); err != nil {
-		fmt.Printf("Unable to get total donated: %v\n", err.Error())
+	if report.TotalDonatedGwei, err = api.MinerGetTotalDonated(minerAddress); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to get total donated: %v\n", err.Error())
 		os.Exit(1)
 	}
 
-	totalDonatedEth := totalDonatedGwei
-
 	// Get workers
-	if workers, err = api.MinerGetWorkers(minerAddress); err != nil {
-		fmt.Printf("Unable to get worker listing: %v\n", err.Error())
+	if report.Workers, err = api.MinerGetWorkers(minerAddress); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to get worker listing: %v\n", err.Error())
 		os.Exit(1)
 	}
 
 	// Get payments
 	if paymentData, err = api.MinerGetPayments(minerAddress, 0); err != nil {
-		fmt.Printf("Unable to get last 10 payments: %v\n", err.Error())
+		fmt.Fprintf(os.Stderr, "Unable to get last 10 payments: %v\n", err.Error())
 		os.Exit(1)
 	}
 
+	report.Payments = paymentData.Data
+
 	// Get mined blocks
 	if blockData, err = api.MinerGetBlocks(minerAddress, 0); err != nil {
-		fmt.Printf("Unable to get last 10 blocks mined: %v\n", err.Error())
+		fmt.Fprintf(os.Stderr, "Unable to get last 10 blocks mined: %v\n", err.Error())
 		os.Exit(1)
 	}
 
-	// Do pretty printing
-	fmt.Printf("Flexpool Miner '%s' Stats\n-\n\n", minerAddress)
-	fmt.Printf("Unpaid Balance: %.8f\n", balanceEth)
-
-	fmt.Printf("Min Payout Threshold: %.4f eth \t\t Donation Percent: %.4f% \t Round Share: %.8f%\n",
-		utils.ConvertGweiToEth(metaDetails.MinPayoutThreshold),
-		metaDetails.PoolDonation,
-		roundShare)
-
-	fmt.Printf("Estimated Daily Eth: %.8f eth \t Total Paid: %.8f eth \t Total Donated: %.8f eth\n\n",
-		utils.ConvertGweiToEth(dailyEstimatedEth),
-		utils.ConvertGweiToEth(totalPaidEth),
-		utils.ConvertGweiToEth(totalDonatedEth))
-
-	fmt.Printf("Workers:\n")
-
-	if len(workers) > 0 {
-		for _, worker := range workers {
-			fmt.Printf("\t %s (effective hashrate: %dMH/s) \t (valid: %d, stale: %d, invalid: %d)\n",
-				worker.Name,
-				utils.ConvertHashrate(worker.EffectiveHashrate, utils.HashesPerSecond, utils.MegaHashesPerSecond),
-				worker.ValidShares,
-				worker.InvalidShares,
-				worker.InvalidShares)
-		}
-	} else {
-		fmt.Printf("\t None currently active.\n")
-	}
-
-	fmt.Printf("\nLast 10 payments:\n")
-
-	if paymentData.Data != nil {
-		for _, payment := range paymentData.Data {
-			fmt.Printf("\t Txn: %s (amount: %.8f eth) \t %s\n",
-				payment.Txid,
-				utils.ConvertGweiToEth(payment.Amount),
-				time.Unix(int64(payment.Timestamp), 0))
-		}
-	} else {
-		fmt.Printf("\t No payments made.\n")
-	}
+	report.Blocks = blockData.Data
 
-	fmt.Printf("\nLast 10 blocks mined: \n")
-
-	if blockData.Data != nil {
-		for _, block := range blockData.Data {
-			fmt.Printf("\t %d (type: %s) (reward: %.8f) \t %s\n",
-				block.Number,
-				block.Type,
-				utils.ConvertGweiToEth(block.TotalRewards),
-				time.Unix(int64(block.Timestamp), 0))
-		}
-	} else {
-		fmt.Printf("\t No blocks mined yet.\n")
+	// Do printing in the requested format
+	if err = format.WriteMinerReport(os.Stdout, outputFormat, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to write output: %v\n", err.Error())
+		os.Exit(1)
 	}
 }
@@ -1,48 +1,70 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"math"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/format"
 	"github.com/cryptogenic/goflexpool/pkg/utils"
+	"github.com/cryptogenic/goflexpool/pkg/utils/pplns"
 )
 
-const (
-	FlexpoolCurrentN               = 2_000_000     // 2 million share pool
-	FlexpoolCurrentShareDifficulty = 4_000_000_000 // 4GH/s difficulty
-)
-
-func secondsToHhMmSs(secondsIn uint) string {
-	hours := secondsIn / 60 / 60
-	minutes := (secondsIn / 60) % 60
-	seconds := secondsIn % 60
-
-	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
-}
+// formats are the output formats this binary can write.
+var formats = []string{format.Text, format.JSON, format.CSV}
 
 func main() {
 	var (
-		err             error
-		poolHashrate    api.PoolHashrate
-		poolMinerCount  int
-		poolWorkerCount int
+		report format.PoolReport
+
+		pplnsN               int
+		pplnsShareDifficulty uint
+		outputFormat         string
 	)
 
+	// The format falls back to the config file and environment
+	cfg, err := config.Setup(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load config: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	// Formats this binary can't write, such as yaml, keep the text layout when they come from the config
+	defaultFormat := format.Text
+	if format.Supported(cfg.Output.Format, formats...) {
+		defaultFormat = cfg.Output.Format
+	}
+
+	// PPLNS settings default to flexpool's current values, but can be overridden if the pool changes them
+	flag.IntVar(&pplnsN, "n", pplns.DefaultN, "PPLNS N value (number of shares in the window)")
+	flag.UintVar(&pplnsShareDifficulty, "share-difficulty", pplns.DefaultShareDifficulty, "Pool share difficulty in hashes")
+	flag.StringVar(&outputFormat, "format", defaultFormat, "Output format: text, json or csv")
+	flag.String("config", "", "Path to a config file")
+	flag.Parse()
+
+	if !format.Supported(outputFormat, formats...) {
+		fmt.Fprintf(os.Stderr, "Unknown format '%s', must be one of %s.\n", outputFormat, strings.Join(formats, ", "))
+		os.Exit(1)
+	}
+
 	// Get basic pool info (hashrates, miners and workers online)
-	if poolHashrate, err = api.PoolGetHashrate(); err != nil {
-		fmt.Printf("Failed to get pool hashrate: %v\n", err.Error())
+	if report.Hashrate, err = api.PoolGetHashrate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get pool hashrate: %v\n", err.Error())
 		os.Exit(1)
 	}
 
-	if poolMinerCount, err = api.PoolGetMinersOnline(); err != nil {
-		fmt.Printf("Failed to get online miner count: %v\n", err.Error())
+	if report.MinersOnline, err = api.PoolGetMinersOnline(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get online miner count: %v\n", err.Error())
 		os.Exit(1)
 	}
 
-	if poolWorkerCount, err = api.PoolGetWorkersOnline(); err != nil {
-		fmt.Printf("Failed to get online worker count: %v\n", err.Error())
+	if report.WorkersOnline, err = api.PoolGetWorkersOnline(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get online worker count: %v\n", err.Error())
 		os.Exit(1)
 	}
 
@@ -52,7 +74,7 @@ func main() {
 
 	for i := 0; i < 10; i++ {
 		if pageBlockData, err = api.PoolGetBlocks(i); err != nil {
-			fmt.Printf("Failed to get pool blockdata: %v\n", err.Error())
+			fmt.Fprintf(os.Stderr, "Failed to get pool blockdata: %v\n", err.Error())
 			os.Exit(1)
 		}
 
@@ -64,34 +86,33 @@ func main() {
 		time.Sleep(200 * time.Millisecond)
 	}
 
-	// Convert hashrates to GH/s for display
-	poolHashrateTotalGHs := utils.ConvertHashrate(poolHashrate.Total, utils.HashesPerSecond, utils.GigaHashesPerSecond)
-	poolHashrateAsGHs := utils.ConvertHashrate(poolHashrate.As, utils.HashesPerSecond, utils.GigaHashesPerSecond)
-	poolHashrateAuGHs := utils.ConvertHashrate(poolHashrate.Au, utils.HashesPerSecond, utils.GigaHashesPerSecond)
-	poolHashrateEuGHs := utils.ConvertHashrate(poolHashrate.Eu, utils.HashesPerSecond, utils.GigaHashesPerSecond)
-	poolHashrateSaGHs := utils.ConvertHashrate(poolHashrate.Sa, utils.HashesPerSecond, utils.GigaHashesPerSecond)
-	poolHashrateUsGHs := utils.ConvertHashrate(poolHashrate.Us, utils.HashesPerSecond, utils.GigaHashesPerSecond)
-
 	// Get PPLNS share window, uncle rate, average block reward, and average blocks per day
-	pplnsShareWindowSeconds := utils.CalculatePPLNSShareWindow(FlexpoolCurrentN, FlexpoolCurrentShareDifficulty, poolHashrate.Total)
-	uncleRate := utils.CalculateUncleRate(blocks)
-	averageBlockRewardGwei := utils.CalculateAverageBlockReward(blocks)
-	averageBlocksPerDay := utils.CalculateAverageBlocksPerDay(blocks)
-
-	averageBlockRewardEth := utils.ConvertGweiToEth(averageBlockRewardGwei)
-
-	// Do pretty printing
-	fmt.Printf("Flexpool Stats\n-\n\n")
-	fmt.Printf("Miners: %d (Workers: %d)\n\n", poolMinerCount, poolWorkerCount)
-	fmt.Printf("Hashrate: %vGH/s (total)\n", poolHashrateTotalGHs)
-	fmt.Printf("\tAs: %vGH/s\n", poolHashrateAsGHs)
-	fmt.Printf("\tAu: %vGH/s\n", poolHashrateAuGHs)
-	fmt.Printf("\tEu: %vGH/s\n", poolHashrateEuGHs)
-	fmt.Printf("\tSa: %vGH/s\n", poolHashrateSaGHs)
-	fmt.Printf("\tUs: %vGH/s\n\n", poolHashrateUsGHs)
-
-	fmt.Printf("PPLNS share window: %s (hh:mm:ss)\n", secondsToHhMmSs(pplnsShareWindowSeconds))
-	fmt.Printf("Uncle rate: %.2f%%\n", uncleRate*100)
-	fmt.Printf("Average blocks per day: %d (average reward: %.8f eth)\n", averageBlocksPerDay, averageBlockRewardEth)
-	fmt.Printf("\t* Averages and uncle rate are over a 100 block period\n")
+	// A stat that can't be calculated, such as with too few blocks, is left at zero rather than failing the report
+	shareWindow, err := utils.CalculatePPLNSShareWindow(pplnsN, float64(pplnsShareDifficulty), float64(report.Hashrate.Total))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to calculate PPLNS share window: %v\n", err.Error())
+	}
+
+	averageBlockReward, err := utils.CalculateAverageBlockReward(blocks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to calculate average block reward: %v\n", err.Error())
+	}
+
+	if report.UncleRate, err = utils.CalculateUncleRate(blocks); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to calculate uncle rate: %v\n", err.Error())
+	}
+
+	if report.AverageBlocksPerDay, err = utils.CalculateAverageBlocksPerDay(blocks); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to calculate average blocks per day: %v\n", err.Error())
+	}
+
+	report.PPLNSShareWindowSeconds = uint(math.Round(shareWindow))
+	report.AverageBlockRewardGwei = uint(math.Round(averageBlockReward))
+	report.BlocksSampled = len(blocks)
+
+	// Do printing in the requested format
+	if err = format.WritePoolReport(os.Stdout, outputFormat, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to write output: %v\n", err.Error())
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/utils"
+	"github.com/cryptogenic/goflexpool/pkg/utils/profit"
+)
+
+func main() {
+	var (
+		inputs profit.Inputs
+		result profit.Result
+
+		minerAddress   string
+		workers        int
+		wattsPerWorker float64
+		pricePerKWh    float64
+		ethPrice       float64
+	)
+
+	// Flags fall back to the config file and environment
+	cfg, err := config.Setup(os.Args[1:])
+	if err != nil {
+		fmt.Printf("Unable to load config: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	flag.StringVar(&minerAddress, "address", cfg.DefaultAddress, "Mining wallet address, or a name from the config file")
+	flag.IntVar(&workers, "workers", 0, "Number of workers drawing power (defaults to the online worker count)")
+	flag.Float64Var(&wattsPerWorker, "watts", cfg.Electricity.WattsPerWorker, "Power draw per worker in watts")
+	flag.Float64Var(&pricePerKWh, "kwh-price", cfg.Electricity.PricePerKWh, "Electricity price per kWh")
+	flag.Float64Var(&ethPrice, "eth-price", cfg.Electricity.EthPrice, "Eth price in the same currency as the electricity price")
+	flag.String("config", "", "Path to a config file")
+	flag.Parse()
+
+	minerAddress = cfg.Address(minerAddress)
+
+	if minerAddress == "" {
+		fmt.Printf("No address given, exiting.\n")
+		os.Exit(1)
+	}
+
+	// Get the miner's balance, threshold, revenue and hashrate
+	if inputs, err = profit.Fetch(minerAddress); err != nil {
+		fmt.Printf("Unable to get miner data: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	if workers > 0 {
+		inputs.Workers = workers
+	}
+
+	inputs.WattsPerWorker = wattsPerWorker
+	inputs.PricePerKWh = pricePerKWh
+	inputs.EthPrice = ethPrice
+
+	if result, err = profit.Calculate(inputs); err != nil {
+		fmt.Printf("Unable to calculate profitability: %v\n", err.Error())
+		os.Exit(1)
+	}
+
+	// Do pretty printing
+	fmt.Printf("Flexpool Miner '%s' Profitability\n-\n\n", minerAddress)
+	fmt.Printf("Effective Hashrate: %dMH/s \t Workers: %d\n",
+		utils.ConvertHashrate(inputs.EffectiveHashrate, utils.HashesPerSecond, utils.MegaHashesPerSecond),
+		inputs.Workers)
+
+	fmt.Printf("Estimated Daily Eth: %.8f eth \t Per MH/s: %.8f eth \t Share of a block: %.4f%%\n\n",
+		utils.ConvertGweiToEth(result.RevenuePerDayGwei),
+		result.RevenuePerMHsPerDayGwei*utils.WeiRatio,
+		result.BlockRewardFractionDaily*100)
+
+	fmt.Printf("Unpaid Balance: %.8f eth \t Payout Threshold: %.4f eth\n",
+		utils.ConvertGweiToEth(inputs.BalanceGwei),
+		utils.ConvertGweiToEth(inputs.MinPayoutThresholdGwei))
+
+	if !result.PayoutReachable {
+		fmt.Printf("Next Payout: never at the current revenue\n\n")
+	} else if result.RemainingToPayoutGwei == 0 {
+		fmt.Printf("Next Payout: threshold reached, payout pending\n\n")
+	} else {
+		fmt.Printf("Next Payout: in %s (around %s)\n\n",
+			result.TimeToPayout.Round(time.Minute),
+			time.Now().Add(result.TimeToPayout).Format("2006-01-02 15:04"))
+	}
+
+	fmt.Printf("Power Usage: %.2f kWh/day \t Power Cost: %.2f/day\n", result.EnergyPerDayKWh, result.PowerCostPerDay)
+
+	if ethPrice > 0 {
+		fmt.Printf("Revenue: %.2f/day \t Profit: %.2f/day\n", result.RevenuePerDay, result.ProfitPerDay)
+	} else {
+		fmt.Printf("\t* Give an -eth-price to calculate profit after power costs\n")
+	}
+}
@@ -0,0 +1,280 @@
+package api
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Breaker defaults, used when BreakerOptions leaves a field unset.
+const (
+	DefaultBreakerThreshold    = 5
+	DefaultBreakerCooldown     = 30 * time.Second
+	DefaultBreakerStaleEntries = 1000
+)
+
+// ErrCircuitOpen is returned by Breaker middleware while the circuit is open, without the request being sent.
+var ErrCircuitOpen = errors.New("api: circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+// Circuit breaker states.
+const (
+	Closed   BreakerState = iota // Requests are sent, and failures are counted
+	Open                         // Requests fail immediately until the cool-down ends
+	HalfOpen                     // A single trial request is sent to decide whether to close the circuit again
+)
+
+// BreakerOptions contains the settings for Breaker middleware. Threshold is the number of consecutive failures that
+// opens the circuit, and Cooldown is how long it stays open before a trial request is let through. OnStateChange, if
+// set, is called whenever the state changes. With ServeStale set, requests made while the circuit is open are answered
+// with the last successful result for the same URL, when there is one, rather than ErrCircuitOpen. Results are kept for
+// up to StaleEntries URLs, dropping the least recently used first.
+type BreakerOptions struct {
+	Threshold     int
+	Cooldown      time.Duration
+	OnStateChange func(from BreakerState, to BreakerState)
+	ServeStale    bool
+	StaleEntries  int
+}
+
+// CircuitBreaker tracks the failures of the calls passing through it, and stops sending them once the API looks down.
+// Transport errors and 5xx responses count as failures. Error responses from the API itself, such as for an invalid
+// address, show the API is up and don't, and neither do calls cancelled by their caller.
+type CircuitBreaker struct {
+	options BreakerOptions
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	opened   time.Time
+	trial    bool
+	last     map[string]*list.Element
+	order    *list.List
+}
+
+// staleResult is the last successful result for a URL, kept in a CircuitBreaker's order list with the most recently
+// used at the front.
+type staleResult struct {
+	key    string
+	result Response
+}
+
+// String returns the state's name - closed, open or half-open.
+func (s BreakerState) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// NewBreaker takes a set of options, and creates a closed CircuitBreaker. Unset thresholds, cool-downs and stale entry
+// limits use DefaultBreakerThreshold, DefaultBreakerCooldown and DefaultBreakerStaleEntries.
+func NewBreaker(options BreakerOptions) *CircuitBreaker {
+	if options.Threshold <= 0 {
+		options.Threshold = DefaultBreakerThreshold
+	}
+
+	if options.Cooldown <= 0 {
+		options.Cooldown = DefaultBreakerCooldown
+	}
+
+	if options.StaleEntries <= 0 {
+		options.StaleEntries = DefaultBreakerStaleEntries
+	}
+
+	return &CircuitBreaker{options: options, last: make(map[string]*list.Element), order: list.New()}
+}
+
+// Breaker takes a set of options, and returns middleware wrapping calls in a new CircuitBreaker. Use NewBreaker and
+// Middleware instead to inspect the breaker's state.
+func Breaker(options BreakerOptions) Middleware {
+	return NewBreaker(options).Middleware
+}
+
+// State returns the breaker's current state. An open breaker whose cool-down has ended reports HalfOpen, as the next
+// call will be let through as a trial.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == Open && time.Since(b.opened) >= b.options.Cooldown {
+		return HalfOpen
+	}
+
+	return b.state
+}
+
+// Middleware wraps next in the circuit breaker.
+func (b *CircuitBreaker) Middleware(next Handler) Handler {
+	return func(call *Call) error {
+		key := call.Request.URL.String()
+
+		if !b.allow() {
+			if result, ok := b.stale(key); ok {
+				call.Result = result
+				return nil
+			}
+
+			return ErrCircuitOpen
+		}
+
+		err := next(call)
+
+		// A call its caller cancelled says nothing about the upstream either way
+		if errors.Is(err, context.Canceled) {
+			b.cancelled()
+			return err
+		}
+
+		// Errors the API answered with below 500 are the caller's fault, so they don't count against the upstream
+		var apiErr *APIError
+		failed := err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode < 500)
+
+		b.record(failed)
+
+		if err == nil && b.options.ServeStale {
+			b.store(key, call.Result)
+		}
+
+		return err
+	}
+}
+
+// allow reports whether a call should be sent, moving an open breaker to half-open once its cool-down has ended. Only
+// one trial call is let through while half-open.
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Closed:
+		return true
+	case Open:
+		if time.Since(b.opened) < b.options.Cooldown {
+			return false
+		}
+
+		b.setState(HalfOpen)
+	}
+
+	if b.trial {
+		return false
+	}
+
+	b.trial = true
+
+	return true
+}
+
+// record takes whether a call failed, and updates the breaker's state with it.
+func (b *CircuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == HalfOpen {
+		b.trial = false
+
+		if failed {
+			b.open()
+		} else {
+			b.failures = 0
+			b.setState(Closed)
+		}
+
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+
+	if b.state == Closed && b.failures >= b.options.Threshold {
+		b.open()
+	}
+}
+
+// cancelled releases a half-open breaker's trial after the trial call was cancelled, so the next call is let through as
+// a trial instead.
+func (b *CircuitBreaker) cancelled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == HalfOpen {
+		b.trial = false
+	}
+}
+
+// stale returns the last successful result for key, if ServeStale is set and there is one.
+func (b *CircuitBreaker) stale(key string) (Response, bool) {
+	if !b.options.ServeStale {
+		return Response{}, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	element, ok := b.last[key]
+	if !ok {
+		return Response{}, false
+	}
+
+	b.order.MoveToFront(element)
+
+	return element.Value.(*staleResult).result, true
+}
+
+// store keeps result as the last successful result for key, dropping the least recently used result once there are
+// more than StaleEntries.
+func (b *CircuitBreaker) store(key string, result Response) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if element, ok := b.last[key]; ok {
+		element.Value.(*staleResult).result = result
+		b.order.MoveToFront(element)
+
+		return
+	}
+
+	b.last[key] = b.order.PushFront(&staleResult{key: key, result: result})
+
+	if b.order.Len() > b.options.StaleEntries {
+		oldest := b.order.Remove(b.order.Back()).(*staleResult)
+		delete(b.last, oldest.key)
+	}
+}
+
+// open moves the breaker to the open state, starting its cool-down. The lock must be held.
+func (b *CircuitBreaker) open() {
+	b.failures = 0
+	b.opened = time.Now()
+	b.setState(Open)
+}
+
+// setState changes the breaker's state and calls OnStateChange if it changed. The lock must be held, so the callback
+// shouldn't call back into the breaker.
+func (b *CircuitBreaker) setState(state BreakerState) {
+	if state == b.state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+
+	if b.options.OnStateChange != nil {
+		b.options.OnStateChange(from, state)
+	}
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultWorkerDetailsConcurrency is how many workers GetWorkerDetails fetches at once when no concurrency is set.
+const DefaultWorkerDetailsConcurrency = 4
+
+// Limiter limits how often requests are sent, blocking in Wait until the next one may go. *ratelimit.Limiter
+// implements it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WorkerDetailsOptions contains the settings for GetWorkerDetails. Concurrency is how many workers are fetched at once,
+// and Limiter, if set, is waited on before every request. SkipOffline leaves offline workers out of the results.
+type WorkerDetailsOptions struct {
+	Concurrency int
+	Limiter     Limiter
+	SkipOffline bool
+}
+
+// WorkerDetails contains everything known about one of a miner's workers. Err is set if fetching its stats or chart
+// failed, and the chart isn't fetched once the stats have failed.
+type WorkerDetails struct {
+	Worker MinerWorker
+	Stats  WorkerStats
+	Chart  []WorkerChartData
+	Err    error
+}
+
+// MinerGetWorkerDetails takes a mining wallet address and a set of options, and gets the stats and chart of every
+// worker for that address through DefaultClient. Returns a map of worker name to WorkerDetails and nil on success, or
+// nil and error if the worker list couldn't be fetched.
+func MinerGetWorkerDetails(address string, options WorkerDetailsOptions) (map[string]WorkerDetails, error) {
+	return GetWorkerDetails(context.Background(), DefaultClient.Miner(), DefaultClient.Worker(), address, options)
+}
+
+// GetWorkerDetails takes a context, the services to fetch from, a mining wallet address and a set of options. It gets
+// the address's workers, then fetches each worker's stats and chart concurrently. Errors for a single worker are kept
+// in its WorkerDetails rather than failing the whole call, and workers not fetched before the context is done get the
+// context's error. Returns a map of worker name to WorkerDetails and nil on success, or nil and error if the worker
+// list couldn't be fetched.
+func GetWorkerDetails(ctx context.Context, miners MinerService, workers WorkerService, address string,
+	options WorkerDetailsOptions) (map[string]WorkerDetails, error) {
+	var (
+		list []MinerWorker
+		err  error
+	)
+
+	if options.Concurrency <= 0 {
+		options.Concurrency = DefaultWorkerDetailsConcurrency
+	}
+
+	wait := func() error {
+		if options.Limiter == nil {
+			return ctx.Err()
+		}
+
+		return options.Limiter.Wait(ctx)
+	}
+
+	if err = wait(); err != nil {
+		return nil, err
+	}
+
+	if list, err = miners.GetWorkers(address); err != nil {
+		return nil, err
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]WorkerDetails, len(list))
+		slots   = make(chan struct{}, options.Concurrency)
+	)
+
+	for _, worker := range list {
+		if options.SkipOffline && !worker.Online {
+			continue
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func(worker MinerWorker) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			details := WorkerDetails{Worker: worker}
+
+			if details.Err = wait(); details.Err == nil {
+				if details.Stats, details.Err = workers.GetStats(address, worker.Name); details.Err != nil {
+					details.Err = fmt.Errorf("stats: %w", details.Err)
+				}
+			}
+
+			if details.Err == nil {
+				if details.Err = wait(); details.Err == nil {
+					if details.Chart, details.Err = workers.GetChart(address, worker.Name); details.Err != nil {
+						details.Err = fmt.Errorf("chart: %w", details.Err)
+					}
+				}
+			}
+
+			mu.Lock()
+			results[worker.Name] = details
+			mu.Unlock()
+		}(worker)
+	}
+
+	wg.Wait()
+
+	return results, nil
+}
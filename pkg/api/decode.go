@@ -0,0 +1,144 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrUnexpectedResponse is returned when a response's result doesn't have the shape its endpoint should return.
+var ErrUnexpectedResponse = errors.New("api: unexpected response")
+
+// decoder reads values out of a decoded JSON result, checking each one's type. The first mismatch is kept in err, and
+// every read after it returns a zero value, so a whole result can be read before checking err once.
+type decoder struct {
+	err error
+}
+
+// object is a JSON object being read by a decoder, along with its path in the result for error messages.
+type object struct {
+	d      *decoder
+	path   string
+	fields map[string]interface{}
+}
+
+// fail records a type mismatch at path, unless an earlier one has already been recorded.
+func (d *decoder) fail(path string, value interface{}, want string) {
+	if d.err != nil {
+		return
+	}
+
+	if value == nil {
+		d.err = fmt.Errorf("%w: %s is missing, want %s", ErrUnexpectedResponse, path, want)
+	} else {
+		d.err = fmt.Errorf("%w: %s is %T, want %s", ErrUnexpectedResponse, path, value, want)
+	}
+}
+
+// number takes a value and its path, and returns it as a float64 if it's a JSON number.
+func (d *decoder) number(value interface{}, path string) float64 {
+	number, ok := value.(float64)
+	if !ok {
+		d.fail(path, value, "number")
+	}
+
+	return number
+}
+
+// object takes a value and its path, and returns it as an object if it's a JSON object.
+func (d *decoder) object(value interface{}, path string) object {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		d.fail(path, value, "object")
+	}
+
+	return object{d: d, path: path, fields: fields}
+}
+
+// list takes a response, and returns its result's elements as objects if it's a JSON array of objects. Like objects, a
+// null result is an empty array, but a missing one is an error, as list endpoints always return a result.
+func (d *decoder) list(response Response) []object {
+	if !response.hasResult {
+		d.fail("result", nil, "array")
+		return nil
+	}
+
+	return d.objects(response.Result, "result")
+}
+
+// objects takes a value and its path, and returns its elements as objects if it's a JSON array of objects. A null
+// value is an empty array.
+func (d *decoder) objects(value interface{}, path string) []object {
+	if value == nil {
+		return nil
+	}
+
+	elements, ok := value.([]interface{})
+	if !ok {
+		d.fail(path, value, "array")
+		return nil
+	}
+
+	objects := make([]object, 0, len(elements))
+
+	for i, element := range elements {
+		objects = append(objects, d.object(element, path+"["+strconv.Itoa(i)+"]"))
+	}
+
+	return objects
+}
+
+// number returns the field key as a float64.
+func (o object) number(key string) float64 {
+	return o.d.number(o.fields[key], o.path+"."+key)
+}
+
+// text returns the field key as a string.
+func (o object) text(key string) string {
+	text, ok := o.fields[key].(string)
+	if !ok {
+		o.d.fail(o.path+"."+key, o.fields[key], "string")
+	}
+
+	return text
+}
+
+// boolean returns the field key as a bool.
+func (o object) boolean(key string) bool {
+	boolean, ok := o.fields[key].(bool)
+	if !ok {
+		o.d.fail(o.path+"."+key, o.fields[key], "bool")
+	}
+
+	return boolean
+}
+
+// object returns the field key as an object.
+func (o object) object(key string) object {
+	return o.d.object(o.fields[key], o.path+"."+key)
+}
+
+// objects returns the field key as a slice of objects, treating null as an empty array.
+func (o object) objects(key string) []object {
+	return o.d.objects(o.fields[key], o.path+"."+key)
+}
+
+// block reads a Block from o, converting its rewards from wei to gwei.
+func (o object) block() Block {
+	return Block{
+		Hash:                  o.text("hash"),
+		Number:                uint(o.number("number")),
+		Type:                  o.text("type"),
+		Miner:                 o.text("miner"),
+		Difficulty:            uint(o.number("difficulty")),
+		Timestamp:             uint(o.number("timestamp")),
+		Confirmed:             o.boolean("confirmed"),
+		RoundTime:             uint(o.number("round_time")),
+		Luck:                  o.number("luck"),
+		ServerName:            o.text("server_name"),
+		BlockReward:           uint(o.number("block_reward") * WeiRatio),
+		BlockFees:             uint(o.number("block_fees") * WeiRatio),
+		UncleInclusionRewards: uint(o.number("uncle_inclusion_rewards") * WeiRatio),
+		TotalRewards:          uint(o.number("total_rewards") * WeiRatio),
+	}
+}
@@ -1,3 +0,0 @@
-module "api"
-
-go 1.15
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// RequestIDHeader is the header the RequestID middleware sets by default.
+const RequestIDHeader = "X-Request-ID"
+
+// Call contains a single API request as it passes through the middleware chain. Request is built before the chain runs,
+// so middleware can change it on the way in. Response, Body and Result are filled in by the innermost handler, so
+// middleware can read them once the next handler returns. Response's body has already been read into Body and closed.
+type Call struct {
+	Endpoint Endpoint
+	Query    string
+	Method   string
+	Params   []string
+	Request  *http.Request
+	Response *http.Response
+	Body     []byte
+	Result   Response
+}
+
+// Handler sends a Call, filling in its response. Returns nil on success, or error on failure.
+type Handler func(call *Call) error
+
+// Middleware wraps a Handler, running code before and/or after it. Middleware can also skip calling next altogether,
+// for example to answer from a cache, by filling in the Call's Result itself.
+type Middleware func(next Handler) Handler
+
+// Use appends middleware to the client's chain. Middleware runs in the order it's added, so the first added is the
+// outermost - it sees the request first and the response last. Middleware should be added before the client is used,
+// as the chain isn't safe to change while requests are in flight.
+func (c *Client) Use(middleware ...Middleware) {
+	c.Middleware = append(c.Middleware, middleware...)
+}
+
+// handler builds the client's middleware chain around the handler that sends requests.
+func (c *Client) handler() Handler {
+	handler := c.send
+
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		handler = c.Middleware[i](handler)
+	}
+
+	return handler
+}
+
+// Logging takes a logger, and returns middleware that logs every call's URL, status and duration, or its error. A nil
+// logger logs to stderr.
+func Logging(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+
+	return func(next Handler) Handler {
+		return func(call *Call) error {
+			start := time.Now()
+			err := next(call)
+			elapsed := time.Since(start).Round(time.Millisecond)
+
+			switch {
+			case err != nil:
+				logger.Printf("GET %s failed after %v: %v", call.Request.URL, elapsed, err)
+			case call.Response != nil:
+				logger.Printf("GET %s %d in %v", call.Request.URL, call.Response.StatusCode, elapsed)
+			default:
+				logger.Printf("GET %s answered without a request in %v", call.Request.URL, elapsed)
+			}
+
+			return err
+		}
+	}
+}
+
+// Headers takes a set of headers, and returns middleware that sets them on every request, replacing any existing values.
+func Headers(headers http.Header) Middleware {
+	return func(next Handler) Handler {
+		return func(call *Call) error {
+			for name, values := range headers {
+				call.Request.Header.Del(name)
+
+				for _, value := range values {
+					call.Request.Header.Add(name, value)
+				}
+			}
+
+			return next(call)
+		}
+	}
+}
+
+// RequestID takes a header name and returns middleware that tags every request with a random ID in that header, unless
+// an earlier middleware has already set one. An empty header name uses RequestIDHeader.
+func RequestID(header string) Middleware {
+	if header == "" {
+		header = RequestIDHeader
+	}
+
+	return func(next Handler) Handler {
+		return func(call *Call) error {
+			if call.Request.Header.Get(header) == "" {
+				id := make([]byte, 8)
+				rand.Read(id)
+
+				call.Request.Header.Set(header, hex.EncodeToString(id))
+			}
+
+			return next(call)
+		}
+	}
+}
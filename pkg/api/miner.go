@@ -116,35 +116,55 @@ type MinerDetails struct {
 // MinerGetBalance takes a mining wallet address and gets the balance in gwei. Returns the balance and nil on success,
 // or 0 and error on failure.
 func MinerGetBalance(address string) (uint, error) {
+	return DefaultClient.Miner().GetBalance(address)
+}
+
+// GetBalance is MinerGetBalance, sent through the service's client.
+func (s minerService) GetBalance(address string) (uint, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "balance", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "balance", []string{}); err != nil {
 		return 0, err
 	}
 
-	wei := response.Result.(float64)
+	d := &decoder{}
+
+	wei := d.number(response.Result, "result")
 	gwei := wei * WeiRatio
-	return uint(gwei), nil
+	return uint(gwei), d.err
 }
 
 // MinerGetCurrent takes a mining wallet address and gets the current effective and reported hashrate of that address.
 // Returns a WorkerCurrentStats instance and nil on success, or an empty WorkerCurrentStats and error on failure.
 func MinerGetCurrent(address string) (WorkerCurrentStats, error) {
+	return DefaultClient.Miner().GetCurrent(address)
+}
+
+// GetCurrent is MinerGetCurrent, sent through the service's client.
+func (s minerService) GetCurrent(address string) (WorkerCurrentStats, error) {
 	var (
 		response Response
 		data     WorkerCurrentStats
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "current", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "current", []string{}); err != nil {
 		return data, err
 	}
 
-	data.EffectiveHashrate = uint(response.Result.(map[string]interface{})["effective_hashrate"].(float64))
-	data.ReportedHashrate = uint(response.Result.(map[string]interface{})["reported_hashrate"].(float64))
+	d := &decoder{}
+
+	responseData := d.object(response.Result, "result")
+
+	data.EffectiveHashrate = uint(responseData.number("effective_hashrate"))
+	data.ReportedHashrate = uint(responseData.number("reported_hashrate"))
+
+	if d.err != nil {
+		return WorkerCurrentStats{}, d.err
+	}
 
 	return data, nil
 }
@@ -153,20 +173,33 @@ func MinerGetCurrent(address string) (WorkerCurrentStats, error) {
 // as it's amount of stale and valid shares over the last 24 hours. Returns a MinerDailyStats instance and nil on success,
 // or an empty MinerDailyStats and error on failure.
 func MinerGetDaily(address string) (MinerDailyStats, error) {
+	return DefaultClient.Miner().GetDaily(address)
+}
+
+// GetDaily is MinerGetDaily, sent through the service's client.
+func (s minerService) GetDaily(address string) (MinerDailyStats, error) {
 	var (
 		response Response
 		data     MinerDailyStats
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "daily", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "daily", []string{}); err != nil {
 		return data, err
 	}
 
-	data.ReportedHashrate = response.Result.(map[string]interface{})["reported_hashrate"].(float64)
-	data.EffectiveHashrate = response.Result.(map[string]interface{})["effective_hashrate"].(float64)
-	data.StaleShares = int(response.Result.(map[string]interface{})["stale_shares"].(float64))
-	data.ValidShares = int(response.Result.(map[string]interface{})["valid_shares"].(float64))
+	d := &decoder{}
+
+	responseData := d.object(response.Result, "result")
+
+	data.ReportedHashrate = responseData.number("reported_hashrate")
+	data.EffectiveHashrate = responseData.number("effective_hashrate")
+	data.StaleShares = int(responseData.number("stale_shares"))
+	data.ValidShares = int(responseData.number("valid_shares"))
+
+	if d.err != nil {
+		return MinerDailyStats{}, d.err
+	}
 
 	return data, nil
 }
@@ -174,28 +207,39 @@ func MinerGetDaily(address string) (MinerDailyStats, error) {
 // MinerGetStats takes a mining wallet address and gets the current and daily stats of that address. Returns a
 // MinerStats instance and nil on success, or an empty MinerStats instance and error on failure.
 func MinerGetStats(address string) (MinerStats, error) {
+	return DefaultClient.Miner().GetStats(address)
+}
+
+// GetStats is MinerGetStats, sent through the service's client.
+func (s minerService) GetStats(address string) (MinerStats, error) {
 	var (
 		response Response
 		data     MinerStats
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "stats", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "stats", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
-	currentData := responseData["current"].(map[string]interface{})
-	dailyData := responseData["daily"].(map[string]interface{})
+	d := &decoder{}
+
+	responseData := d.object(response.Result, "result")
+	currentData := responseData.object("current")
+	dailyData := responseData.object("daily")
 
-	data.Current.EffectiveHashrate = uint(currentData["effective_hashrate"].(float64))
-	data.Current.ReportedHashrate = uint(currentData["reported_hashrate"].(float64))
+	data.Current.EffectiveHashrate = uint(currentData.number("effective_hashrate"))
+	data.Current.ReportedHashrate = uint(currentData.number("reported_hashrate"))
 
-	data.Daily.EffectiveHashrate = dailyData["effective_hashrate"].(float64)
-	data.Daily.InvalidShares = int(dailyData["invalid_shares"].(float64))
-	data.Daily.ReportedHashrate = dailyData["reported_hashrate"].(float64)
-	data.Daily.StaleShares = int(dailyData["stale_shares"].(float64))
-	data.Daily.ValidShares = int(dailyData["valid_shares"].(float64))
+	data.Daily.EffectiveHashrate = dailyData.number("effective_hashrate")
+	data.Daily.InvalidShares = int(dailyData.number("invalid_shares"))
+	data.Daily.ReportedHashrate = dailyData.number("reported_hashrate")
+	data.Daily.StaleShares = int(dailyData.number("stale_shares"))
+	data.Daily.ValidShares = int(dailyData.number("valid_shares"))
+
+	if d.err != nil {
+		return MinerStats{}, d.err
+	}
 
 	return data, nil
 }
@@ -203,20 +247,31 @@ func MinerGetStats(address string) (MinerStats, error) {
 // MinerGetWorkerCount takes a mining wallet address and gets the offline and online worker counts for that address. Returns
 // a MinerWorkerCount instance and nil on success, or an empty MinerWorkerCount instance and error on failure.
 func MinerGetWorkerCount(address string) (MinerWorkerCount, error) {
+	return DefaultClient.Miner().GetWorkerCount(address)
+}
+
+// GetWorkerCount is MinerGetWorkerCount, sent through the service's client.
+func (s minerService) GetWorkerCount(address string) (MinerWorkerCount, error) {
 	var (
 		response Response
 		data     MinerWorkerCount
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "workerCount", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "workerCount", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
+	d := &decoder{}
+
+	responseData := d.object(response.Result, "result")
 
-	data.Offline = int(responseData["offline"].(float64))
-	data.Online = int(responseData["online"].(float64))
+	data.Offline = int(responseData.number("offline"))
+	data.Online = int(responseData.number("online"))
+
+	if d.err != nil {
+		return MinerWorkerCount{}, d.err
+	}
 
 	return data, nil
 }
@@ -224,34 +279,39 @@ func MinerGetWorkerCount(address string) (MinerWorkerCount, error) {
 // MinerGetWorkers takes a mining wallet address and gets a list of the active workers for that address. Returns a slice
 // of MinerWorker instances and nil on success, or an empty slice and error on failure.
 func MinerGetWorkers(address string) ([]MinerWorker, error) {
+	return DefaultClient.Miner().GetWorkers(address)
+}
+
+// GetWorkers is MinerGetWorkers, sent through the service's client.
+func (s minerService) GetWorkers(address string) ([]MinerWorker, error) {
 	var (
 		response Response
 		data     []MinerWorker
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "workers", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "workers", []string{}); err != nil {
 		return data, err
 	}
 
-	if response.Result != nil {
-		responseData := response.Result.([]interface{})
-
-		for _, worker := range responseData {
-			workerData := worker.(map[string]interface{})
+	d := &decoder{}
+
+	for _, workerData := range d.list(response) {
+		data = append(data, MinerWorker{
+			Name:                   workerData.text("name"),
+			Online:                 workerData.boolean("online"),
+			DuplicateWorkersMerged: int(workerData.number("duplicate_workers_merged")),
+			ReportedHashrate:       uint(workerData.number("reported_hashrate")),
+			EffectiveHashrate:      uint(workerData.number("effective_hashrate")),
+			ValidShares:            int(workerData.number("valid_shares")),
+			StaleShares:            int(workerData.number("stale_shares")),
+			InvalidShares:          int(workerData.number("invalid_shares")),
+			LastSeen:               int(workerData.number("last_seen")),
+		})
+	}
 
-			data = append(data, MinerWorker{
-				Name:                   workerData["name"].(string),
-				Online:                 workerData["online"].(bool),
-				DuplicateWorkersMerged: int(workerData["duplicate_workers_merged"].(float64)),
-				ReportedHashrate:       uint(workerData["reported_hashrate"].(float64)),
-				EffectiveHashrate:      uint(workerData["effective_hashrate"].(float64)),
-				ValidShares:            int(workerData["valid_shares"].(float64)),
-				StaleShares:            int(workerData["stale_shares"].(float64)),
-				InvalidShares:          int(workerData["invalid_shares"].(float64)),
-				LastSeen:               int(workerData["last_seen"].(float64)),
-			})
-		}
+	if d.err != nil {
+		return nil, d.err
 	}
 
 	return data, nil
@@ -260,38 +320,50 @@ func MinerGetWorkers(address string) ([]MinerWorker, error) {
 // MinerGetChart takes a mining wallet address and gets a list of the chart data for that address. Returns a slice of
 // MinerChartData instances and nil on success, or an empty slice and error on failure.
 func MinerGetChart(address string) ([]MinerChartData, error) {
+	return DefaultClient.Miner().GetChart(address)
+}
+
+// GetChart is MinerGetChart, sent through the service's client.
+func (s minerService) GetChart(address string) ([]MinerChartData, error) {
 	var (
 		response Response
 		data     []MinerChartData
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "chart", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "chart", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.([]interface{})
-
-	for _, chart := range responseData {
-		chartData := chart.(map[string]interface{})
+	d := &decoder{}
 
+	for _, chartData := range d.list(response) {
 		data = append(data, MinerChartData{
-			Timestamp:                int(chartData["timestamp"].(float64)),
-			EffectiveHashrate:        uint(chartData["effective_hashrate"].(float64)),
-			AverageEffectiveHashrate: chartData["average_effective_hashrate"].(float64),
-			ReportedHashrate:         uint(chartData["reported_hashrate"].(float64)),
-			ValidShares:              int(chartData["valid_shares"].(float64)),
-			StaleShares:              int(chartData["stale_shares"].(float64)),
-			InvalidShares:            int(chartData["invalid_shares"].(float64)),
+			Timestamp:                int(chartData.number("timestamp")),
+			EffectiveHashrate:        uint(chartData.number("effective_hashrate")),
+			AverageEffectiveHashrate: chartData.number("average_effective_hashrate"),
+			ReportedHashrate:         uint(chartData.number("reported_hashrate")),
+			ValidShares:              int(chartData.number("valid_shares")),
+			StaleShares:              int(chartData.number("stale_shares")),
+			InvalidShares:            int(chartData.number("invalid_shares")),
 		})
 	}
 
+	if d.err != nil {
+		return nil, d.err
+	}
+
 	return data, nil
 }
 
 // MinerGetPayments takes a mining wallet address and a page number, and gets a list of payment data for that address + page.
 // Returns a MinerPaymentData instance and nil on success, or an empty MinerPaymentData instance and error on failure.
 func MinerGetPayments(address string, page int) (MinerPaymentData, error) {
+	return DefaultClient.Miner().GetPayments(address, page)
+}
+
+// GetPayments is MinerGetPayments, sent through the service's client.
+func (s minerService) GetPayments(address string, page int) (MinerPaymentData, error) {
 	var (
 		response Response
 		data     MinerPaymentData
@@ -300,28 +372,30 @@ func MinerGetPayments(address string, page int) (MinerPaymentData, error) {
 
 	pageStr := strconv.Itoa(page)
 
-	if response, err = sendAPIRequest(Miner, address, "payments", []string{"page=" + pageStr}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "payments", []string{"page=" + pageStr}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
+	d := &decoder{}
 
-	if responseData["data"] != nil {
-		for _, payment := range responseData["data"].([]interface{}) {
-			paymentData := payment.(map[string]interface{})
+	responseData := d.object(response.Result, "result")
 
-			data.Data = append(data.Data, MinerPayment{
-				Txid:      paymentData["txid"].(string),
-				Amount:    uint(paymentData["amount"].(float64) * WeiRatio),
-				Timestamp: uint(paymentData["timestamp"].(float64)),
-				Duration:  uint(paymentData["duration"].(float64)),
-			})
-		}
+	for _, paymentData := range responseData.objects("data") {
+		data.Data = append(data.Data, MinerPayment{
+			Txid:      paymentData.text("txid"),
+			Amount:    uint(paymentData.number("amount") * WeiRatio),
+			Timestamp: uint(paymentData.number("timestamp")),
+			Duration:  uint(paymentData.number("duration")),
+		})
 	}
 
-	data.ItemsPerPage = int(responseData["items_per_page"].(float64))
-	data.TotalItems = int(responseData["total_items"].(float64))
-	data.TotalPages = int(responseData["total_pages"].(float64))
+	data.ItemsPerPage = int(responseData.number("items_per_page"))
+	data.TotalItems = int(responseData.number("total_items"))
+	data.TotalPages = int(responseData.number("total_pages"))
+
+	if d.err != nil {
+		return MinerPaymentData{}, d.err
+	}
 
 	return data, nil
 }
@@ -329,48 +403,67 @@ func MinerGetPayments(address string, page int) (MinerPaymentData, error) {
 // MinerGetPaymentCount takes a mining wallet address and gets the number of payments made to that address. Returns the
 // number of payments as an int and nil on success, or 0 and error on failure.
 func MinerGetPaymentCount(address string) (int, error) {
+	return DefaultClient.Miner().GetPaymentCount(address)
+}
+
+// GetPaymentCount is MinerGetPaymentCount, sent through the service's client.
+func (s minerService) GetPaymentCount(address string) (int, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "paymentCount", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "paymentCount", []string{}); err != nil {
 		return 0, err
 	}
 
-	return int(response.Result.(float64)), nil
+	d := &decoder{}
+
+	return int(d.number(response.Result, "result")), d.err
 }
 
 // MinerGetPaymentChart takes a mining wallet address and gets a list of payments made to that address. Returns a slice of
 // MinerPaymentChart instances and nil on success, an empty slice and error on failure.
 func MinerGetPaymentChart(address string) ([]MinerPaymentChart, error) {
+	return DefaultClient.Miner().GetPaymentChart(address)
+}
+
+// GetPaymentChart is MinerGetPaymentChart, sent through the service's client.
+func (s minerService) GetPaymentChart(address string) ([]MinerPaymentChart, error) {
 	var (
 		response Response
 		data     []MinerPaymentChart
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "paymentsChart", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "paymentsChart", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.([]interface{})
-
-	for _, payment := range responseData {
-		paymentData := payment.(map[string]interface{})
+	d := &decoder{}
 
+	for _, paymentData := range d.list(response) {
 		data = append(data, MinerPaymentChart{
-			Amount:    uint(paymentData["amount"].(float64) * WeiRatio),
-			Timestamp: uint(paymentData["timestamp"].(float64)),
+			Amount:    uint(paymentData.number("amount") * WeiRatio),
+			Timestamp: uint(paymentData.number("timestamp")),
 		})
 	}
 
+	if d.err != nil {
+		return nil, d.err
+	}
+
 	return data, nil
 }
 
 // MinerGetBlocks takes an address and page number, and gets a list of blocks mined from that address. Returns a
 // MinerBlockData instance and nil on success, an empty MinerBlockData and error on failure.
 func MinerGetBlocks(address string, page int) (MinerBlockData, error) {
+	return DefaultClient.Miner().GetBlocks(address, page)
+}
+
+// GetBlocks is MinerGetBlocks, sent through the service's client.
+func (s minerService) GetBlocks(address string, page int) (MinerBlockData, error) {
 	var (
 		response Response
 		data     MinerBlockData
@@ -379,38 +472,25 @@ func MinerGetBlocks(address string, page int) (MinerBlockData, error) {
 
 	pageStr := strconv.Itoa(page)
 
-	if response, err = sendAPIRequest(Miner, address, "blocks", []string{"page=" + pageStr}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "blocks", []string{"page=" + pageStr}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
+	d := &decoder{}
 
-	if responseData["data"] != nil {
-		for _, block := range responseData["data"].([]interface{}) {
-			blockData := block.(map[string]interface{})
+	responseData := d.object(response.Result, "result")
 
-			data.Data = append(data.Data, Block{
-				Hash:                  blockData["hash"].(string),
-				Number:                uint(blockData["number"].(float64)),
-				Type:                  blockData["type"].(string),
-				Miner:                 blockData["miner"].(string),
-				Difficulty:            uint(blockData["difficulty"].(float64)),
-				Timestamp:             uint(blockData["timestamp"].(float64)),
-				Confirmed:             blockData["confirmed"].(bool),
-				RoundTime:             uint(blockData["round_time"].(float64)),
-				Luck:                  blockData["luck"].(float64),
-				ServerName:            blockData["server_name"].(string),
-				BlockReward:           uint(blockData["block_reward"].(float64) * WeiRatio),
-				BlockFees:             uint(blockData["block_fees"].(float64) * WeiRatio),
-				UncleInclusionRewards: uint(blockData["uncle_inclusion_rewards"].(float64) * WeiRatio),
-				TotalRewards:          uint(blockData["total_rewards"].(float64) * WeiRatio),
-			})
-		}
+	for _, blockData := range responseData.objects("data") {
+		data.Data = append(data.Data, blockData.block())
 	}
 
-	data.ItemsPerPage = int(responseData["items_per_page"].(float64))
-	data.TotalItems = int(responseData["total_items"].(float64))
-	data.TotalPages = int(responseData["total_pages"].(float64))
+	data.ItemsPerPage = int(responseData.number("items_per_page"))
+	data.TotalItems = int(responseData.number("total_items"))
+	data.TotalPages = int(responseData.number("total_pages"))
+
+	if d.err != nil {
+		return MinerBlockData{}, d.err
+	}
 
 	return data, nil
 }
@@ -418,39 +498,57 @@ func MinerGetBlocks(address string, page int) (MinerBlockData, error) {
 // MinerGetBlockCount takes a mining wallet address and gets the number of blocks mined by that address. Returns the number
 // of blocks mined as an int and nil on success, or 0 and error on failure.
 func MinerGetBlockCount(address string) (int, error) {
+	return DefaultClient.Miner().GetBlockCount(address)
+}
+
+// GetBlockCount is MinerGetBlockCount, sent through the service's client.
+func (s minerService) GetBlockCount(address string) (int, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "blockCount", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "blockCount", []string{}); err != nil {
 		return 0, err
 	}
 
-	return int(response.Result.(float64)), nil
+	d := &decoder{}
+
+	return int(d.number(response.Result, "result")), d.err
 }
 
 // MinerGetDetails takes a mining wallet address and gets the overall meta details of that wallet. Returns a MinerDetails
 // instance and nil on success, or an empty MinerDetails instance and error on failure.
 func MinerGetDetails(address string) (MinerDetails, error) {
+	return DefaultClient.Miner().GetDetails(address)
+}
+
+// GetDetails is MinerGetDetails, sent through the service's client.
+func (s minerService) GetDetails(address string) (MinerDetails, error) {
 	var (
 		response Response
 		data     MinerDetails
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "details", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "details", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
+	d := &decoder{}
 
-	data.MinPayoutThreshold = uint(responseData["min_payout_threshold"].(float64) * WeiRatio)
-	data.PoolDonation = responseData["pool_donation"].(float64)
-	data.MaxFeePrice = uint(responseData["max_fee_price"].(float64))
-	data.CensoredEmail = responseData["censored_email"].(string)
-	data.CensoredIp = responseData["censored_ip"].(string)
-	data.FirstJoined = uint(responseData["first_joined"].(float64))
+	responseData := d.object(response.Result, "result")
+
+	data.MinPayoutThreshold = uint(responseData.number("min_payout_threshold") * WeiRatio)
+	data.PoolDonation = responseData.number("pool_donation")
+	data.MaxFeePrice = uint(responseData.number("max_fee_price"))
+	data.CensoredEmail = responseData.text("censored_email")
+	data.CensoredIp = responseData.text("censored_ip")
+	data.FirstJoined = uint(responseData.number("first_joined"))
+
+	if d.err != nil {
+		return MinerDetails{}, d.err
+	}
 
 	return data, nil
 }
@@ -458,65 +556,93 @@ func MinerGetDetails(address string) (MinerDetails, error) {
 // MinerGetEstimatedDailyRevenue takes a mining address and gets the estimated daily revenue in gwei. Returns the estimated
 // daily revenue as an int and nil on success, or 0 and error on failure.
 func MinerGetEstimatedDailyRevenue(address string) (uint, error) {
+	return DefaultClient.Miner().GetEstimatedDailyRevenue(address)
+}
+
+// GetEstimatedDailyRevenue is MinerGetEstimatedDailyRevenue, sent through the service's client.
+func (s minerService) GetEstimatedDailyRevenue(address string) (uint, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "estimatedDailyRevenue", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "estimatedDailyRevenue", []string{}); err != nil {
 		return 0, err
 	}
 
-	wei := response.Result.(float64)
+	d := &decoder{}
+
+	wei := d.number(response.Result, "result")
 	gwei := wei * WeiRatio
-	return uint(gwei), nil
+	return uint(gwei), d.err
 }
 
 // MinerGetRoundShare takes a mining address and gets the current round share in percentage. Returns the round share as
 // a float64 and nil on success, or 0.0 and error on failure.
 func MinerGetRoundShare(address string) (float64, error) {
+	return DefaultClient.Miner().GetRoundShare(address)
+}
+
+// GetRoundShare is MinerGetRoundShare, sent through the service's client.
+func (s minerService) GetRoundShare(address string) (float64, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "roundShare", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "roundShare", []string{}); err != nil {
 		return 0.0, err
 	}
 
-	return response.Result.(float64), nil
+	d := &decoder{}
+
+	return d.number(response.Result, "result"), d.err
 }
 
 // MinerGetTotalPaid takes a mining address and gets the total amount of gwei paid to that address. Returns the amount paid
 // as an int and nil on success, or 0 and error on failure.
 func MinerGetTotalPaid(address string) (uint, error) {
+	return DefaultClient.Miner().GetTotalPaid(address)
+}
+
+// GetTotalPaid is MinerGetTotalPaid, sent through the service's client.
+func (s minerService) GetTotalPaid(address string) (uint, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "totalPaid", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "totalPaid", []string{}); err != nil {
 		return 0, err
 	}
 
-	wei := response.Result.(float64)
+	d := &decoder{}
+
+	wei := d.number(response.Result, "result")
 	gwei := wei * WeiRatio
-	return uint(gwei), nil
+	return uint(gwei), d.err
 }
 
 // MinerGetTotalDonated takes a mining address and gets the total amount of gwei donated from that address to the pool.
 // Returns the amount donated as an int and nil on success, or -0 and error on failure.
 func MinerGetTotalDonated(address string) (uint, error) {
+	return DefaultClient.Miner().GetTotalDonated(address)
+}
+
+// GetTotalDonated is MinerGetTotalDonated, sent through the service's client.
+func (s minerService) GetTotalDonated(address string) (uint, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Miner, address, "totalDonated", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Miner, address, "totalDonated", []string{}); err != nil {
 		return -0, err
 	}
 
-	wei := response.Result.(float64)
+	d := &decoder{}
+
+	wei := d.number(response.Result, "result")
 	gwei := wei * WeiRatio
-	return uint(gwei), nil
+	return uint(gwei), d.err
 }
@@ -2,8 +2,10 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 )
@@ -23,32 +25,113 @@ const WeiRatio = 0.000000001
 // Endpoint type alias for the sendAPIRequest function.
 type Endpoint int
 
+// Client contains the settings used to send requests to the API. Middleware wraps every request, and is usually added
+// with Use. A nil HTTPClient uses http.DefaultClient. Miner, Worker and Pool return the client's endpoints as services.
+type Client struct {
+	Host       string
+	HTTPClient *http.Client
+	Middleware []Middleware
+
+	ctx context.Context
+}
+
+// DefaultClient is the Client used by all of the endpoint wrapper functions. Its host can be changed to point at a proxy or
+// mirror of the API, and its HTTP client replaced to set timeouts or transports.
+var DefaultClient = &Client{
+	Host:       APIHost,
+	HTTPClient: &http.Client{},
+}
+
+// WithContext takes a context, and returns a copy of the client whose requests carry it. Requests are cancelled along
+// with the context, and middleware can read it from the Call's request, for example to record spans under the caller's
+// trace. The copy shares the client's middleware and HTTP client.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	client := *c
+	client.ctx = ctx
+
+	return &client
+}
+
+// String returns the endpoint's path segment - miner, worker or pool.
+func (e Endpoint) String() string {
+	switch e {
+	case Miner:
+		return "miner"
+	case Worker:
+		return "worker"
+	case Pool:
+		return "pool"
+	}
+
+	return "unknown"
+}
+
 // ResponseError contains the error-related data that could be returned from the API if it's used incorrectly.
 type ResponseError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+// APIError is returned when the API answers with a non-2xx status, or with an error in its response. StatusCode is the
+// HTTP status, and Code and Message the API's error, which are empty if the body didn't contain one.
+type APIError struct {
+	StatusCode int
+	Code       int
+	Message    string
+}
+
+// Error returns the API's error message, or the HTTP status if there isn't one.
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("api: %s (code %d, status %d)", e.Message, e.Code, e.StatusCode)
+	}
+
+	return fmt.Sprintf("api: request failed with status %d", e.StatusCode)
+}
+
 // Response is the primary container used for all responses from any API endpoint, containing the result and the error.
 type Response struct {
 	Error  ResponseError `json:"error"`
 	Result interface{}   `json:"result"`
+
+	// hasResult is whether the response had a result field at all, so a missing result can be told apart from a null
+	// one.
+	hasResult bool
+}
+
+// UnmarshalJSON decodes a response body into the Response, recording whether it had a result field. Returns nil on
+// success, or error on failure.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Error  ResponseError   `json:"error"`
+		Result json.RawMessage `json:"result"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	r.Error = raw.Error
+	r.Result = nil
+	r.hasResult = raw.Result != nil
+
+	if !r.hasResult {
+		return nil
+	}
+
+	return json.Unmarshal(raw.Result, &r.Result)
 }
 
 // sendAPIRequest is an internal function that takes an endpoint, and sends a GET request to the given query and method
-// with the given set of parameters. Returns the Response container and nil on success, an empty Response and error on failure.
-func sendAPIRequest(endpoint Endpoint, query string, method string, params []string) (Response, error) {
+// with the given set of parameters through the client's middleware. Returns the Response container and nil on success, an empty Response and error on failure.
+func (c *Client) sendAPIRequest(endpoint Endpoint, query string, method string, params []string) (Response, error) {
 	var (
-		err               error
-		req               *http.Request
-		resp              *http.Response
-		responseBodyBytes []byte
-		responseWrapped   Response
-		httpClient        http.Client
+		err error
+		req *http.Request
 	)
 
 	// Build up the URL in format [host] + / + [endpoint] + query/params
-	url := APIHost
+	url := c.Host
 
 	switch endpoint {
 	case Miner:
@@ -58,7 +141,7 @@ func sendAPIRequest(endpoint Endpoint, query string, method string, params []str
 	case Pool:
 		url += "/pool"
 	default:
-		return responseWrapped, errors.New("endpoint not supported")
+		return Response{}, errors.New("endpoint not supported")
 	}
 
 	// The pool endpoint doesn't use queries, so we'll build it into the URL for the other endpoints only.
@@ -79,31 +162,65 @@ func sendAPIRequest(endpoint Endpoint, query string, method string, params []str
 		}
 	}
 
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	// Build up the GET request - data is currently not used for the API, just the URL.
-	if req, err = http.NewRequest("GET", url, bytes.NewBuffer([]byte{})); err != nil {
-		return responseWrapped, err
+	if req, err = http.NewRequestWithContext(ctx, "GET", url, bytes.NewBuffer([]byte{})); err != nil {
+		return Response{}, err
 	}
 
 	// Depending on CORS settings we might need to explicitly define the content-type, so we'll set it just in case.
 	req.Header.Set("Content-Type", "application/json")
 
+	// Send the request through the middleware chain
+	call := &Call{Endpoint: endpoint, Query: query, Method: method, Params: params, Request: req}
+
+	if err = c.handler()(call); err != nil {
+		return Response{}, err
+	}
+
+	return call.Result, nil
+}
+
+// send is the innermost Handler, which sends a Call's request to the API and decodes the response into it. Returns nil
+// on success, an APIError if the API reported an error, or error on failure.
+func (c *Client) send(call *Call) error {
+	var (
+		err  error
+		resp *http.Response
+	)
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
 	// Fire off the request to the API
-	if resp, err = httpClient.Do(req); err != nil {
-		return responseWrapped, err
+	if resp, err = httpClient.Do(call.Request); err != nil {
+		return err
 	}
 
+	call.Response = resp
+
 	// Parse the response and marshal it into the Response container
-	if responseBodyBytes, err = ioutil.ReadAll(resp.Body); err != nil {
-		return responseWrapped, err
+	if call.Body, err = ioutil.ReadAll(resp.Body); err != nil {
+		return err
 	}
 
 	if err = resp.Body.Close(); err != nil {
-		return responseWrapped, err
+		return err
 	}
 
-	if err = json.Unmarshal(responseBodyBytes, &responseWrapped); err != nil {
-		return responseWrapped, err
+	// Errors are reported before decoding, so they're never mistaken for an empty result. A non-2xx body may not be
+	// JSON at all, in which case only the status is reported.
+	decodeErr := json.Unmarshal(call.Body, &call.Result)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 || call.Result.Error != (ResponseError{}) {
+		return &APIError{StatusCode: resp.StatusCode, Code: call.Result.Error.Code, Message: call.Result.Error.Message}
 	}
 
-	return responseWrapped, nil
+	return decodeErr
 }
@@ -66,24 +66,35 @@ type PoolAvgLuckRoundTime struct {
 // PoolGetHashrate gets the hashrate of the pool for each region in hashes per second. Returns a PoolHashrate instance and
 // nil on success, or an empty PoolHashrate and error on failure.
 func PoolGetHashrate() (PoolHashrate, error) {
+	return DefaultClient.Pool().GetHashrate()
+}
+
+// GetHashrate is PoolGetHashrate, sent through the service's client.
+func (s poolService) GetHashrate() (PoolHashrate, error) {
 	var (
 		response Response
 		data     PoolHashrate
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Pool, "", "hashrate", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "hashrate", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
+	d := &decoder{}
 
-	data.As = uint(responseData["as"].(float64))
-	data.Au = uint(responseData["au"].(float64))
-	data.Eu = uint(responseData["eu"].(float64))
-	data.Sa = uint(responseData["sa"].(float64))
-	data.Total = uint(responseData["total"].(float64))
-	data.Us = uint(responseData["us"].(float64))
+	responseData := d.object(response.Result, "result")
+
+	data.As = uint(responseData.number("as"))
+	data.Au = uint(responseData.number("au"))
+	data.Eu = uint(responseData.number("eu"))
+	data.Sa = uint(responseData.number("sa"))
+	data.Total = uint(responseData.number("total"))
+	data.Us = uint(responseData.number("us"))
+
+	if d.err != nil {
+		return PoolHashrate{}, d.err
+	}
 
 	return data, nil
 }
@@ -91,68 +102,94 @@ func PoolGetHashrate() (PoolHashrate, error) {
 // PoolGetHashrateChart gets a list of hashrate chart data for the pool. Returns a slice of PoolHashrateChartData instances
 // and nil on success, or an empty slice and error on failure.
 func PoolGetHashrateChart() ([]PoolHashrateChartData, error) {
+	return DefaultClient.Pool().GetHashrateChart()
+}
+
+// GetHashrateChart is PoolGetHashrateChart, sent through the service's client.
+func (s poolService) GetHashrateChart() ([]PoolHashrateChartData, error) {
 	var (
 		response Response
 		data     []PoolHashrateChartData
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Pool, "", "hashrateChart", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "hashrateChart", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.([]interface{})
-
-	for _, statDataPoint := range responseData {
-		statData := statDataPoint.(map[string]interface{})
+	d := &decoder{}
 
+	for _, statData := range d.list(response) {
 		data = append(data, PoolHashrateChartData{
-			As:        uint(statData["as"].(float64)),
-			Au:        uint(statData["au"].(float64)),
-			Eu:        uint(statData["eu"].(float64)),
-			Sa:        uint(statData["sa"].(float64)),
-			Timestamp: uint(statData["timestamp"].(float64)),
-			Total:     uint(statData["total"].(float64)),
-			Us:        uint(statData["us"].(float64)),
+			As:        uint(statData.number("as")),
+			Au:        uint(statData.number("au")),
+			Eu:        uint(statData.number("eu")),
+			Sa:        uint(statData.number("sa")),
+			Timestamp: uint(statData.number("timestamp")),
+			Total:     uint(statData.number("total")),
+			Us:        uint(statData.number("us")),
 		})
 	}
 
+	if d.err != nil {
+		return nil, d.err
+	}
+
 	return data, nil
 }
 
 // PoolGetMinersOnline gets how many miners are currently active on the pool. Returns the active miner count and nil on
 // success, or 0 and error on failure.
 func PoolGetMinersOnline() (int, error) {
+	return DefaultClient.Pool().GetMinersOnline()
+}
+
+// GetMinersOnline is PoolGetMinersOnline, sent through the service's client.
+func (s poolService) GetMinersOnline() (int, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Pool, "", "minersOnline", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "minersOnline", []string{}); err != nil {
 		return 0, err
 	}
 
-	return int(response.Result.(float64)), nil
+	d := &decoder{}
+
+	return int(d.number(response.Result, "result")), d.err
 }
 
 // PoolGetWorkersOnline gets how many workers are currently active on the pool. Returns the active worker count and nil on
 // success, or 0 and error on failure.
 func PoolGetWorkersOnline() (int, error) {
+	return DefaultClient.Pool().GetWorkersOnline()
+}
+
+// GetWorkersOnline is PoolGetWorkersOnline, sent through the service's client.
+func (s poolService) GetWorkersOnline() (int, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Pool, "", "workersOnline", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "workersOnline", []string{}); err != nil {
 		return 0, err
 	}
 
-	return int(response.Result.(float64)), nil
+	d := &decoder{}
+
+	return int(d.number(response.Result, "result")), d.err
 }
 
 // PoolGetBlocks takes a page number and gets a list of blocks the pool has mined from that page. Returns a PoolBlockData
 // instance and nil on success, or an empty PoolBlockData and error on failure.
 func PoolGetBlocks(page int) (PoolBlockData, error) {
+	return DefaultClient.Pool().GetBlocks(page)
+}
+
+// GetBlocks is PoolGetBlocks, sent through the service's client.
+func (s poolService) GetBlocks(page int) (PoolBlockData, error) {
 	var (
 		response Response
 		data     PoolBlockData
@@ -161,37 +198,25 @@ func PoolGetBlocks(page int) (PoolBlockData, error) {
 
 	pageStr := strconv.Itoa(page)
 
-	if response, err = sendAPIRequest(Pool, "", "blocks", []string{"page=" + pageStr}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "blocks", []string{"page=" + pageStr}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
-	blocksData := responseData["data"].([]interface{})
-
-	for _, blockDataPoint := range blocksData {
-		blockData := blockDataPoint.(map[string]interface{})
-
-		data.Data = append(data.Data, Block{
-			Hash:                  blockData["hash"].(string),
-			Number:                uint(blockData["number"].(float64)),
-			Type:                  blockData["type"].(string),
-			Miner:                 blockData["miner"].(string),
-			Difficulty:            uint(blockData["difficulty"].(float64)),
-			Timestamp:             uint(blockData["timestamp"].(float64)),
-			Confirmed:             blockData["confirmed"].(bool),
-			RoundTime:             uint(blockData["round_time"].(float64)),
-			Luck:                  blockData["luck"].(float64),
-			ServerName:            blockData["server_name"].(string),
-			BlockReward:           uint(blockData["block_reward"].(float64) * WeiRatio),
-			BlockFees:             uint(blockData["block_fees"].(float64) * WeiRatio),
-			UncleInclusionRewards: uint(blockData["uncle_inclusion_rewards"].(float64) * WeiRatio),
-			TotalRewards:          uint(blockData["total_rewards"].(float64) * WeiRatio),
-		})
+	d := &decoder{}
+
+	responseData := d.object(response.Result, "result")
+
+	for _, blockData := range responseData.objects("data") {
+		data.Data = append(data.Data, blockData.block())
 	}
 
-	data.ItemsPerPage = int(responseData["items_per_page"].(float64))
-	data.TotalItems = int(responseData["total_items"].(float64))
-	data.TotalPages = int(responseData["total_pages"].(float64))
+	data.ItemsPerPage = int(responseData.number("items_per_page"))
+	data.TotalItems = int(responseData.number("total_items"))
+	data.TotalPages = int(responseData.number("total_pages"))
+
+	if d.err != nil {
+		return PoolBlockData{}, d.err
+	}
 
 	return data, nil
 }
@@ -199,20 +224,31 @@ func PoolGetBlocks(page int) (PoolBlockData, error) {
 // PoolGetBlockCount gets how many blocks have been mined by the pool. Returns a PoolBlockCount instance and nil on success,
 // or an empty PoolBlockCount and error on failure.
 func PoolGetBlockCount() (PoolBlockCount, error) {
+	return DefaultClient.Pool().GetBlockCount()
+}
+
+// GetBlockCount is PoolGetBlockCount, sent through the service's client.
+func (s poolService) GetBlockCount() (PoolBlockCount, error) {
 	var (
 		response Response
 		data     PoolBlockCount
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Pool, "", "blockCount", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "blockCount", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
+	d := &decoder{}
 
-	data.Confirmed = int(responseData["confirmed"].(float64))
-	data.Unconfirmed = int(responseData["unconfirmed"].(float64))
+	responseData := d.object(response.Result, "result")
+
+	data.Confirmed = int(responseData.number("confirmed"))
+	data.Unconfirmed = int(responseData.number("unconfirmed"))
+
+	if d.err != nil {
+		return PoolBlockCount{}, d.err
+	}
 
 	return data, nil
 }
@@ -220,80 +256,105 @@ func PoolGetBlockCount() (PoolBlockCount, error) {
 // PoolGetTopMiners gets a list of the top miners in the pool. Returns a slice of PoolMinerInfo instances and nil on
 // success, or an empty slice and error on failure.
 func PoolGetTopMiners() ([]PoolMinerInfo, error) {
+	return DefaultClient.Pool().GetTopMiners()
+}
+
+// GetTopMiners is PoolGetTopMiners, sent through the service's client.
+func (s poolService) GetTopMiners() ([]PoolMinerInfo, error) {
 	var (
 		response Response
 		data     []PoolMinerInfo
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Pool, "", "topMiners", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "topMiners", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.([]interface{})
-
-	for _, minerDataPoint := range responseData {
-		minerData := minerDataPoint.(map[string]interface{})
+	d := &decoder{}
 
+	for _, minerData := range d.list(response) {
 		data = append(data, PoolMinerInfo{
-			Address:      minerData["address"].(string),
-			Hashrate:     uint(minerData["hashrate"].(float64)),
-			TotalWorkers: int(minerData["total_workers"].(float64)),
-			Balance:      uint(minerData["balance"].(float64) * WeiRatio),
-			PoolDonation: minerData["pool_donation"].(float64),
-			FirstJoined:  uint(minerData["first_joined"].(float64)),
+			Address:      minerData.text("address"),
+			Hashrate:     uint(minerData.number("hashrate")),
+			TotalWorkers: int(minerData.number("total_workers")),
+			Balance:      uint(minerData.number("balance") * WeiRatio),
+			PoolDonation: minerData.number("pool_donation"),
+			FirstJoined:  uint(minerData.number("first_joined")),
 		})
 	}
 
+	if d.err != nil {
+		return nil, d.err
+	}
+
 	return data, nil
 }
 
 // PoolGetTopDonators gets a list of the top donators in the pool. Returns a slice of PoolDonatorInfo instances and nil
 // on success, or an empty slice and error on failure.
 func PoolGetTopDonators() ([]PoolDonatorInfo, error) {
+	return DefaultClient.Pool().GetTopDonators()
+}
+
+// GetTopDonators is PoolGetTopDonators, sent through the service's client.
+func (s poolService) GetTopDonators() ([]PoolDonatorInfo, error) {
 	var (
 		response Response
 		data     []PoolDonatorInfo
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Pool, "", "topDonators", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "topDonators", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.([]interface{})
-
-	for _, donatorDataPoint := range responseData {
-		donatorData := donatorDataPoint.(map[string]interface{})
+	d := &decoder{}
 
+	for _, donatorData := range d.list(response) {
 		data = append(data, PoolDonatorInfo{
-			Address:      donatorData["address"].(string),
-			PoolDonation: donatorData["pool_donation"].(float64),
-			TotalDonated: uint(donatorData["total_donated"].(float64) * WeiRatio),
-			FirstJoined:  uint(donatorData["first_joined"].(float64)),
+			Address:      donatorData.text("address"),
+			PoolDonation: donatorData.number("pool_donation"),
+			TotalDonated: uint(donatorData.number("total_donated") * WeiRatio),
+			FirstJoined:  uint(donatorData.number("first_joined")),
 		})
 	}
 
+	if d.err != nil {
+		return nil, d.err
+	}
+
 	return data, nil
 }
 
 // PoolGetAverageLuckRoundTime gets the pool's current average luck as a percent and roundtime in seconds. Returns a
 // PoolAvgLuckRoundTime instance and nil on success, or an empty PoolAvgLuckRoundTime and error on failure.
 func PoolGetAverageLuckRoundTime() (PoolAvgLuckRoundTime, error) {
+	return DefaultClient.Pool().GetAverageLuckRoundTime()
+}
+
+// GetAverageLuckRoundTime is PoolGetAverageLuckRoundTime, sent through the service's client.
+func (s poolService) GetAverageLuckRoundTime() (PoolAvgLuckRoundTime, error) {
 	var (
 		response Response
 		data     PoolAvgLuckRoundTime
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Pool, "", "avgLuckRoundtime", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "avgLuckRoundtime", []string{}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
+	d := &decoder{}
 
-	data.Luck = responseData["luck"].(float64)
-	data.RoundTime = responseData["round_time"].(float64)
+	responseData := d.object(response.Result, "result")
+
+	data.Luck = responseData.number("luck")
+	data.RoundTime = responseData.number("round_time")
+
+	if d.err != nil {
+		return PoolAvgLuckRoundTime{}, d.err
+	}
 
 	return data, nil
 }
@@ -301,29 +362,43 @@ func PoolGetAverageLuckRoundTime() (PoolAvgLuckRoundTime, error) {
 // PoolGetCurrentLuck gets the pool's current luck as a percent. Returns the current luck as a float64 and nil on success,
 // or 0.0 and error on failure.
 func PoolGetCurrentLuck() (float64, error) {
+	return DefaultClient.Pool().GetCurrentLuck()
+}
+
+// GetCurrentLuck is PoolGetCurrentLuck, sent through the service's client.
+func (s poolService) GetCurrentLuck() (float64, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Pool, "", "currentLuck", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "currentLuck", []string{}); err != nil {
 		return 0.0, err
 	}
 
-	return response.Result.(float64), nil
+	d := &decoder{}
+
+	return d.number(response.Result, "result"), d.err
 }
 
 // PoolGetAverageBlockReward gets the pool's average block reward in gwei. Returns the average block reward as an int
 // and nil on success, or 0 and error on failure.
 func PoolGetAverageBlockReward() (uint, error) {
+	return DefaultClient.Pool().GetAverageBlockReward()
+}
+
+// GetAverageBlockReward is PoolGetAverageBlockReward, sent through the service's client.
+func (s poolService) GetAverageBlockReward() (uint, error) {
 	var (
 		response Response
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Pool, "", "averageBlockReward", []string{}); err != nil {
+	if response, err = s.client.sendAPIRequest(Pool, "", "averageBlockReward", []string{}); err != nil {
 		return 0, err
 	}
 
-	return uint(response.Result.(float64)), nil
+	d := &decoder{}
+
+	return uint(d.number(response.Result, "result")), d.err
 }
@@ -0,0 +1,67 @@
+package api
+
+// MinerService covers the /miner/{address} endpoints. It's implemented by Client.Miner, and by fakes in tests.
+type MinerService interface {
+	GetBalance(address string) (uint, error)
+	GetCurrent(address string) (WorkerCurrentStats, error)
+	GetDaily(address string) (MinerDailyStats, error)
+	GetStats(address string) (MinerStats, error)
+	GetWorkerCount(address string) (MinerWorkerCount, error)
+	GetWorkers(address string) ([]MinerWorker, error)
+	GetChart(address string) ([]MinerChartData, error)
+	GetPayments(address string, page int) (MinerPaymentData, error)
+	GetPaymentCount(address string) (int, error)
+	GetPaymentChart(address string) ([]MinerPaymentChart, error)
+	GetBlocks(address string, page int) (MinerBlockData, error)
+	GetBlockCount(address string) (int, error)
+	GetDetails(address string) (MinerDetails, error)
+	GetEstimatedDailyRevenue(address string) (uint, error)
+	GetRoundShare(address string) (float64, error)
+	GetTotalPaid(address string) (uint, error)
+	GetTotalDonated(address string) (uint, error)
+}
+
+// WorkerService covers the /worker/{address}/{worker} endpoints. It's implemented by Client.Worker, and by fakes in tests.
+type WorkerService interface {
+	GetCurrent(address string, worker string) (WorkerCurrentStats, error)
+	GetDaily(address string, worker string) (WorkerDailyStats, error)
+	GetStats(address string, worker string) (WorkerStats, error)
+	GetChart(address string, worker string) ([]WorkerChartData, error)
+}
+
+// PoolService covers the /pool endpoints. It's implemented by Client.Pool, and by fakes in tests.
+type PoolService interface {
+	GetHashrate() (PoolHashrate, error)
+	GetHashrateChart() ([]PoolHashrateChartData, error)
+	GetMinersOnline() (int, error)
+	GetWorkersOnline() (int, error)
+	GetBlocks(page int) (PoolBlockData, error)
+	GetBlockCount() (PoolBlockCount, error)
+	GetTopMiners() ([]PoolMinerInfo, error)
+	GetTopDonators() ([]PoolDonatorInfo, error)
+	GetAverageLuckRoundTime() (PoolAvgLuckRoundTime, error)
+	GetCurrentLuck() (float64, error)
+	GetAverageBlockReward() (uint, error)
+}
+
+// Services implemented by the client's endpoints.
+type (
+	minerService  struct{ client *Client }
+	workerService struct{ client *Client }
+	poolService   struct{ client *Client }
+)
+
+// Miner returns the client's /miner endpoints as a MinerService.
+func (c *Client) Miner() MinerService {
+	return minerService{client: c}
+}
+
+// Worker returns the client's /worker endpoints as a WorkerService.
+func (c *Client) Worker() WorkerService {
+	return workerService{client: c}
+}
+
+// Pool returns the client's /pool endpoints as a PoolService.
+func (c *Client) Pool() PoolService {
+	return poolService{client: c}
+}
@@ -35,20 +35,31 @@ type WorkerChartData struct {
 // WorkerGetCurrent takes a mining wallet address and worker name, and gets the current effective and reported hashrate of
 // that address. Returns a WorkerCurrentStats instance and nil on success, or an empty WorkerCurrentStats and error on failure.
 func WorkerGetCurrent(address string, worker string) (WorkerCurrentStats, error) {
+	return DefaultClient.Worker().GetCurrent(address, worker)
+}
+
+// GetCurrent is WorkerGetCurrent, sent through the service's client.
+func (s workerService) GetCurrent(address string, worker string) (WorkerCurrentStats, error) {
 	var (
 		response Response
 		data     WorkerCurrentStats
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Worker, address, worker, []string{"current"}); err != nil {
+	if response, err = s.client.sendAPIRequest(Worker, address, worker, []string{"current"}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
+	d := &decoder{}
+
+	responseData := d.object(response.Result, "result")
 
-	data.EffectiveHashrate = uint(responseData["effective_hashrate"].(float64))
-	data.ReportedHashrate = uint(responseData["reported_hashrate"].(float64))
+	data.EffectiveHashrate = uint(responseData.number("effective_hashrate"))
+	data.ReportedHashrate = uint(responseData.number("reported_hashrate"))
+
+	if d.err != nil {
+		return WorkerCurrentStats{}, d.err
+	}
 
 	return data, nil
 }
@@ -57,23 +68,34 @@ func WorkerGetCurrent(address string, worker string) (WorkerCurrentStats, error)
 // address as well as it's amount of stale and valid shares over the last 24 hours. Returns a WorkerDailyStats instance
 // and nil on success, an empty WorkerDailyStats and error on failure.
 func WorkerGetDaily(address string, worker string) (WorkerDailyStats, error) {
+	return DefaultClient.Worker().GetDaily(address, worker)
+}
+
+// GetDaily is WorkerGetDaily, sent through the service's client.
+func (s workerService) GetDaily(address string, worker string) (WorkerDailyStats, error) {
 	var (
 		response Response
 		data     WorkerDailyStats
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Worker, address, worker, []string{"daily"}); err != nil {
+	if response, err = s.client.sendAPIRequest(Worker, address, worker, []string{"daily"}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
+	d := &decoder{}
+
+	responseData := d.object(response.Result, "result")
 
-	data.EffectiveHashrate = uint(responseData["effective_hashrate"].(float64))
-	data.InvalidShares = int(responseData["invalid_shares"].(float64))
-	data.ReportedHashrate = uint(responseData["reported_hashrate"].(float64))
-	data.StaleShares = int(responseData["stale_shares"].(float64))
-	data.ValidShares = int(responseData["valid_shares"].(float64))
+	data.EffectiveHashrate = uint(responseData.number("effective_hashrate"))
+	data.InvalidShares = int(responseData.number("invalid_shares"))
+	data.ReportedHashrate = uint(responseData.number("reported_hashrate"))
+	data.StaleShares = int(responseData.number("stale_shares"))
+	data.ValidShares = int(responseData.number("valid_shares"))
+
+	if d.err != nil {
+		return WorkerDailyStats{}, d.err
+	}
 
 	return data, nil
 }
@@ -81,28 +103,39 @@ func WorkerGetDaily(address string, worker string) (WorkerDailyStats, error) {
 // WorkerGetStats takes a mining wallet address and worker name, and gets the current and daily stats of that worker. Returns
 // a WorkerStats instance and nil on success, or an empty WorkerStats instance and error on failure.
 func WorkerGetStats(address string, worker string) (WorkerStats, error) {
+	return DefaultClient.Worker().GetStats(address, worker)
+}
+
+// GetStats is WorkerGetStats, sent through the service's client.
+func (s workerService) GetStats(address string, worker string) (WorkerStats, error) {
 	var (
 		response Response
 		data     WorkerStats
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Worker, address, worker, []string{"stats"}); err != nil {
+	if response, err = s.client.sendAPIRequest(Worker, address, worker, []string{"stats"}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.(map[string]interface{})
-	currentData := responseData["current"].(map[string]interface{})
-	dailyData := responseData["daily"].(map[string]interface{})
+	d := &decoder{}
+
+	responseData := d.object(response.Result, "result")
+	currentData := responseData.object("current")
+	dailyData := responseData.object("daily")
 
-	data.Current.EffectiveHashrate = uint(currentData["effective_hashrate"].(float64))
-	data.Current.ReportedHashrate = uint(currentData["reported_hashrate"].(float64))
+	data.Current.EffectiveHashrate = uint(currentData.number("effective_hashrate"))
+	data.Current.ReportedHashrate = uint(currentData.number("reported_hashrate"))
 
-	data.Daily.EffectiveHashrate = uint(dailyData["effective_hashrate"].(float64))
-	data.Daily.InvalidShares = int(dailyData["invalid_shares"].(float64))
-	data.Daily.ReportedHashrate = uint(dailyData["reported_hashrate"].(float64))
-	data.Daily.StaleShares = int(dailyData["stale_shares"].(float64))
-	data.Daily.ValidShares = int(dailyData["valid_shares"].(float64))
+	data.Daily.EffectiveHashrate = uint(dailyData.number("effective_hashrate"))
+	data.Daily.InvalidShares = int(dailyData.number("invalid_shares"))
+	data.Daily.ReportedHashrate = uint(dailyData.number("reported_hashrate"))
+	data.Daily.StaleShares = int(dailyData.number("stale_shares"))
+	data.Daily.ValidShares = int(dailyData.number("valid_shares"))
+
+	if d.err != nil {
+		return WorkerStats{}, d.err
+	}
 
 	return data, nil
 }
@@ -110,31 +143,38 @@ func WorkerGetStats(address string, worker string) (WorkerStats, error) {
 // WorkerGetChart takes a mining wallet address and worker name, and gets a list of chart data for that address. Returns
 // a slice of MinerChartData instances and nil on success, or an empty slice and error on failure.
 func WorkerGetChart(address string, worker string) ([]WorkerChartData, error) {
+	return DefaultClient.Worker().GetChart(address, worker)
+}
+
+// GetChart is WorkerGetChart, sent through the service's client.
+func (s workerService) GetChart(address string, worker string) ([]WorkerChartData, error) {
 	var (
 		response Response
 		data     []WorkerChartData
 		err      error
 	)
 
-	if response, err = sendAPIRequest(Worker, address, worker, []string{"chart"}); err != nil {
+	if response, err = s.client.sendAPIRequest(Worker, address, worker, []string{"chart"}); err != nil {
 		return data, err
 	}
 
-	responseData := response.Result.([]interface{})
-
-	for _, chartDataPoint := range responseData {
-		chartData := chartDataPoint.(map[string]interface{})
+	d := &decoder{}
 
+	for _, chartData := range d.list(response) {
 		data = append(data, WorkerChartData{
-			Timestamp:                uint(chartData["timestamp"].(float64)),
-			EffectiveHashrate:        uint(chartData["effective_hashrate"].(float64)),
-			AverageEffectiveHashrate: chartData["average_effective_hashrate"].(float64),
-			ReportedHashrate:         uint(chartData["reported_hashrate"].(float64)),
-			ValidShares:              int(chartData["valid_shares"].(float64)),
-			StaleShares:              int(chartData["stale_shares"].(float64)),
-			InvalidShares:            int(chartData["invalid_shares"].(float64)),
+			Timestamp:                uint(chartData.number("timestamp")),
+			EffectiveHashrate:        uint(chartData.number("effective_hashrate")),
+			AverageEffectiveHashrate: chartData.number("average_effective_hashrate"),
+			ReportedHashrate:         uint(chartData.number("reported_hashrate")),
+			ValidShares:              int(chartData.number("valid_shares")),
+			StaleShares:              int(chartData.number("stale_shares")),
+			InvalidShares:            int(chartData.number("invalid_shares")),
 		})
 	}
 
+	if d.err != nil {
+		return nil, d.err
+	}
+
 	return data, nil
 }
@@ -0,0 +1,200 @@
+package apitest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+// ErrNotScripted is returned by calls the Fake has no response for.
+var ErrNotScripted = errors.New("apitest: call not scripted")
+
+// Fake is an in-memory stand-in for the flexpool API, implementing api.MinerService, api.WorkerService and
+// api.PoolService. Responses are scripted with On, and every call is recorded so tests can assert on them afterwards.
+// Methods are named by service and method, such as "Miner.GetBalance" or "Pool.GetBlocks".
+type Fake struct {
+	mu        sync.Mutex
+	responses []*Response
+	calls     []Call
+}
+
+// Response is a scripted response, matching calls to a method with the given arguments, or any arguments if none were
+// given.
+type Response struct {
+	method string
+	args   []interface{}
+	value  interface{}
+	err    error
+}
+
+// Call is a recorded call to the Fake.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// New creates a Fake with nothing scripted.
+func New() *Fake {
+	return &Fake{}
+}
+
+// On takes a method name and optional arguments, and scripts a response for matching calls. Later responses take
+// priority over earlier ones for the same call. Set the response's values with Return.
+func (f *Fake) On(method string, args ...interface{}) *Response {
+	response := &Response{method: method, args: args}
+
+	f.mu.Lock()
+	f.responses = append(f.responses, response)
+	f.mu.Unlock()
+
+	return response
+}
+
+// Return takes the value and error matching calls should return. The value must have the method's result type, or
+// for numbers, be convertible to it, so that untyped constants can be used. A nil value returns the zero value.
+func (r *Response) Return(value interface{}, err error) *Response {
+	r.value = value
+	r.err = err
+
+	return r
+}
+
+// Miner returns the Fake as an api.MinerService.
+func (f *Fake) Miner() api.MinerService {
+	return fakeMiner{fake: f}
+}
+
+// Worker returns the Fake as an api.WorkerService.
+func (f *Fake) Worker() api.WorkerService {
+	return fakeWorker{fake: f}
+}
+
+// Pool returns the Fake as an api.PoolService.
+func (f *Fake) Pool() api.PoolService {
+	return fakePool{fake: f}
+}
+
+// Calls takes a method name, and returns the calls made to it in order. An empty method name returns every call.
+func (f *Fake) Calls(method string) []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var calls []Call
+
+	for _, call := range f.calls {
+		if method == "" || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// AssertCalled takes a method name and arguments, and fails the test if no call to the method had those arguments.
+func (f *Fake) AssertCalled(t testing.TB, method string, args ...interface{}) {
+	t.Helper()
+
+	for _, call := range f.Calls(method) {
+		if reflect.DeepEqual(call.Args, args) {
+			return
+		}
+	}
+
+	t.Errorf("apitest: %s(%s) wasn't called, calls were: %s", method, formatArgs(args), f.formatCalls(method))
+}
+
+// AssertNotCalled takes a method name, and fails the test if the method was called.
+func (f *Fake) AssertNotCalled(t testing.TB, method string) {
+	t.Helper()
+
+	if calls := f.Calls(method); len(calls) > 0 {
+		t.Errorf("apitest: %s was called %d times, want 0: %s", method, len(calls), f.formatCalls(method))
+	}
+}
+
+// AssertCallCount takes a method name and a count, and fails the test if the method wasn't called exactly that many
+// times.
+func (f *Fake) AssertCallCount(t testing.TB, method string, count int) {
+	t.Helper()
+
+	if calls := f.Calls(method); len(calls) != count {
+		t.Errorf("apitest: %s was called %d times, want %d", method, len(calls), count)
+	}
+}
+
+// call records a call to method, and stores the scripted value in the pointer result. Returns the scripted error, or
+// ErrNotScripted if nothing matches. Panics if the scripted value doesn't fit the result, as the test is wrong.
+func (f *Fake) call(method string, args []interface{}, result interface{}) error {
+	f.mu.Lock()
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+
+	var response *Response
+
+	for i := len(f.responses) - 1; i >= 0; i-- {
+		r := f.responses[i]
+
+		if r.method == method && (len(r.args) == 0 || reflect.DeepEqual(r.args, args)) {
+			response = r
+			break
+		}
+	}
+
+	f.mu.Unlock()
+
+	if response == nil {
+		return fmt.Errorf("%w: %s(%s)", ErrNotScripted, method, formatArgs(args))
+	}
+
+	if response.value != nil {
+		out := reflect.ValueOf(result).Elem()
+		value := reflect.ValueOf(response.value)
+
+		switch {
+		case value.Type().AssignableTo(out.Type()):
+			out.Set(value)
+		case isNumber(value.Kind()) && isNumber(out.Kind()):
+			out.Set(value.Convert(out.Type()))
+		default:
+			panic(fmt.Sprintf("apitest: %s returns %s, but was scripted with %s", method, out.Type(), value.Type()))
+		}
+	}
+
+	return response.err
+}
+
+// formatCalls returns the calls made to method as a string, for failure messages.
+func (f *Fake) formatCalls(method string) string {
+	calls := f.Calls(method)
+	if len(calls) == 0 {
+		return "none"
+	}
+
+	formatted := make([]string, len(calls))
+
+	for i, call := range calls {
+		formatted[i] = call.Method + "(" + formatArgs(call.Args) + ")"
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// formatArgs returns a call's arguments as a comma separated string.
+func formatArgs(args []interface{}) string {
+	formatted := make([]string, len(args))
+
+	for i, arg := range args {
+		formatted[i] = fmt.Sprintf("%#v", arg)
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// isNumber reports whether kind is an integer or float kind.
+func isNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
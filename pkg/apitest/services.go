@@ -0,0 +1,266 @@
+package apitest
+
+import "github.com/cryptogenic/goflexpool/pkg/api"
+
+// Services implemented by the Fake.
+type (
+	fakeMiner  struct{ fake *Fake }
+	fakeWorker struct{ fake *Fake }
+	fakePool   struct{ fake *Fake }
+)
+
+// GetBalance returns the response scripted for "Miner.GetBalance".
+func (s fakeMiner) GetBalance(address string) (uint, error) {
+	var result uint
+	err := s.fake.call("Miner.GetBalance", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetCurrent returns the response scripted for "Miner.GetCurrent".
+func (s fakeMiner) GetCurrent(address string) (api.WorkerCurrentStats, error) {
+	var result api.WorkerCurrentStats
+	err := s.fake.call("Miner.GetCurrent", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetDaily returns the response scripted for "Miner.GetDaily".
+func (s fakeMiner) GetDaily(address string) (api.MinerDailyStats, error) {
+	var result api.MinerDailyStats
+	err := s.fake.call("Miner.GetDaily", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetStats returns the response scripted for "Miner.GetStats".
+func (s fakeMiner) GetStats(address string) (api.MinerStats, error) {
+	var result api.MinerStats
+	err := s.fake.call("Miner.GetStats", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetWorkerCount returns the response scripted for "Miner.GetWorkerCount".
+func (s fakeMiner) GetWorkerCount(address string) (api.MinerWorkerCount, error) {
+	var result api.MinerWorkerCount
+	err := s.fake.call("Miner.GetWorkerCount", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetWorkers returns the response scripted for "Miner.GetWorkers".
+func (s fakeMiner) GetWorkers(address string) ([]api.MinerWorker, error) {
+	var result []api.MinerWorker
+	err := s.fake.call("Miner.GetWorkers", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetChart returns the response scripted for "Miner.GetChart".
+func (s fakeMiner) GetChart(address string) ([]api.MinerChartData, error) {
+	var result []api.MinerChartData
+	err := s.fake.call("Miner.GetChart", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetPayments returns the response scripted for "Miner.GetPayments".
+func (s fakeMiner) GetPayments(address string, page int) (api.MinerPaymentData, error) {
+	var result api.MinerPaymentData
+	err := s.fake.call("Miner.GetPayments", []interface{}{address, page}, &result)
+
+	return result, err
+}
+
+// GetPaymentCount returns the response scripted for "Miner.GetPaymentCount".
+func (s fakeMiner) GetPaymentCount(address string) (int, error) {
+	var result int
+	err := s.fake.call("Miner.GetPaymentCount", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetPaymentChart returns the response scripted for "Miner.GetPaymentChart".
+func (s fakeMiner) GetPaymentChart(address string) ([]api.MinerPaymentChart, error) {
+	var result []api.MinerPaymentChart
+	err := s.fake.call("Miner.GetPaymentChart", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetBlocks returns the response scripted for "Miner.GetBlocks".
+func (s fakeMiner) GetBlocks(address string, page int) (api.MinerBlockData, error) {
+	var result api.MinerBlockData
+	err := s.fake.call("Miner.GetBlocks", []interface{}{address, page}, &result)
+
+	return result, err
+}
+
+// GetBlockCount returns the response scripted for "Miner.GetBlockCount".
+func (s fakeMiner) GetBlockCount(address string) (int, error) {
+	var result int
+	err := s.fake.call("Miner.GetBlockCount", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetDetails returns the response scripted for "Miner.GetDetails".
+func (s fakeMiner) GetDetails(address string) (api.MinerDetails, error) {
+	var result api.MinerDetails
+	err := s.fake.call("Miner.GetDetails", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetEstimatedDailyRevenue returns the response scripted for "Miner.GetEstimatedDailyRevenue".
+func (s fakeMiner) GetEstimatedDailyRevenue(address string) (uint, error) {
+	var result uint
+	err := s.fake.call("Miner.GetEstimatedDailyRevenue", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetRoundShare returns the response scripted for "Miner.GetRoundShare".
+func (s fakeMiner) GetRoundShare(address string) (float64, error) {
+	var result float64
+	err := s.fake.call("Miner.GetRoundShare", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetTotalPaid returns the response scripted for "Miner.GetTotalPaid".
+func (s fakeMiner) GetTotalPaid(address string) (uint, error) {
+	var result uint
+	err := s.fake.call("Miner.GetTotalPaid", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetTotalDonated returns the response scripted for "Miner.GetTotalDonated".
+func (s fakeMiner) GetTotalDonated(address string) (uint, error) {
+	var result uint
+	err := s.fake.call("Miner.GetTotalDonated", []interface{}{address}, &result)
+
+	return result, err
+}
+
+// GetCurrent returns the response scripted for "Worker.GetCurrent".
+func (s fakeWorker) GetCurrent(address string, worker string) (api.WorkerCurrentStats, error) {
+	var result api.WorkerCurrentStats
+	err := s.fake.call("Worker.GetCurrent", []interface{}{address, worker}, &result)
+
+	return result, err
+}
+
+// GetDaily returns the response scripted for "Worker.GetDaily".
+func (s fakeWorker) GetDaily(address string, worker string) (api.WorkerDailyStats, error) {
+	var result api.WorkerDailyStats
+	err := s.fake.call("Worker.GetDaily", []interface{}{address, worker}, &result)
+
+	return result, err
+}
+
+// GetStats returns the response scripted for "Worker.GetStats".
+func (s fakeWorker) GetStats(address string, worker string) (api.WorkerStats, error) {
+	var result api.WorkerStats
+	err := s.fake.call("Worker.GetStats", []interface{}{address, worker}, &result)
+
+	return result, err
+}
+
+// GetChart returns the response scripted for "Worker.GetChart".
+func (s fakeWorker) GetChart(address string, worker string) ([]api.WorkerChartData, error) {
+	var result []api.WorkerChartData
+	err := s.fake.call("Worker.GetChart", []interface{}{address, worker}, &result)
+
+	return result, err
+}
+
+// GetHashrate returns the response scripted for "Pool.GetHashrate".
+func (s fakePool) GetHashrate() (api.PoolHashrate, error) {
+	var result api.PoolHashrate
+	err := s.fake.call("Pool.GetHashrate", nil, &result)
+
+	return result, err
+}
+
+// GetHashrateChart returns the response scripted for "Pool.GetHashrateChart".
+func (s fakePool) GetHashrateChart() ([]api.PoolHashrateChartData, error) {
+	var result []api.PoolHashrateChartData
+	err := s.fake.call("Pool.GetHashrateChart", nil, &result)
+
+	return result, err
+}
+
+// GetMinersOnline returns the response scripted for "Pool.GetMinersOnline".
+func (s fakePool) GetMinersOnline() (int, error) {
+	var result int
+	err := s.fake.call("Pool.GetMinersOnline", nil, &result)
+
+	return result, err
+}
+
+// GetWorkersOnline returns the response scripted for "Pool.GetWorkersOnline".
+func (s fakePool) GetWorkersOnline() (int, error) {
+	var result int
+	err := s.fake.call("Pool.GetWorkersOnline", nil, &result)
+
+	return result, err
+}
+
+// GetBlocks returns the response scripted for "Pool.GetBlocks".
+func (s fakePool) GetBlocks(page int) (api.PoolBlockData, error) {
+	var result api.PoolBlockData
+	err := s.fake.call("Pool.GetBlocks", []interface{}{page}, &result)
+
+	return result, err
+}
+
+// GetBlockCount returns the response scripted for "Pool.GetBlockCount".
+func (s fakePool) GetBlockCount() (api.PoolBlockCount, error) {
+	var result api.PoolBlockCount
+	err := s.fake.call("Pool.GetBlockCount", nil, &result)
+
+	return result, err
+}
+
+// GetTopMiners returns the response scripted for "Pool.GetTopMiners".
+func (s fakePool) GetTopMiners() ([]api.PoolMinerInfo, error) {
+	var result []api.PoolMinerInfo
+	err := s.fake.call("Pool.GetTopMiners", nil, &result)
+
+	return result, err
+}
+
+// GetTopDonators returns the response scripted for "Pool.GetTopDonators".
+func (s fakePool) GetTopDonators() ([]api.PoolDonatorInfo, error) {
+	var result []api.PoolDonatorInfo
+	err := s.fake.call("Pool.GetTopDonators", nil, &result)
+
+	return result, err
+}
+
+// GetAverageLuckRoundTime returns the response scripted for "Pool.GetAverageLuckRoundTime".
+func (s fakePool) GetAverageLuckRoundTime() (api.PoolAvgLuckRoundTime, error) {
+	var result api.PoolAvgLuckRoundTime
+	err := s.fake.call("Pool.GetAverageLuckRoundTime", nil, &result)
+
+	return result, err
+}
+
+// GetCurrentLuck returns the response scripted for "Pool.GetCurrentLuck".
+func (s fakePool) GetCurrentLuck() (float64, error) {
+	var result float64
+	err := s.fake.call("Pool.GetCurrentLuck", nil, &result)
+
+	return result, err
+}
+
+// GetAverageBlockReward returns the response scripted for "Pool.GetAverageBlockReward".
+func (s fakePool) GetAverageBlockReward() (uint, error) {
+	var result uint
+	err := s.fake.call("Pool.GetAverageBlockReward", nil, &result)
+
+	return result, err
+}
@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// errFetchPanicked is returned to callers that were waiting on a fetch that panicked.
+var errFetchPanicked = errors.New("cache: fetch panicked")
+
+// Status describes how a Fetch was answered.
+type Status int
+
+// Fetch statuses.
+const (
+	Miss      Status = iota // The value was fetched
+	Hit                     // A fresh value was already cached
+	Coalesced               // The value was being fetched by another caller, and was shared with this one
+	Stale                   // The fetch failed, so an expired value was returned instead
+)
+
+// Cache is a concurrency-safe cache of values that expire after a fixed TTL. Concurrent fetches of the same missing key
+// are coalesced, so only one of them calls through to the fetch function. Expired values can optionally be kept for a
+// while longer, and returned if fetching a fresh value fails.
+type Cache struct {
+	ttl      time.Duration
+	staleTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]entry
+	calls   map[string]*call
+	stats   Stats
+}
+
+// Stats contains counts of how fetches were answered.
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Coalesced uint64 `json:"coalesced"`
+	Stale     uint64 `json:"stale"`
+	Errors    uint64 `json:"errors"`
+	Entries   int    `json:"entries"`
+}
+
+// entry is a cached value.
+type entry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// call is a fetch in progress, which other callers for the same key wait on.
+type call struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// New takes a TTL and creates an empty Cache whose values expire that long after they're fetched.
+func New(ttl time.Duration) *Cache {
+	return NewWithStale(ttl, 0)
+}
+
+// NewWithStale takes a TTL and a stale TTL, and creates an empty Cache whose values expire ttl after they're fetched.
+// Expired values are kept for a further staleTTL, and returned with the Stale status if fetching a fresh value fails.
+func NewWithStale(ttl time.Duration, staleTTL time.Duration) *Cache {
+	return &Cache{
+		ttl:      ttl,
+		staleTTL: staleTTL,
+		entries:  make(map[string]entry),
+		calls:    make(map[string]*call),
+	}
+}
+
+// Get takes a key and a function to fetch its value, and returns the cached value if it's fresh, or calls fetch and
+// caches the result. Errors aren't cached. Returns the value and nil on success, or nil and the fetch error on failure.
+func (c *Cache) Get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	value, _, err := c.Fetch(key, fetch)
+	return value, err
+}
+
+// Fetch is the same as Get, but also returns how the value was found.
+func (c *Cache) Fetch(key string, fetch func() (interface{}, error)) (interface{}, Status, error) {
+	c.mu.Lock()
+
+	now := time.Now()
+	cached, ok := c.entries[key]
+
+	if ok && now.Before(cached.expires) {
+		c.stats.Hits++
+		c.mu.Unlock()
+
+		return cached.value, Hit, nil
+	}
+
+	if ok && !now.Before(cached.expires.Add(c.staleTTL)) {
+		delete(c.entries, key)
+		ok = false
+	}
+
+	// Share a fetch that's already in flight for this key
+	if inFlight, found := c.calls[key]; found {
+		c.stats.Coalesced++
+		c.mu.Unlock()
+
+		<-inFlight.done
+
+		if inFlight.err != nil && ok {
+			c.mu.Lock()
+			c.stats.Stale++
+			c.mu.Unlock()
+
+			return cached.value, Stale, nil
+		}
+
+		return inFlight.value, Coalesced, inFlight.err
+	}
+
+	current := &call{done: make(chan struct{})}
+	c.calls[key] = current
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	// If fetch panics, waiters are released with an error rather than blocking forever
+	finished := false
+	defer func() {
+		if !finished {
+			current.err = errFetchPanicked
+		}
+
+		c.mu.Lock()
+		delete(c.calls, key)
+
+		if current.err == nil {
+			c.entries[key] = entry{value: current.value, expires: time.Now().Add(c.ttl)}
+		} else {
+			c.stats.Errors++
+		}
+
+		c.mu.Unlock()
+		close(current.done)
+	}()
+
+	current.value, current.err = fetch()
+	finished = true
+
+	if current.err != nil && ok {
+		c.mu.Lock()
+		c.stats.Stale++
+		c.mu.Unlock()
+
+		return cached.value, Stale, nil
+	}
+
+	return current.value, Miss, current.err
+}
+
+// Purge removes every value that's past its TTL and stale TTL. Expired values are also removed when they're next
+// requested, so this only needs calling to free memory used by keys that aren't requested again.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, cached := range c.entries {
+		if !now.Before(cached.expires.Add(c.staleTTL)) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Stats returns counts of how fetches were answered, and the number of cached values.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.entries)
+
+	return stats
+}
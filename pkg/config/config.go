@@ -0,0 +1,451 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/format"
+	"github.com/cryptogenic/goflexpool/pkg/utils/health"
+)
+
+// FileName is the name of the config file looked for in the user's config directory.
+const FileName = "config.json"
+
+// YAMLFileName is the name of the YAML config file looked for in the user's config directory when there's no FileName.
+const YAMLFileName = "config.yaml"
+
+// PathEnv is the environment variable that can point at a config file instead of the default location.
+const PathEnv = "FLEXPOOL_CONFIG"
+
+// addressPattern matches a hex encoded ethereum address.
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// Config contains the settings shared by every command. Values are applied in order of precedence: command line flags,
+// then environment variables, then the config file, then defaults.
+type Config struct {
+	Addresses      map[string]string   `json:"addresses"`
+	DefaultAddress string              `json:"default_address"`
+	Groups         []Group             `json:"groups"`
+	Tags           map[string][]string `json:"tags,omitempty"`
+	API            APISettings         `json:"api"`
+	Thresholds     health.Thresholds   `json:"thresholds"`
+	Electricity    Electricity         `json:"electricity"`
+	Output         Output              `json:"output"`
+	Web            WebSettings         `json:"web"`
+}
+
+// Group contains a named set of workers, matched by glob patterns (for example "rackA-*") or regular expressions against
+// the worker name. Workers can also be put in groups explicitly with the config's Tags, which map worker names to group
+// names. Address can be a named address or a literal one, and limits the group to that miner's workers.
+type Group struct {
+	Name     string   `json:"name"`
+	Address  string   `json:"address,omitempty"`
+	Patterns []string `json:"patterns,omitempty"`
+	Regex    []string `json:"regex,omitempty"`
+}
+
+// APISettings contains the settings used by the api package's client. Timeout is a duration string such as "30s", and no
+// timeout is used when it's empty.
+type APISettings struct {
+	Host    string `json:"host"`
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// Electricity contains the power costs used for profitability calculations.
+type Electricity struct {
+	WattsPerWorker float64 `json:"watts_per_worker"`
+	PricePerKWh    float64 `json:"price_per_kwh"`
+	EthPrice       float64 `json:"eth_price"`
+}
+
+// Output contains output preferences. An empty Format leaves each command on its human readable default, as does a
+// format the command can't write, such as yaml for poolinfo. Text and table are both the human readable layout.
+type Output struct {
+	Format string `json:"format,omitempty"`
+}
+
+// WebSettings contains the settings for the flexpool-web dashboard. Basic auth is required when Username is set, and
+// CacheTTL is a duration string such as "1m".
+type WebSettings struct {
+	Listen   string `json:"listen"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	CacheTTL string `json:"cache_ttl"`
+}
+
+// Error contains a single problem found in the config, with the location it was found at. Line and Column are 0 when the
+// problem didn't come from the file, such as a bad environment variable.
+type Error struct {
+	File    string
+	Line    int
+	Column  int
+	Path    string
+	Message string
+}
+
+// Errors contains every problem found while loading a config.
+type Errors []*Error
+
+// Default returns the config used when no config file exists.
+func Default() Config {
+	return Config{
+		Addresses:  make(map[string]string),
+		API:        APISettings{Host: api.APIHost},
+		Thresholds: health.DefaultThresholds(),
+		Web:        WebSettings{Listen: "127.0.0.1:8080", CacheTTL: "1m"},
+	}
+}
+
+// Error formats the problem as "file:line:column: path: message", leaving out any parts that aren't known.
+func (e *Error) Error() string {
+	location := e.File
+	if e.Line > 0 {
+		location += fmt.Sprintf(":%d:%d", e.Line, e.Column)
+	}
+
+	message := e.Message
+	if e.Path != "" {
+		message = e.Path + ": " + message
+	}
+
+	if location == "" {
+		return message
+	}
+
+	return location + ": " + message
+}
+
+// Error joins every problem onto its own line.
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Path takes command line arguments and finds the config file to use - the value of a -config flag, then the
+// FLEXPOOL_CONFIG environment variable, then config.json or config.yaml in the user's config directory. Returns the path
+// and whether it was given explicitly, so callers can ignore a missing default file.
+func Path(args []string) (string, bool) {
+	for i, arg := range args {
+		name := strings.TrimLeft(arg, "-")
+		if name == arg || len(arg)-len(name) > 2 {
+			continue
+		}
+
+		if name == "config" && i+1 < len(args) {
+			return args[i+1], true
+		}
+
+		if strings.HasPrefix(name, "config=") {
+			return strings.TrimPrefix(name, "config="), true
+		}
+	}
+
+	if env := os.Getenv(PathEnv); env != "" {
+		return env, true
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", false
+	}
+
+	name := filepath.Join(dir, "flexpool", FileName)
+	yamlName := filepath.Join(dir, "flexpool", YAMLFileName)
+
+	if !fileExists(name) && fileExists(yamlName) {
+		return yamlName, false
+	}
+
+	return name, false
+}
+
+// fileExists returns whether a file exists at name.
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+
+	return err == nil
+}
+
+// Load takes a path to a config file, and parses and validates it - as YAML if it has a .yaml or .yml extension, and as
+// JSON otherwise. Returns the Config and nil on success, or the defaults and error on failure. Problems with the file's
+// contents are returned as Errors.
+func Load(name string) (Config, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return Default(), err
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return ParseYAML(name, data)
+	}
+
+	return Parse(name, data)
+}
+
+// Setup takes command line arguments and loads the config file they point to, applies environment variable overrides and
+// configures the api package's client. A missing config file is only an error if its path was given explicitly. Returns
+// the Config and nil on success, or the defaults and error on failure.
+func Setup(args []string) (Config, error) {
+	cfg := Default()
+
+	name, explicit := Path(args)
+	if name != "" {
+		loaded, err := Load(name)
+		if err == nil {
+			cfg = loaded
+		} else if explicit || !os.IsNotExist(err) {
+			return Default(), err
+		}
+	}
+
+	if err := cfg.ApplyEnv(os.Getenv); err != nil {
+		return Default(), err
+	}
+
+	cfg.ApplyAPI()
+
+	return cfg, nil
+}
+
+// ApplyEnv takes a function to look up environment variables (normally os.Getenv), and overrides config values with any
+// FLEXPOOL_* variables that are set. Returns nil on success, or Errors if a variable is invalid.
+func (c *Config) ApplyEnv(getenv func(string) string) error {
+	var problems Errors
+
+	for _, override := range envOverrides {
+		value := getenv(override.name)
+		if value == "" {
+			continue
+		}
+
+		if err := override.set(c, value); err != nil {
+			problems = append(problems, &Error{Path: override.name, Message: err.Error()})
+		}
+	}
+
+	for _, problem := range c.Validate() {
+		if override := envOverrideFor(problem.Path); override != "" && getenv(override) != "" {
+			problem.Path = override
+			problems = append(problems, problem)
+		}
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+
+	return nil
+}
+
+// ApplyAPI configures the api package's DefaultClient with the config's host and timeout. The client's HTTPClient is
+// only replaced when a timeout is set, with a copy that keeps its transport and other settings.
+func (c Config) ApplyAPI() {
+	if c.API.Host != "" {
+		api.DefaultClient.Host = strings.TrimSuffix(c.API.Host, "/")
+	}
+
+	if c.API.Timeout == "" {
+		return
+	}
+
+	// Timeout has already been validated, so a parse error just means no timeout
+	timeout, _ := time.ParseDuration(c.API.Timeout)
+
+	httpClient := *http.DefaultClient
+	if api.DefaultClient.HTTPClient != nil {
+		httpClient = *api.DefaultClient.HTTPClient
+	}
+
+	httpClient.Timeout = timeout
+	api.DefaultClient.HTTPClient = &httpClient
+}
+
+// Address takes a name from the config's address list or a literal address, and returns the address it refers to. An
+// empty name returns the default address.
+func (c Config) Address(name string) string {
+	if name == "" {
+		name = c.DefaultAddress
+	}
+
+	if address, ok := c.Addresses[name]; ok {
+		return address
+	}
+
+	return name
+}
+
+// Validate checks every config value. Returns the problems found, each with the JSON path of the value at fault, or nil
+// if the config is valid.
+func (c Config) Validate() Errors {
+	var problems Errors
+
+	add := func(path string, message string, args ...interface{}) {
+		problems = append(problems, &Error{Path: path, Message: fmt.Sprintf(message, args...)})
+	}
+
+	names := make([]string, 0, len(c.Addresses))
+	for name := range c.Addresses {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, " \t\n") {
+			add("addresses."+name, "address names can't be empty or contain whitespace")
+		}
+
+		if !addressPattern.MatchString(c.Addresses[name]) {
+			add("addresses."+name, "%q is not a valid address", c.Addresses[name])
+		}
+	}
+
+	if c.DefaultAddress != "" && !c.resolvable(c.DefaultAddress) {
+		add("default_address", "%q is not a named or valid address", c.DefaultAddress)
+	}
+
+	workers := make([]string, 0, len(c.Tags))
+	for worker := range c.Tags {
+		workers = append(workers, worker)
+	}
+
+	sort.Strings(workers)
+
+	tagged := make(map[string]bool)
+	for _, worker := range workers {
+		for j, group := range c.Tags[worker] {
+			if group == "" {
+				add(fmt.Sprintf("tags.%s[%d]", worker, j), "group name can't be empty")
+			}
+
+			tagged[group] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, group := range c.Groups {
+		prefix := fmt.Sprintf("groups[%d]", i)
+
+		if group.Name == "" {
+			add(prefix+".name", "group name is required")
+		} else if seen[group.Name] {
+			add(prefix+".name", "duplicate group %q", group.Name)
+		}
+
+		seen[group.Name] = true
+
+		if group.Address != "" && !c.resolvable(group.Address) {
+			add(prefix+".address", "%q is not a named or valid address", group.Address)
+		}
+
+		if len(group.Patterns) == 0 && len(group.Regex) == 0 && !tagged[group.Name] {
+			add(prefix, "group needs a pattern, a regex or a worker tagged with it")
+		}
+
+		for j, pattern := range group.Patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				add(fmt.Sprintf("%s.patterns[%d]", prefix, j), "invalid pattern %q", pattern)
+			}
+		}
+
+		for j, expression := range group.Regex {
+			if _, err := regexp.Compile(expression); err != nil {
+				add(fmt.Sprintf("%s.regex[%d]", prefix, j), "invalid regex %q", expression)
+			}
+		}
+	}
+
+	if host, err := url.Parse(c.API.Host); err != nil || (host.Scheme != "http" && host.Scheme != "https") || host.Host == "" {
+		add("api.host", "%q is not an http or https URL", c.API.Host)
+	}
+
+	if c.API.Timeout != "" {
+		if timeout, err := time.ParseDuration(c.API.Timeout); err != nil || timeout < 0 {
+			add("api.timeout", "%q is not a valid duration", c.API.Timeout)
+		}
+	}
+
+	ratios := []struct {
+		path  string
+		value float64
+	}{
+		{"thresholds.max_stale_ratio", c.Thresholds.MaxStaleRatio},
+		{"thresholds.max_invalid_ratio", c.Thresholds.MaxInvalidRatio},
+		{"thresholds.min_efficiency", c.Thresholds.MinEfficiency},
+		{"thresholds.max_hashrate_drop", c.Thresholds.MaxHashrateDrop},
+	}
+
+	for _, ratio := range ratios {
+		if ratio.value < 0 || ratio.value > 1 {
+			add(ratio.path, "must be between 0 and 1")
+		}
+	}
+
+	if c.Thresholds.BaselineWindow < 1 {
+		add("thresholds.baseline_window", "must be at least 1")
+	}
+
+	if c.Thresholds.MaxFlaps < 0 {
+		add("thresholds.max_flaps", "can't be negative")
+	}
+
+	if c.Thresholds.MaxZScore < 0 {
+		add("thresholds.max_z_score", "can't be negative")
+	}
+
+	costs := []struct {
+		path  string
+		value float64
+	}{
+		{"electricity.watts_per_worker", c.Electricity.WattsPerWorker},
+		{"electricity.price_per_kwh", c.Electricity.PricePerKWh},
+		{"electricity.eth_price", c.Electricity.EthPrice},
+	}
+
+	for _, cost := range costs {
+		if cost.value < 0 {
+			add(cost.path, "can't be negative")
+		}
+	}
+
+	if c.Output.Format != "" && !format.Valid(c.Output.Format) {
+		add("output.format", "%q is not a valid format, must be text, table, json, csv, yaml or empty", c.Output.Format)
+	}
+
+	if _, _, err := net.SplitHostPort(c.Web.Listen); err != nil {
+		add("web.listen", "%q is not a host:port address", c.Web.Listen)
+	}
+
+	if (c.Web.Username == "") != (c.Web.Password == "") {
+		add("web.username", "username and password must be set together")
+	}
+
+	if ttl, err := time.ParseDuration(c.Web.CacheTTL); err != nil || ttl <= 0 {
+		add("web.cache_ttl", "%q is not a valid duration", c.Web.CacheTTL)
+	}
+
+	return problems
+}
+
+// resolvable returns whether a value is a named address or a valid literal address.
+func (c Config) resolvable(nameOrAddress string) bool {
+	_, named := c.Addresses[nameOrAddress]
+
+	return named || addressPattern.MatchString(nameOrAddress)
+}
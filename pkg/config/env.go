@@ -0,0 +1,89 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+)
+
+// errNotNumber is returned when a numeric environment variable can't be parsed.
+var errNotNumber = errors.New("not a number")
+
+// envOverride maps an environment variable onto the config value at a JSON path.
+type envOverride struct {
+	name string
+	path string
+	set  func(c *Config, value string) error
+}
+
+// envOverrides are the environment variables that override config values.
+var envOverrides = []envOverride{
+	{"FLEXPOOL_ADDRESS", "default_address", func(c *Config, value string) error {
+		c.DefaultAddress = value
+		return nil
+	}},
+	{"FLEXPOOL_API_HOST", "api.host", func(c *Config, value string) error {
+		c.API.Host = value
+		return nil
+	}},
+	{"FLEXPOOL_API_TIMEOUT", "api.timeout", func(c *Config, value string) error {
+		c.API.Timeout = value
+		return nil
+	}},
+	{"FLEXPOOL_OUTPUT_FORMAT", "output.format", func(c *Config, value string) error {
+		c.Output.Format = value
+		return nil
+	}},
+	{"FLEXPOOL_WEB_LISTEN", "web.listen", func(c *Config, value string) error {
+		c.Web.Listen = value
+		return nil
+	}},
+	{"FLEXPOOL_WEB_USERNAME", "web.username", func(c *Config, value string) error {
+		c.Web.Username = value
+		return nil
+	}},
+	{"FLEXPOOL_WEB_PASSWORD", "web.password", func(c *Config, value string) error {
+		c.Web.Password = value
+		return nil
+	}},
+	{"FLEXPOOL_WATTS_PER_WORKER", "electricity.watts_per_worker", setFloat(func(c *Config) *float64 {
+		return &c.Electricity.WattsPerWorker
+	})},
+	{"FLEXPOOL_PRICE_PER_KWH", "electricity.price_per_kwh", setFloat(func(c *Config) *float64 {
+		return &c.Electricity.PricePerKWh
+	})},
+	{"FLEXPOOL_ETH_PRICE", "electricity.eth_price", setFloat(func(c *Config) *float64 {
+		return &c.Electricity.EthPrice
+	})},
+	{"FLEXPOOL_MAX_STALE_RATIO", "thresholds.max_stale_ratio", setFloat(func(c *Config) *float64 {
+		return &c.Thresholds.MaxStaleRatio
+	})},
+	{"FLEXPOOL_MAX_INVALID_RATIO", "thresholds.max_invalid_ratio", setFloat(func(c *Config) *float64 {
+		return &c.Thresholds.MaxInvalidRatio
+	})},
+}
+
+// setFloat returns an envOverride setter that parses a float into the field returned by field.
+func setFloat(field func(c *Config) *float64) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errNotNumber
+		}
+
+		*field(c) = parsed
+
+		return nil
+	}
+}
+
+// envOverrideFor returns the environment variable that overrides the value at a JSON path, or an empty string if there
+// isn't one.
+func envOverrideFor(path string) string {
+	for _, override := range envOverrides {
+		if override.path == path {
+			return override.name
+		}
+	}
+
+	return ""
+}
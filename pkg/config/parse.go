@@ -0,0 +1,231 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// scanner walks the tokens of a JSON document alongside the Go type it will be decoded into, recording where each value
+// starts so problems can be reported with line numbers, and finding keys the type has no field for.
+type scanner struct {
+	data      []byte
+	decoder   *json.Decoder
+	positions map[string]int64
+	unknown   Errors
+}
+
+// Parse takes a file name (used in error messages) and the contents of a JSON config file, and parses and validates it.
+// Values missing from the file keep their defaults. Returns the Config and nil on success, or the defaults and Errors
+// with line numbers on failure.
+func Parse(name string, data []byte) (Config, error) {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+
+	cfg := Default()
+
+	s := scanner{
+		data:      data,
+		decoder:   json.NewDecoder(bytes.NewReader(data)),
+		positions: make(map[string]int64),
+	}
+
+	if err := s.value("", reflect.TypeOf(cfg)); err != nil {
+		if errors.As(err, &syntaxErr) {
+			return Default(), Errors{locate(name, data, syntaxErr.Offset-1, "", syntaxErr.Error())}
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return Default(), Errors{locate(name, data, int64(len(data)), "", "unexpected end of JSON input")}
+		}
+
+		return Default(), err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		if errors.As(err, &syntaxErr) {
+			return Default(), Errors{locate(name, data, syntaxErr.Offset-1, "", syntaxErr.Error())}
+		}
+
+		if errors.As(err, &typeErr) {
+			offset, ok := s.positions[typeErr.Field]
+			if !ok {
+				offset = typeErr.Offset
+			}
+
+			message := fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)
+
+			return Default(), Errors{locate(name, data, offset, typeErr.Field, message)}
+		}
+
+		return Default(), &Error{File: name, Message: err.Error()}
+	}
+
+	problems := append(s.unknown, cfg.Validate()...)
+
+	for _, problem := range problems {
+		problem.File = name
+
+		if offset, ok := s.positions[problem.Path]; ok {
+			problem.Line, problem.Column = lineColumn(data, offset)
+		}
+	}
+
+	if len(problems) > 0 {
+		sortErrors(problems)
+		return Default(), problems
+	}
+
+	return cfg, nil
+}
+
+// sortErrors sorts problems by their position in the file, keeping the order of problems at the same position.
+func sortErrors(problems Errors) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+
+		return problems[i].Column < problems[j].Column
+	})
+}
+
+// value reads the JSON value at path, recursing into objects and arrays. t is the type the value decodes into, or nil if
+// it isn't known.
+func (s *scanner) value(path string, t reflect.Type) error {
+	token, start, err := s.next()
+	if err != nil {
+		return err
+	}
+
+	// Object values keep the position of their key
+	if _, ok := s.positions[path]; !ok {
+		s.positions[path] = start
+	}
+
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	if delim == '[' {
+		var elem reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elem = t.Elem()
+		}
+
+		for i := 0; s.decoder.More(); i++ {
+			if err = s.value(fmt.Sprintf("%s[%d]", path, i), elem); err != nil {
+				return err
+			}
+		}
+	} else {
+		for s.decoder.More() {
+			if token, start, err = s.next(); err != nil {
+				return err
+			}
+
+			key, _ := token.(string)
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+
+			s.positions[keyPath] = start
+
+			var field reflect.Type
+			if t != nil && t.Kind() == reflect.Map {
+				field = t.Elem()
+			} else if t != nil && t.Kind() == reflect.Struct {
+				if field, ok = structField(t, key); !ok {
+					s.unknown = append(s.unknown, &Error{Path: keyPath, Message: "unknown field"})
+				}
+			}
+
+			if err = s.value(keyPath, field); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Consume the closing delimiter
+	_, err = s.decoder.Token()
+
+	return err
+}
+
+// next reads the next token. Returns the token, the offset it starts at and nil on success, or nil, 0 and error on
+// failure.
+func (s *scanner) next() (json.Token, int64, error) {
+	start := s.decoder.InputOffset()
+
+	// The decoder reports where the previous token ended, so skip the whitespace and separators after it
+	for start < int64(len(s.data)) && strings.IndexByte(" \t\r\n:,", s.data[start]) >= 0 {
+		start++
+	}
+
+	token, err := s.decoder.Token()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return token, start, nil
+}
+
+// structField finds the field of a struct type that a JSON key decodes into, matching encoding/json's case-insensitive
+// rules. Returns the field's type and whether it was found.
+func structField(t reflect.Type, key string) (reflect.Type, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if strings.EqualFold(name, key) {
+			return field.Type, true
+		}
+	}
+
+	return nil, false
+}
+
+// locate builds an Error for a byte offset into data.
+func locate(name string, data []byte, offset int64, path string, message string) *Error {
+	line, column := lineColumn(data, offset)
+
+	return &Error{File: name, Line: line, Column: column, Path: path, Message: message}
+}
+
+// lineColumn converts a byte offset into data to a 1-based line and column.
+func lineColumn(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+
+	return line, column
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// position is a 1-based line and column in a YAML document.
+type position struct {
+	line   int
+	column int
+}
+
+// ParseYAML takes a file name (used in error messages) and the contents of a YAML config file, and parses and validates
+// it with the same rules as Parse. Keys are the same as in a JSON config file. Returns the Config and nil on success, or
+// the defaults and Errors with line numbers on failure.
+func ParseYAML(name string, data []byte) (Config, error) {
+	var (
+		document yaml.Node
+		value    interface{}
+	)
+
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return Default(), Errors{&Error{File: name, Message: strings.TrimPrefix(err.Error(), "yaml: ")}}
+	}
+
+	// An empty file is an empty config, as in JSON with {}
+	value = map[string]interface{}{}
+
+	if len(document.Content) > 0 {
+		if err := document.Decode(&value); err != nil {
+			return Default(), Errors{&Error{File: name, Message: strings.TrimPrefix(err.Error(), "yaml: ")}}
+		}
+	}
+
+	// The document is checked as JSON, so unknown fields, type errors and invalid values are found exactly as they are in
+	// a JSON config
+	translated, err := json.Marshal(value)
+	if err != nil {
+		return Default(), Errors{&Error{File: name, Message: "keys must be strings"}}
+	}
+
+	cfg, err := Parse(name, translated)
+
+	var problems Errors
+	if !errors.As(err, &problems) {
+		return cfg, err
+	}
+
+	// Parse located each problem in the JSON translation, so it's moved to where its value is in the YAML document
+	positions := make(map[string]position)
+	yamlPositions(&document, "", positions)
+
+	for _, problem := range problems {
+		at := positions[problem.Path]
+		problem.Line, problem.Column = at.line, at.column
+	}
+
+	sortErrors(problems)
+
+	return Default(), problems
+}
+
+// yamlPositions walks a YAML node, recording the position of every value under path in the same form as the paths in
+// Errors. Values in a mapping keep the position of their key, as they do in JSON.
+func yamlPositions(node *yaml.Node, path string, positions map[string]position) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			yamlPositions(child, path, positions)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+
+			keyPath := key.Value
+			if path != "" {
+				keyPath = path + "." + key.Value
+			}
+
+			positions[keyPath] = position{key.Line, key.Column}
+			yamlPositions(node.Content[i+1], keyPath, positions)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+			positions[itemPath] = position{item.Line, item.Column}
+			yamlPositions(item, itemPath, positions)
+		}
+	}
+}
@@ -0,0 +1,416 @@
+package feed
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+// Event types. A snapshot carries the full current value of a topic, and is sent when a subscriber joins and when a
+// topic is first polled. An update carries only what changed since the previous poll.
+const (
+	Snapshot = "snapshot"
+	Update   = "update"
+)
+
+// Errors returned for invalid subscriptions, and by Subscription.Err once a subscription's events are closed.
+var (
+	ErrNoTopics     = errors.New("at least one topic is required")
+	ErrUnknownTopic = errors.New("topic must be pool/blocks, pool/hashrate or miner/{address}/workers|payments|hashrate")
+	ErrSlowConsumer = errors.New("subscriber fell too far behind and was disconnected")
+	ErrClosed       = errors.New("feed is closed")
+	ErrEmptyPoll    = errors.New("poll returned nothing where the previous poll had entries")
+)
+
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// Event is a change to a topic, sent to every subscriber of that topic. Data is the topic's full value for a Snapshot,
+// and the topic's change type for an Update - WorkerChanges for workers, the new blocks or payments for blocks and
+// payments, and the new value for hashrate.
+type Event struct {
+	Topic string      `json:"topic"`
+	Type  string      `json:"type"`
+	Time  int64       `json:"time"`
+	Data  interface{} `json:"data"`
+}
+
+// WorkerChanges contains the workers that appeared, changed or disappeared between two polls of a miner's workers.
+type WorkerChanges struct {
+	Added   []api.MinerWorker `json:"added,omitempty"`
+	Changed []api.MinerWorker `json:"changed,omitempty"`
+	Removed []string          `json:"removed,omitempty"`
+}
+
+// Options contains the settings for a Hub. Interval is how often each subscribed topic is polled, and Buffer is how
+// many events a subscriber can fall behind by before it's disconnected. OnError is called with any failed poll, and can
+// be nil. AllowedOrigins lists the origins, such as https://example.com, whose pages may open a WebSocket on top of the
+// Hub's own origin, with "*" allowing any.
+type Options struct {
+	Interval       time.Duration
+	Buffer         int
+	OnError        func(topic string, err error)
+	AllowedOrigins []string
+}
+
+// Hub polls the API for the topics its subscribers are interested in, and pushes changes to them. Each topic is polled
+// in one place however many subscribers it has, and only while it has at least one.
+type Hub struct {
+	options Options
+
+	mu     sync.Mutex
+	topics map[string]*topic
+	closed bool
+}
+
+// Subscription receives the events of one or more topics.
+type Subscription struct {
+	hub    *Hub
+	events chan Event
+	topics []string
+	err    error
+}
+
+// topic is a polled topic and its subscribers.
+type topic struct {
+	name        string
+	poll        func() (interface{}, error)
+	diff        func(previous interface{}, current interface{}) (interface{}, bool)
+	subscribers map[*Subscription]bool
+	snapshot    *Event
+	stop        chan struct{}
+}
+
+// NewHub takes a set of Options and creates a Hub with no subscribers. The interval defaults to 30 seconds, and the
+// buffer to 16 events.
+func NewHub(options Options) *Hub {
+	if options.Interval <= 0 {
+		options.Interval = 30 * time.Second
+	}
+
+	if options.Buffer < 1 {
+		options.Buffer = 16
+	}
+
+	return &Hub{options: options, topics: make(map[string]*topic)}
+}
+
+// Subscribe takes a list of topics and subscribes to them, immediately queueing the latest snapshot of any topic that
+// has already been polled. Returns the Subscription and nil on success, or nil and error if a topic is invalid or the
+// Hub is closed.
+func (h *Hub) Subscribe(topics ...string) (*Subscription, error) {
+	var unique []string
+	seen := make(map[string]bool)
+
+	for _, name := range topics {
+		if _, _, err := newTopic(name); err != nil {
+			return nil, err
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+
+	if len(unique) == 0 {
+		return nil, ErrNoTopics
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrClosed
+	}
+
+	// There's always room for one snapshot per topic on top of the buffer, so the replay can't overflow it
+	sub := &Subscription{hub: h, events: make(chan Event, h.options.Buffer+len(unique)), topics: unique}
+
+	for _, name := range unique {
+		t, ok := h.topics[name]
+		if !ok {
+			poll, diff, _ := newTopic(name)
+			t = &topic{name: name, poll: poll, diff: diff, subscribers: make(map[*Subscription]bool), stop: make(chan struct{})}
+			h.topics[name] = t
+
+			go h.run(t)
+		}
+
+		t.subscribers[sub] = true
+
+		if t.snapshot != nil {
+			sub.events <- *t.snapshot
+		}
+	}
+
+	return sub, nil
+}
+
+// Topics returns the names of the topics that currently have subscribers.
+func (h *Hub) Topics() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	names := make([]string, 0, len(h.topics))
+	for name := range h.topics {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Close stops polling every topic and closes every subscription with ErrClosed.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+
+	for _, t := range h.topics {
+		for sub := range t.subscribers {
+			h.drop(sub, ErrClosed)
+		}
+	}
+}
+
+// Events returns the channel the subscription's events are delivered on. It's closed when the subscription ends, after
+// which Err says why.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err returns why the subscription ended - ErrSlowConsumer, ErrClosed, or nil if it was closed by its owner or is
+// still open.
+func (s *Subscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	return s.err
+}
+
+// Close unsubscribes from every topic and closes the subscription's events.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.drop(s, nil)
+}
+
+// drop removes a subscription from its topics, stopping any topic left without subscribers, and closes its events.
+// Dropping an already dropped subscription does nothing. h.mu must be held.
+func (h *Hub) drop(sub *Subscription, reason error) {
+	dropped := false
+
+	for _, name := range sub.topics {
+		t, ok := h.topics[name]
+		if !ok || !t.subscribers[sub] {
+			continue
+		}
+
+		dropped = true
+		delete(t.subscribers, sub)
+
+		if len(t.subscribers) == 0 {
+			close(t.stop)
+			delete(h.topics, name)
+		}
+	}
+
+	if dropped {
+		sub.err = reason
+		close(sub.events)
+	}
+}
+
+// run polls a topic until it has no subscribers left. A failed poll is passed to OnError and skipped, so the next one
+// is diffed against the last good poll. A list that comes back empty when the last good poll wasn't is taken to be a
+// failed poll too, with ErrEmptyPoll, until a second poll in a row agrees, so a blip in the API doesn't remove
+// everything.
+func (h *Hub) run(t *topic) {
+	ticker := time.NewTicker(h.options.Interval)
+	defer ticker.Stop()
+
+	var (
+		previous interface{}
+		empty    int
+	)
+
+	for {
+		current, err := t.poll()
+
+		if err == nil && previous != nil && isEmptyList(current) && !isEmptyList(previous) {
+			if empty++; empty < 2 {
+				err = ErrEmptyPoll
+			}
+		}
+
+		if err != nil {
+			if h.options.OnError != nil {
+				h.options.OnError(t.name, err)
+			}
+		} else {
+			empty = 0
+			event := Event{Topic: t.name, Type: Snapshot, Time: time.Now().Unix(), Data: current}
+			publish := true
+
+			if previous != nil {
+				event.Type = Update
+				event.Data, publish = t.diff(previous, current)
+			}
+
+			previous = current
+
+			h.mu.Lock()
+			t.snapshot = &Event{Topic: t.name, Type: Snapshot, Time: event.Time, Data: current}
+
+			if publish {
+				h.publish(t, event)
+			}
+
+			h.mu.Unlock()
+		}
+
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish sends an event to a topic's subscribers. Subscribers whose buffer is full are dropped with ErrSlowConsumer
+// rather than holding up everyone else, and can resubscribe to get a fresh snapshot. h.mu must be held.
+func (h *Hub) publish(t *topic, event Event) {
+	for sub := range t.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			h.drop(sub, ErrSlowConsumer)
+		}
+	}
+}
+
+// newTopic takes a topic name and returns the functions that poll it and diff two polls of it, or ErrUnknownTopic.
+func newTopic(name string) (func() (interface{}, error), func(interface{}, interface{}) (interface{}, bool), error) {
+	parts := strings.Split(name, "/")
+
+	switch {
+	case name == "pool/blocks":
+		return pollPoolBlocks, diffBlocks, nil
+	case name == "pool/hashrate":
+		return pollPoolHashrate, diffValue, nil
+	case len(parts) != 3 || parts[0] != "miner" || !addressPattern.MatchString(parts[1]):
+		return nil, nil, ErrUnknownTopic
+	}
+
+	address := parts[1]
+
+	switch parts[2] {
+	case "workers":
+		return func() (interface{}, error) { return api.MinerGetWorkers(address) }, diffWorkers, nil
+	case "payments":
+		return func() (interface{}, error) { return pollMinerPayments(address) }, diffPayments, nil
+	case "hashrate":
+		return func() (interface{}, error) { return api.MinerGetCurrent(address) }, diffValue, nil
+	}
+
+	return nil, nil, ErrUnknownTopic
+}
+
+// pollPoolBlocks gets the first page of the pool's blocks.
+func pollPoolBlocks() (interface{}, error) {
+	blocks, err := api.PoolGetBlocks(0)
+	return blocks.Data, err
+}
+
+// pollPoolHashrate gets the pool's hashrate.
+func pollPoolHashrate() (interface{}, error) {
+	return api.PoolGetHashrate()
+}
+
+// pollMinerPayments gets the first page of a miner's payments.
+func pollMinerPayments(address string) ([]api.MinerPayment, error) {
+	payments, err := api.MinerGetPayments(address, 0)
+	return payments.Data, err
+}
+
+// isEmptyList reports whether a polled value is a list with nothing in it.
+func isEmptyList(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Slice && v.Len() == 0
+}
+
+// diffValue returns the current value if it differs from the previous one.
+func diffValue(previous interface{}, current interface{}) (interface{}, bool) {
+	return current, previous != current
+}
+
+// diffBlocks returns the blocks in the current poll that weren't in the previous one.
+func diffBlocks(previous interface{}, current interface{}) (interface{}, bool) {
+	seen := make(map[string]bool)
+	for _, block := range previous.([]api.Block) {
+		seen[block.Hash] = true
+	}
+
+	var added []api.Block
+	for _, block := range current.([]api.Block) {
+		if !seen[block.Hash] {
+			added = append(added, block)
+		}
+	}
+
+	return added, len(added) > 0
+}
+
+// diffPayments returns the payments in the current poll that weren't in the previous one.
+func diffPayments(previous interface{}, current interface{}) (interface{}, bool) {
+	seen := make(map[string]bool)
+	for _, payment := range previous.([]api.MinerPayment) {
+		seen[payment.Txid] = true
+	}
+
+	var added []api.MinerPayment
+	for _, payment := range current.([]api.MinerPayment) {
+		if !seen[payment.Txid] {
+			added = append(added, payment)
+		}
+	}
+
+	return added, len(added) > 0
+}
+
+// diffWorkers returns the workers that were added, changed or removed between two polls.
+func diffWorkers(previous interface{}, current interface{}) (interface{}, bool) {
+	var changes WorkerChanges
+
+	before := make(map[string]api.MinerWorker)
+	for _, worker := range previous.([]api.MinerWorker) {
+		before[worker.Name] = worker
+	}
+
+	for _, worker := range current.([]api.MinerWorker) {
+		old, ok := before[worker.Name]
+
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, worker)
+		case old != worker:
+			changes.Changed = append(changes.Changed, worker)
+		}
+
+		delete(before, worker.Name)
+	}
+
+	for _, worker := range previous.([]api.MinerWorker) {
+		if _, ok := before[worker.Name]; ok {
+			changes.Removed = append(changes.Removed, worker.Name)
+		}
+	}
+
+	return changes, len(changes.Added)+len(changes.Changed)+len(changes.Removed) > 0
+}
@@ -0,0 +1,315 @@
+package feed
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// websocketGUID is appended to the client's key to build the handshake's accept value, as defined by RFC 6455.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket opcodes.
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xA
+)
+
+// Timeouts for slow connections. A subscriber that can't take a frame within writeTimeout is disconnected, and SSE
+// streams send a comment every keepAlive so proxies don't close idle connections.
+const (
+	writeTimeout = 10 * time.Second
+	keepAlive    = 15 * time.Second
+)
+
+// Errors for invalid WebSocket requests and frames.
+var (
+	errBadHandshake = errors.New("invalid websocket handshake")
+	errBadFrame     = errors.New("invalid websocket frame")
+	errBadOrigin    = errors.New("websocket origin not allowed")
+)
+
+// errorEvent is sent as the last message when the feed ends a subscription.
+type errorEvent struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP streams the topics given in the request's topic query parameters, which can be repeated. WebSocket upgrade
+// requests get a WebSocket with one JSON Event per text message, and anything else gets a Server-Sent Events stream
+// with the Event's type as the event name. WebSockets aren't covered by CORS, so upgrades from a browser page on another
+// origin are refused unless that origin is in AllowedOrigins.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	upgrade := strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+	if upgrade && !h.allowOrigin(r) {
+		http.Error(w, errBadOrigin.Error(), http.StatusForbidden)
+		return
+	}
+
+	sub, err := h.Subscribe(r.URL.Query()["topic"]...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	defer sub.Close()
+
+	if upgrade {
+		serveWebSocket(w, r, sub)
+	} else {
+		serveSSE(w, r, sub)
+	}
+}
+
+// allowOrigin reports whether a WebSocket upgrade may go ahead. Requests without an Origin header don't come from a
+// browser page, so are allowed, as are pages on the Hub's own host and the origins in AllowedOrigins.
+func (h *Hub) allowOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, allowed := range h.options.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+
+	parsed, err := url.Parse(origin)
+
+	return err == nil && strings.EqualFold(parsed.Host, r.Host)
+}
+
+// serveSSE streams a subscription's events as Server-Sent Events until the client goes away or the subscription ends.
+func serveSSE(w http.ResponseWriter, r *http.Request, sub *Subscription) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case event, ok := <-sub.Events():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					data, _ := json.Marshal(errorEvent{Error: err.Error()})
+					fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+					flusher.Flush()
+				}
+
+				return
+			}
+
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		}
+
+		flusher.Flush()
+	}
+}
+
+// serveWebSocket upgrades the connection and streams a subscription's events as text messages until the client closes
+// the connection or the subscription ends. Messages from the client other than pings and closes are ignored.
+func serveWebSocket(w http.ResponseWriter, r *http.Request, sub *Subscription) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" || r.Header.Get("Sec-WebSocket-Version") != "13" ||
+		!strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+		http.Error(w, errBadHandshake.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websockets unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	conn, buffered, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+
+	defer conn.Close()
+
+	accept := sha1.Sum([]byte(key + websocketGUID))
+	fmt.Fprintf(buffered, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Accept: %s\r\n\r\n", base64.StdEncoding.EncodeToString(accept[:]))
+
+	if err := buffered.Flush(); err != nil {
+		return
+	}
+
+	ws := &websocket{conn: conn}
+	closed := make(chan struct{})
+
+	go func() {
+		defer close(closed)
+		ws.readLoop(buffered.Reader)
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					data, _ := json.Marshal(errorEvent{Error: err.Error()})
+					ws.writeFrame(opText, data)
+				}
+
+				ws.writeFrame(opClose, []byte{0x03, 0xE8})
+
+				return
+			}
+
+			data, _ := json.Marshal(event)
+			if err := ws.writeFrame(opText, data); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// websocket is a minimal server side WebSocket connection, supporting unfragmented text messages out and control frames
+// in.
+type websocket struct {
+	conn net.Conn
+	mu   sync.Mutex
+}
+
+// writeFrame writes a single unmasked frame. Returns nil on success, or error if the write fails or times out.
+func (ws *websocket) writeFrame(opcode byte, payload []byte) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	header := []byte{0x80 | opcode, 0}
+
+	switch {
+	case len(payload) < 126:
+		header[1] = byte(len(payload))
+	case len(payload) <= 0xFFFF:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(len(payload)))
+	default:
+		header[1] = 127
+		header = append(header, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(header[2:], uint64(len(payload)))
+	}
+
+	ws.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+
+	if _, err := ws.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// readLoop reads frames from the client, answering pings and returning once the client closes the connection or a
+// read fails.
+func (ws *websocket) readLoop(reader *bufio.Reader) {
+	for {
+		opcode, payload, err := readFrame(reader)
+		if err != nil {
+			return
+		}
+
+		switch opcode {
+		case opPing:
+			ws.writeFrame(opPong, payload)
+		case opClose:
+			ws.writeFrame(opClose, payload)
+			return
+		}
+	}
+}
+
+// readFrame reads a single masked client frame. Data frame payloads are discarded since the feed doesn't take any
+// messages. Returns the opcode, the payload of control frames and nil on success, or error on failure.
+func readFrame(reader *bufio.Reader) (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return 0, nil, err
+	}
+
+	opcode := header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(reader, extended[:]); err != nil {
+			return 0, nil, err
+		}
+
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(reader, extended[:]); err != nil {
+			return 0, nil, err
+		}
+
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+
+	// Clients must mask every frame, and control frames can't be longer than 125 bytes
+	if !masked || (opcode >= opClose && length > 125) {
+		return 0, nil, errBadFrame
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(reader, mask[:]); err != nil {
+		return 0, nil, err
+	}
+
+	if opcode < opClose {
+		_, err := io.CopyN(ioutil.Discard, reader, int64(length))
+		return opcode, nil, err
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return 0, nil, err
+	}
+
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return opcode, payload, nil
+}
@@ -0,0 +1,330 @@
+// Package format renders results from the api and utils packages for display or machine consumption. Write handles any
+// scalar, struct or slice of structs as a table, JSON, CSV or YAML, and the report types in this package have dedicated
+// text renderers used by the example binaries.
+package format
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats supported by Write.
+const (
+	Text  = "text"
+	Table = "table"
+	JSON  = "json"
+	CSV   = "csv"
+	YAML  = "yaml"
+)
+
+// ErrUnknownFormat is returned when an output format isn't supported.
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// Valid returns whether the given output format is supported by Write.
+func Valid(format string) bool {
+	switch format {
+	case Text, Table, JSON, CSV, YAML:
+		return true
+	}
+
+	return false
+}
+
+// Supported takes an output format and the formats a program can write, and returns whether the format is valid and one
+// of them.
+func Supported(format string, formats ...string) bool {
+	if !Valid(format) {
+		return false
+	}
+
+	for _, supported := range formats {
+		if format == supported {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Write takes a writer, an output format and a result, and writes the result in that format. Results can be scalars,
+// structs or slices of structs. Text and Table both produce a table - slices get a header row, and structs are written
+// as key/value pairs followed by a section for each slice field. CSV is laid out the same way, with sections separated by
+// a blank line. Returns nil on success, or error if the format isn't supported or the write fails.
+func Write(w io.Writer, format string, result interface{}) error {
+	switch format {
+	case Text, Table:
+		return writeTable(w, reflect.ValueOf(result))
+	case JSON:
+		return writeJSON(w, result)
+	case CSV:
+		return writeCSV(w, reflect.ValueOf(result))
+	case YAML:
+		return writeYAML(w, reflect.ValueOf(result), 0)
+	}
+
+	return ErrUnknownFormat
+}
+
+// writeJSON writes the result as indented JSON.
+func writeJSON(w io.Writer, result interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(result)
+}
+
+// writeTable writes slices as a table with a header row, structs as a two column key/value table followed by a table for
+// each slice field, and scalars as-is.
+func writeTable(w io.Writer, value reflect.Value) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	switch value.Kind() {
+	case reflect.Slice:
+		keys, _ := flatten(reflect.New(value.Type().Elem()).Elem(), "")
+		fmt.Fprintln(table, strings.ToUpper(strings.Join(keys, "\t")))
+
+		for i := 0; i < value.Len(); i++ {
+			_, values := flatten(value.Index(i), "")
+			fmt.Fprintln(table, strings.Join(values, "\t"))
+		}
+	case reflect.Struct:
+		keys, values := flatten(value, "")
+
+		for i := range keys {
+			fmt.Fprintf(table, "%s\t%s\n", keys[i], values[i])
+		}
+
+		if err := table.Flush(); err != nil {
+			return err
+		}
+
+		for _, section := range sliceFields(value) {
+			fmt.Fprintf(w, "\n%s:\n", section.name)
+
+			if err := writeTable(w, section.value); err != nil {
+				return err
+			}
+		}
+	default:
+		fmt.Fprintln(table, formatScalar(value))
+	}
+
+	return table.Flush()
+}
+
+// writeCSV writes slices as one row per item, structs as a single row followed by a section for each slice field, and
+// scalars as a single "value" column, each with a header row.
+func writeCSV(w io.Writer, value reflect.Value) error {
+	writer := csv.NewWriter(w)
+
+	switch value.Kind() {
+	case reflect.Slice:
+		keys, _ := flatten(reflect.New(value.Type().Elem()).Elem(), "")
+		if err := writer.Write(keys); err != nil {
+			return err
+		}
+
+		for i := 0; i < value.Len(); i++ {
+			_, values := flatten(value.Index(i), "")
+			if err := writer.Write(values); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		keys, values := flatten(value, "")
+		if err := writer.WriteAll([][]string{keys, values}); err != nil {
+			return err
+		}
+
+		for _, section := range sliceFields(value) {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+
+			if err := writeCSV(w, section.value); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := writer.WriteAll([][]string{{"value"}, {formatScalar(value)}}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeYAML recursively writes the value as a YAML document, keeping struct fields in declaration order.
+func writeYAML(w io.Writer, value reflect.Value, indent int) error {
+	padding := strings.Repeat("  ", indent)
+
+	switch value.Kind() {
+	case reflect.Slice:
+		if value.Len() == 0 {
+			_, err := fmt.Fprintf(w, "%s[]\n", padding)
+			return err
+		}
+
+		for i := 0; i < value.Len(); i++ {
+			item := value.Index(i)
+
+			if item.Kind() != reflect.Struct {
+				if _, err := fmt.Fprintf(w, "%s- %s\n", padding, formatYAMLScalar(item)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			// Write the struct one level deeper, then swap the leading indent of its first line for the list marker
+			var builder strings.Builder
+			if err := writeYAML(&builder, item, indent+1); err != nil {
+				return err
+			}
+
+			if _, err := fmt.Fprintf(w, "%s- %s", padding, strings.TrimPrefix(builder.String(), padding+"  ")); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !visible(field) {
+				continue
+			}
+
+			key := fieldName(field)
+			fieldValue := value.Field(i)
+
+			if fieldValue.Kind() == reflect.Struct || (fieldValue.Kind() == reflect.Slice && fieldValue.Len() > 0) {
+				if _, err := fmt.Fprintf(w, "%s%s:\n", padding, key); err != nil {
+					return err
+				}
+
+				if err := writeYAML(w, fieldValue, indent+1); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if fieldValue.Kind() == reflect.Slice {
+				if _, err := fmt.Fprintf(w, "%s%s: []\n", padding, key); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "%s%s: %s\n", padding, key, formatYAMLScalar(fieldValue)); err != nil {
+				return err
+			}
+		}
+	default:
+		_, err := fmt.Fprintf(w, "%s%s\n", padding, formatYAMLScalar(value))
+		return err
+	}
+
+	return nil
+}
+
+// section is a named slice field of a struct, written after the struct's scalar fields.
+type section struct {
+	name  string
+	value reflect.Value
+}
+
+// sliceFields returns the visible slice fields of a struct value in declaration order.
+func sliceFields(value reflect.Value) []section {
+	var sections []section
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+
+		if visible(field) && value.Field(i).Kind() == reflect.Slice {
+			sections = append(sections, section{fieldName(field), value.Field(i)})
+		}
+	}
+
+	return sections
+}
+
+// flatten takes a struct value and returns its visible non-slice field names (from their json tags) and formatted
+// values, with nested structs flattened into dotted names.
+func flatten(value reflect.Value, prefix string) ([]string, []string) {
+	var keys, values []string
+
+	if value.Kind() != reflect.Struct {
+		return []string{strings.TrimSuffix(prefix, ".")}, []string{formatScalar(value)}
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !visible(field) || value.Field(i).Kind() == reflect.Slice {
+			continue
+		}
+
+		key := prefix + fieldName(field)
+
+		if value.Field(i).Kind() == reflect.Struct {
+			nestedKeys, nestedValues := flatten(value.Field(i), key+".")
+			keys = append(keys, nestedKeys...)
+			values = append(values, nestedValues...)
+
+			continue
+		}
+
+		keys = append(keys, key)
+		values = append(values, formatScalar(value.Field(i)))
+	}
+
+	return keys, values
+}
+
+// visible returns whether a struct field is written, which it is if it's exported and not hidden from JSON with a "-"
+// tag, so every format shows the same fields.
+func visible(field reflect.StructField) bool {
+	return field.PkgPath == "" && field.Tag.Get("json") != "-"
+}
+
+// fieldName returns the json tag name of a struct field, or its Go name if it has no tag.
+func fieldName(field reflect.StructField) string {
+	if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+		return tag
+	}
+
+	return field.Name
+}
+
+// formatScalar formats a non-struct value for table and CSV output.
+func formatScalar(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case reflect.Invalid:
+		return ""
+	}
+
+	return fmt.Sprint(value.Interface())
+}
+
+// formatYAMLScalar formats a non-struct value for YAML output, quoting strings so they're never misread as other types.
+func formatYAMLScalar(value reflect.Value) string {
+	if value.Kind() == reflect.String {
+		return strconv.Quote(value.String())
+	}
+
+	if value.Kind() == reflect.Invalid {
+		return "null"
+	}
+
+	return formatScalar(value)
+}
@@ -0,0 +1,170 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/utils"
+)
+
+// MinerReport contains the output of the minerinfo command. Field names and units are stable, so scripts can rely on the
+// JSON and CSV output. Currency values are in gwei, and RoundShare is a percentage as returned by the API.
+type MinerReport struct {
+	Address                   string             `json:"address"`
+	BalanceGwei               uint               `json:"balance_gwei"`
+	MinPayoutThresholdGwei    uint               `json:"min_payout_threshold_gwei"`
+	PoolDonation              float64            `json:"pool_donation"`
+	RoundShare                float64            `json:"round_share"`
+	EstimatedDailyRevenueGwei uint               `json:"estimated_daily_revenue_gwei"`
+	TotalPaidGwei             uint               `json:"total_paid_gwei"`
+	TotalDonatedGwei          uint               `json:"total_donated_gwei"`
+	Workers                   []api.MinerWorker  `json:"workers"`
+	Payments                  []api.MinerPayment `json:"payments"`
+	Blocks                    []api.Block        `json:"blocks"`
+}
+
+// PoolReport contains the output of the poolinfo command. Field names and units are stable, so scripts can rely on the
+// JSON and CSV output. Hashrates are in hashes/second, UncleRate is a fraction between 0 and 1, and the averages are
+// taken over BlocksSampled blocks.
+type PoolReport struct {
+	MinersOnline            int              `json:"miners_online"`
+	WorkersOnline           int              `json:"workers_online"`
+	Hashrate                api.PoolHashrate `json:"hashrate"`
+	PPLNSShareWindowSeconds uint             `json:"pplns_share_window_seconds"`
+	UncleRate               float64          `json:"uncle_rate"`
+	AverageBlockRewardGwei  uint             `json:"average_block_reward_gwei"`
+	AverageBlocksPerDay     float64          `json:"average_blocks_per_day"`
+	BlocksSampled           int              `json:"blocks_sampled"`
+}
+
+// WriteMinerReport takes a writer, an output format and a MinerReport, and writes the report in that format. The text
+// format is the human-readable layout from WriteMinerText.
+func WriteMinerReport(w io.Writer, format string, report MinerReport) error {
+	if format == Text {
+		return WriteMinerText(w, report)
+	}
+
+	return Write(w, format, report)
+}
+
+// WritePoolReport takes a writer, an output format and a PoolReport, and writes the report in that format. The text
+// format is the human-readable layout from WritePoolText.
+func WritePoolReport(w io.Writer, format string, report PoolReport) error {
+	if format == Text {
+		return WritePoolText(w, report)
+	}
+
+	return Write(w, format, report)
+}
+
+// WriteMinerText writes a MinerReport in a human-readable layout, with currency in eth and hashrates in MH/s.
+func WriteMinerText(w io.Writer, report MinerReport) error {
+	var err error
+
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("Flexpool Miner '%s' Stats\n-\n\n", report.Address)
+	printf("Unpaid Balance: %.8f eth\n", utils.ConvertGweiToEth(report.BalanceGwei))
+
+	printf("Min Payout Threshold: %.4f eth \t Donation Percent: %.4f%% \t Round Share: %.8f%%\n",
+		utils.ConvertGweiToEth(report.MinPayoutThresholdGwei),
+		report.PoolDonation,
+		report.RoundShare)
+
+	printf("Estimated Daily Eth: %.8f eth \t Total Paid: %.8f eth \t Total Donated: %.8f eth\n\n",
+		utils.ConvertGweiToEth(report.EstimatedDailyRevenueGwei),
+		utils.ConvertGweiToEth(report.TotalPaidGwei),
+		utils.ConvertGweiToEth(report.TotalDonatedGwei))
+
+	printf("Workers:\n")
+
+	if len(report.Workers) > 0 {
+		for _, worker := range report.Workers {
+			printf("\t %s (effective hashrate: %dMH/s) \t (valid: %d, stale: %d, invalid: %d)\n",
+				worker.Name,
+				utils.ConvertHashrate(worker.EffectiveHashrate, utils.HashesPerSecond, utils.MegaHashesPerSecond),
+				worker.ValidShares,
+				worker.StaleShares,
+				worker.InvalidShares)
+		}
+	} else {
+		printf("\t None currently active.\n")
+	}
+
+	printf("\nLast 10 payments:\n")
+
+	if len(report.Payments) > 0 {
+		for _, payment := range report.Payments {
+			printf("\t Txn: %s (amount: %.8f eth) \t %s\n",
+				payment.Txid,
+				utils.ConvertGweiToEth(payment.Amount),
+				time.Unix(int64(payment.Timestamp), 0))
+		}
+	} else {
+		printf("\t No payments made.\n")
+	}
+
+	printf("\nLast 10 blocks mined:\n")
+
+	if len(report.Blocks) > 0 {
+		for _, block := range report.Blocks {
+			printf("\t %d (type: %s) (reward: %.8f eth) \t %s\n",
+				block.Number,
+				block.Type,
+				utils.ConvertGweiToEth(block.TotalRewards),
+				time.Unix(int64(block.Timestamp), 0))
+		}
+	} else {
+		printf("\t No blocks mined yet.\n")
+	}
+
+	return err
+}
+
+// WritePoolText writes a PoolReport in a human-readable layout, with hashrates in GH/s.
+func WritePoolText(w io.Writer, report PoolReport) error {
+	var err error
+
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	gigahashes := func(hashrate uint) uint {
+		return utils.ConvertHashrate(hashrate, utils.HashesPerSecond, utils.GigaHashesPerSecond)
+	}
+
+	printf("Flexpool Stats\n-\n\n")
+	printf("Miners: %d (Workers: %d)\n\n", report.MinersOnline, report.WorkersOnline)
+	printf("Hashrate: %dGH/s (total)\n", gigahashes(report.Hashrate.Total))
+	printf("\tAs: %dGH/s\n", gigahashes(report.Hashrate.As))
+	printf("\tAu: %dGH/s\n", gigahashes(report.Hashrate.Au))
+	printf("\tEu: %dGH/s\n", gigahashes(report.Hashrate.Eu))
+	printf("\tSa: %dGH/s\n", gigahashes(report.Hashrate.Sa))
+	printf("\tUs: %dGH/s\n\n", gigahashes(report.Hashrate.Us))
+
+	printf("PPLNS share window: %s (hh:mm:ss)\n", secondsToHhMmSs(report.PPLNSShareWindowSeconds))
+	printf("Uncle rate: %.2f%%\n", report.UncleRate*100)
+	printf("Average blocks per day: %.2f (average reward: %.8f eth)\n",
+		report.AverageBlocksPerDay,
+		utils.ConvertGweiToEth(report.AverageBlockRewardGwei))
+	printf("\t* Averages and uncle rate are over a %d block period\n", report.BlocksSampled)
+
+	return err
+}
+
+// secondsToHhMmSs formats a number of seconds as hh:mm:ss.
+func secondsToHhMmSs(secondsIn uint) string {
+	hours := secondsIn / 60 / 60
+	minutes := (secondsIn / 60) % 60
+	seconds := secondsIn % 60
+
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
@@ -0,0 +1,155 @@
+package gql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/cache"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// schemaSource is the GraphQL schema served by the Handler.
+//
+//go:embed schema.graphql
+var schemaSource string
+
+// Defaults for Options.
+const (
+	DefaultMaxDepth       = 8
+	DefaultMaxParallelism = 10
+	DefaultMaxAddresses   = 5
+)
+
+// Options contains the limits a Handler puts on each request, so one query can't fan out into an unbounded number of
+// API calls. MaxDepth is how deeply fields can be nested, MaxParallelism how many resolvers run at once, and
+// MaxAddresses how many distinct miner addresses a request can ask about.
+type Options struct {
+	MaxDepth       int
+	MaxParallelism int
+	MaxAddresses   int
+}
+
+// Handler serves GraphQL queries over HTTP, as a JSON POST body or GET query parameters. Each request gets its own
+// loader, so the API calls behind it are made concurrently and at most once each however many fields need them.
+type Handler struct {
+	schema       *graphql.Schema
+	maxAddresses int
+}
+
+// request is a GraphQL request body.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// loader deduplicates the API calls made while resolving a single request, and counts the miner addresses it asks
+// about.
+type loader struct {
+	cache        *cache.Cache
+	maxAddresses int
+
+	mu        sync.Mutex
+	addresses map[string]bool
+}
+
+// loaderKey is the context key a request's loader is stored under.
+type loaderKey struct{}
+
+// NewHandler takes a set of Options, and parses the schema and creates a Handler. Zero options fall back to their
+// defaults.
+func NewHandler(options Options) *Handler {
+	if options.MaxDepth <= 0 {
+		options.MaxDepth = DefaultMaxDepth
+	}
+
+	if options.MaxParallelism <= 0 {
+		options.MaxParallelism = DefaultMaxParallelism
+	}
+
+	if options.MaxAddresses <= 0 {
+		options.MaxAddresses = DefaultMaxAddresses
+	}
+
+	schema := graphql.MustParseSchema(schemaSource, &resolver{},
+		graphql.MaxDepth(options.MaxDepth), graphql.MaxParallelism(options.MaxParallelism))
+
+	return &Handler{schema: schema, maxAddresses: options.MaxAddresses}
+}
+
+// ServeHTTP executes a GraphQL query and writes the result.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				http.Error(w, "invalid variables: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := h.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Exec takes a query, operation name and variables, and executes the query with a new loader.
+func (h *Handler) Exec(ctx context.Context, query string, operationName string, variables map[string]interface{}) *graphql.Response {
+	// Values only live as long as the request, so the TTL just needs to outlast it
+	ctx = context.WithValue(ctx, loaderKey{}, &loader{cache: cache.New(time.Hour), maxAddresses: h.maxAddresses,
+		addresses: make(map[string]bool)})
+
+	return h.schema.Exec(ctx, query, operationName, variables)
+}
+
+// load returns the value for key from the request's loader, calling fetch if no other resolver has asked for it yet.
+// Concurrent resolvers asking for the same key share a single call.
+func load(ctx context.Context, key string, fetch func() (interface{}, error)) (interface{}, error) {
+	l, ok := ctx.Value(loaderKey{}).(*loader)
+	if !ok {
+		return fetch()
+	}
+
+	return l.cache.Get(key, fetch)
+}
+
+// useAddress records that the request asks about a miner address. Addresses differing only in case are the same miner.
+// Returns nil on success, or ErrTooManyAddresses if the request has already asked about as many others as it may.
+func useAddress(ctx context.Context, address string) error {
+	l, ok := ctx.Value(loaderKey{}).(*loader)
+	if !ok {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	address = strings.ToLower(address)
+	if !l.addresses[address] && len(l.addresses) >= l.maxAddresses {
+		return ErrTooManyAddresses
+	}
+
+	l.addresses[address] = true
+
+	return nil
+}
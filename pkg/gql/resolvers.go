@@ -0,0 +1,741 @@
+package gql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+// maxFirst is the most items a connection returns at once.
+const maxFirst = 100
+
+// Errors returned for invalid arguments, and for requests over the Handler's limits.
+var (
+	ErrInvalidAddress   = errors.New("address must be 0x followed by 40 hex digits")
+	ErrInvalidFirst     = errors.New("first must be between 0 and 100")
+	ErrInvalidCursor    = errors.New("invalid cursor")
+	ErrTooManyAddresses = errors.New("too many distinct miner addresses in one request")
+)
+
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// resolver is the root Query resolver.
+type resolver struct{}
+
+// Resolvers for the schema's object types, each wrapping the api package value it's built from. Fields are resolved by
+// the methods of the same name.
+type minerResolver struct {
+	address string
+}
+
+type workerResolver struct {
+	address string
+	worker  api.MinerWorker
+}
+
+type poolResolver struct{}
+
+type blockResolver struct {
+	block api.Block
+}
+
+type paymentResolver struct {
+	payment api.MinerPayment
+}
+
+type hashrateResolver struct {
+	current api.WorkerCurrentStats
+}
+
+type dailyStatsResolver struct {
+	effectiveHashrate float64
+	reportedHashrate  float64
+	validShares       int
+	staleShares       int
+	invalidShares     int
+}
+
+type workerCountResolver struct {
+	count api.MinerWorkerCount
+}
+
+type chartPointResolver struct {
+	point api.WorkerChartData
+}
+
+type minerDetailsResolver struct {
+	details api.MinerDetails
+}
+
+type poolHashrateResolver struct {
+	hashrate api.PoolHashrate
+}
+
+type blockCountResolver struct {
+	count api.PoolBlockCount
+}
+
+type topMinerResolver struct {
+	miner api.PoolMinerInfo
+}
+
+type pageInfoResolver struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+// edge is an item in a connection, with the cursor that points at it.
+type edge struct {
+	cursor string
+	node   interface{}
+}
+
+// connection is a page of a paginated list.
+type connection struct {
+	edges      []edge
+	pageInfo   *pageInfoResolver
+	totalCount int
+}
+
+type paymentConnectionResolver struct {
+	connection
+}
+
+type paymentEdgeResolver struct {
+	edge
+}
+
+type blockConnectionResolver struct {
+	connection
+}
+
+type blockEdgeResolver struct {
+	edge
+}
+
+// connectionArgs are the arguments of a paginated field.
+type connectionArgs struct {
+	First *int32
+	After *string
+}
+
+// Miner resolves a miner by address.
+func (r *resolver) Miner(ctx context.Context, args struct{ Address string }) (*minerResolver, error) {
+	if !addressPattern.MatchString(args.Address) {
+		return nil, ErrInvalidAddress
+	}
+
+	if err := useAddress(ctx, args.Address); err != nil {
+		return nil, err
+	}
+
+	return &minerResolver{address: args.Address}, nil
+}
+
+// Pool resolves the pool.
+func (r *resolver) Pool() *poolResolver {
+	return &poolResolver{}
+}
+
+// Address resolves the miner's address, as given to the miner field.
+func (m *minerResolver) Address() string {
+	return m.address
+}
+
+// Balance resolves the miner's unpaid balance in gwei.
+func (m *minerResolver) Balance(ctx context.Context) (float64, error) {
+	value, err := load(ctx, "miner/"+m.address+"/balance", func() (interface{}, error) {
+		return api.MinerGetBalance(m.address)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(value.(uint)), nil
+}
+
+// Current resolves the miner's current effective and reported hashrates.
+func (m *minerResolver) Current(ctx context.Context) (*hashrateResolver, error) {
+	value, err := load(ctx, "miner/"+m.address+"/current", func() (interface{}, error) {
+		return api.MinerGetCurrent(m.address)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hashrateResolver{current: value.(api.WorkerCurrentStats)}, nil
+}
+
+// Daily resolves the miner's hashrates and shares over the last day.
+func (m *minerResolver) Daily(ctx context.Context) (*dailyStatsResolver, error) {
+	value, err := load(ctx, "miner/"+m.address+"/daily", func() (interface{}, error) {
+		return api.MinerGetDaily(m.address)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	daily := value.(api.MinerDailyStats)
+
+	return &dailyStatsResolver{
+		effectiveHashrate: daily.EffectiveHashrate,
+		reportedHashrate:  daily.ReportedHashrate,
+		validShares:       daily.ValidShares,
+		staleShares:       daily.StaleShares,
+		invalidShares:     daily.InvalidShares,
+	}, nil
+}
+
+// WorkerCount resolves the number of the miner's workers that are online and offline.
+func (m *minerResolver) WorkerCount(ctx context.Context) (*workerCountResolver, error) {
+	value, err := load(ctx, "miner/"+m.address+"/workerCount", func() (interface{}, error) {
+		return api.MinerGetWorkerCount(m.address)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workerCountResolver{count: value.(api.MinerWorkerCount)}, nil
+}
+
+// Workers resolves the miner's workers, optionally only those that are online or offline.
+func (m *minerResolver) Workers(ctx context.Context, args struct{ Online *bool }) ([]*workerResolver, error) {
+	workers, err := m.workers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := []*workerResolver{}
+	for _, worker := range workers {
+		if args.Online == nil || *args.Online == worker.Online {
+			resolvers = append(resolvers, &workerResolver{address: m.address, worker: worker})
+		}
+	}
+
+	return resolvers, nil
+}
+
+// Worker resolves one of the miner's workers by name, or null if there's no such worker.
+func (m *minerResolver) Worker(ctx context.Context, args struct{ Name string }) (*workerResolver, error) {
+	workers, err := m.workers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, worker := range workers {
+		if worker.Name == args.Name {
+			return &workerResolver{address: m.address, worker: worker}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Payments resolves a page of the miner's payments, newest first.
+func (m *minerResolver) Payments(ctx context.Context, args connectionArgs) (*paymentConnectionResolver, error) {
+	conn, err := paginate(args, func(page int) ([]interface{}, int, int, error) {
+		value, err := load(ctx, "miner/"+m.address+"/payments?page="+strconv.Itoa(page), func() (interface{}, error) {
+			return api.MinerGetPayments(m.address, page)
+		})
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		data := value.(api.MinerPaymentData)
+
+		items := make([]interface{}, len(data.Data))
+		for i, payment := range data.Data {
+			items[i] = payment
+		}
+
+		return items, data.ItemsPerPage, data.TotalItems, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &paymentConnectionResolver{conn}, nil
+}
+
+// Blocks resolves a page of the blocks the miner found, newest first.
+func (m *minerResolver) Blocks(ctx context.Context, args connectionArgs) (*blockConnectionResolver, error) {
+	conn, err := paginate(args, func(page int) ([]interface{}, int, int, error) {
+		value, err := load(ctx, "miner/"+m.address+"/blocks?page="+strconv.Itoa(page), func() (interface{}, error) {
+			return api.MinerGetBlocks(m.address, page)
+		})
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		data := value.(api.MinerBlockData)
+
+		return blockItems(data.Data), data.ItemsPerPage, data.TotalItems, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockConnectionResolver{conn}, nil
+}
+
+// Details resolves the miner's payout settings and join date.
+func (m *minerResolver) Details(ctx context.Context) (*minerDetailsResolver, error) {
+	value, err := load(ctx, "miner/"+m.address+"/details", func() (interface{}, error) {
+		return api.MinerGetDetails(m.address)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &minerDetailsResolver{details: value.(api.MinerDetails)}, nil
+}
+
+// EstimatedDailyRevenue resolves the miner's estimated revenue per day in gwei.
+func (m *minerResolver) EstimatedDailyRevenue(ctx context.Context) (float64, error) {
+	value, err := load(ctx, "miner/"+m.address+"/estimatedDailyRevenue", func() (interface{}, error) {
+		return api.MinerGetEstimatedDailyRevenue(m.address)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(value.(uint)), nil
+}
+
+// RoundShare resolves the miner's share of the current round as a percentage.
+func (m *minerResolver) RoundShare(ctx context.Context) (float64, error) {
+	value, err := load(ctx, "miner/"+m.address+"/roundShare", func() (interface{}, error) {
+		return api.MinerGetRoundShare(m.address)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return value.(float64), nil
+}
+
+// TotalPaid resolves the total paid to the miner in gwei.
+func (m *minerResolver) TotalPaid(ctx context.Context) (float64, error) {
+	value, err := load(ctx, "miner/"+m.address+"/totalPaid", func() (interface{}, error) {
+		return api.MinerGetTotalPaid(m.address)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(value.(uint)), nil
+}
+
+// TotalDonated resolves the total the miner has donated to the pool in gwei.
+func (m *minerResolver) TotalDonated(ctx context.Context) (float64, error) {
+	value, err := load(ctx, "miner/"+m.address+"/totalDonated", func() (interface{}, error) {
+		return api.MinerGetTotalDonated(m.address)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(value.(uint)), nil
+}
+
+// workers loads the miner's workers, shared by the workers and worker fields.
+func (m *minerResolver) workers(ctx context.Context) ([]api.MinerWorker, error) {
+	value, err := load(ctx, "miner/"+m.address+"/workers", func() (interface{}, error) {
+		return api.MinerGetWorkers(m.address)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]api.MinerWorker), nil
+}
+
+// Worker fields, read from the worker as the miner's worker list returned it.
+func (w *workerResolver) Name() string               { return w.worker.Name }
+func (w *workerResolver) Online() bool               { return w.worker.Online }
+func (w *workerResolver) ReportedHashrate() float64  { return float64(w.worker.ReportedHashrate) }
+func (w *workerResolver) EffectiveHashrate() float64 { return float64(w.worker.EffectiveHashrate) }
+func (w *workerResolver) ValidShares() int32         { return int32(w.worker.ValidShares) }
+func (w *workerResolver) StaleShares() int32         { return int32(w.worker.StaleShares) }
+func (w *workerResolver) InvalidShares() int32       { return int32(w.worker.InvalidShares) }
+func (w *workerResolver) LastSeen() int32            { return int32(w.worker.LastSeen) }
+
+// Daily resolves the worker's hashrates and shares over the last day.
+func (w *workerResolver) Daily(ctx context.Context) (*dailyStatsResolver, error) {
+	value, err := load(ctx, "worker/"+w.address+"/"+w.worker.Name+"/daily", func() (interface{}, error) {
+		return api.WorkerGetDaily(w.address, w.worker.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	daily := value.(api.WorkerDailyStats)
+
+	return &dailyStatsResolver{
+		effectiveHashrate: float64(daily.EffectiveHashrate),
+		reportedHashrate:  float64(daily.ReportedHashrate),
+		validShares:       daily.ValidShares,
+		staleShares:       daily.StaleShares,
+		invalidShares:     daily.InvalidShares,
+	}, nil
+}
+
+// Chart resolves the worker's hashrate and share chart.
+func (w *workerResolver) Chart(ctx context.Context) ([]*chartPointResolver, error) {
+	value, err := load(ctx, "worker/"+w.address+"/"+w.worker.Name+"/chart", func() (interface{}, error) {
+		return api.WorkerGetChart(w.address, w.worker.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points := []*chartPointResolver{}
+	for _, point := range value.([]api.WorkerChartData) {
+		points = append(points, &chartPointResolver{point: point})
+	}
+
+	return points, nil
+}
+
+// Hashrate resolves the pool's hashrate, in total and by region.
+func (p *poolResolver) Hashrate(ctx context.Context) (*poolHashrateResolver, error) {
+	value, err := load(ctx, "pool/hashrate", func() (interface{}, error) {
+		return api.PoolGetHashrate()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &poolHashrateResolver{hashrate: value.(api.PoolHashrate)}, nil
+}
+
+// MinersOnline resolves the number of miners online.
+func (p *poolResolver) MinersOnline(ctx context.Context) (int32, error) {
+	value, err := load(ctx, "pool/minersOnline", func() (interface{}, error) {
+		return api.PoolGetMinersOnline()
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(value.(int)), nil
+}
+
+// WorkersOnline resolves the number of workers online.
+func (p *poolResolver) WorkersOnline(ctx context.Context) (int32, error) {
+	value, err := load(ctx, "pool/workersOnline", func() (interface{}, error) {
+		return api.PoolGetWorkersOnline()
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(value.(int)), nil
+}
+
+// CurrentLuck resolves the pool's current luck as a percentage.
+func (p *poolResolver) CurrentLuck(ctx context.Context) (float64, error) {
+	value, err := load(ctx, "pool/currentLuck", func() (interface{}, error) {
+		return api.PoolGetCurrentLuck()
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return value.(float64), nil
+}
+
+// AverageLuck resolves the pool's average luck.
+func (p *poolResolver) AverageLuck(ctx context.Context) (float64, error) {
+	luck, err := p.averageLuckRoundTime(ctx)
+	return luck.Luck, err
+}
+
+// AverageRoundTime resolves the pool's average round time in seconds.
+func (p *poolResolver) AverageRoundTime(ctx context.Context) (float64, error) {
+	luck, err := p.averageLuckRoundTime(ctx)
+	return luck.RoundTime, err
+}
+
+// AverageBlockReward resolves the pool's average block reward in gwei.
+func (p *poolResolver) AverageBlockReward(ctx context.Context) (float64, error) {
+	value, err := load(ctx, "pool/averageBlockReward", func() (interface{}, error) {
+		return api.PoolGetAverageBlockReward()
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(value.(uint)), nil
+}
+
+// BlockCount resolves the number of the pool's blocks that are confirmed and unconfirmed.
+func (p *poolResolver) BlockCount(ctx context.Context) (*blockCountResolver, error) {
+	value, err := load(ctx, "pool/blockCount", func() (interface{}, error) {
+		return api.PoolGetBlockCount()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockCountResolver{count: value.(api.PoolBlockCount)}, nil
+}
+
+// Blocks resolves a page of the pool's blocks, newest first.
+func (p *poolResolver) Blocks(ctx context.Context, args connectionArgs) (*blockConnectionResolver, error) {
+	conn, err := paginate(args, func(page int) ([]interface{}, int, int, error) {
+		value, err := load(ctx, "pool/blocks?page="+strconv.Itoa(page), func() (interface{}, error) {
+			return api.PoolGetBlocks(page)
+		})
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		data := value.(api.PoolBlockData)
+
+		return blockItems(data.Data), data.ItemsPerPage, data.TotalItems, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockConnectionResolver{conn}, nil
+}
+
+// TopMiners resolves the pool's top miners.
+func (p *poolResolver) TopMiners(ctx context.Context) ([]*topMinerResolver, error) {
+	value, err := load(ctx, "pool/topMiners", func() (interface{}, error) {
+		return api.PoolGetTopMiners()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	miners := []*topMinerResolver{}
+	for _, miner := range value.([]api.PoolMinerInfo) {
+		miners = append(miners, &topMinerResolver{miner: miner})
+	}
+
+	return miners, nil
+}
+
+// averageLuckRoundTime loads the pool's average luck and round time, shared by the averageLuck and averageRoundTime
+// fields.
+func (p *poolResolver) averageLuckRoundTime(ctx context.Context) (api.PoolAvgLuckRoundTime, error) {
+	value, err := load(ctx, "pool/avgLuckRoundtime", func() (interface{}, error) {
+		return api.PoolGetAverageLuckRoundTime()
+	})
+	if err != nil {
+		return api.PoolAvgLuckRoundTime{}, err
+	}
+
+	return value.(api.PoolAvgLuckRoundTime), nil
+}
+
+// Block fields, read from the api.Block.
+func (b *blockResolver) Hash() string          { return b.block.Hash }
+func (b *blockResolver) Number() float64       { return float64(b.block.Number) }
+func (b *blockResolver) Type() string          { return b.block.Type }
+func (b *blockResolver) Miner() string         { return b.block.Miner }
+func (b *blockResolver) Difficulty() float64   { return float64(b.block.Difficulty) }
+func (b *blockResolver) Timestamp() float64    { return float64(b.block.Timestamp) }
+func (b *blockResolver) Confirmed() bool       { return b.block.Confirmed }
+func (b *blockResolver) RoundTime() float64    { return float64(b.block.RoundTime) }
+func (b *blockResolver) Luck() float64         { return b.block.Luck }
+func (b *blockResolver) ServerName() string    { return b.block.ServerName }
+func (b *blockResolver) TotalRewards() float64 { return float64(b.block.TotalRewards) }
+
+// Payment fields, read from the api.MinerPayment.
+func (p *paymentResolver) Txid() string       { return p.payment.Txid }
+func (p *paymentResolver) Amount() float64    { return float64(p.payment.Amount) }
+func (p *paymentResolver) Timestamp() float64 { return float64(p.payment.Timestamp) }
+func (p *paymentResolver) Duration() float64  { return float64(p.payment.Duration) }
+
+// Hashrate fields, read from the api.WorkerCurrentStats.
+func (h *hashrateResolver) Effective() float64 { return float64(h.current.EffectiveHashrate) }
+func (h *hashrateResolver) Reported() float64  { return float64(h.current.ReportedHashrate) }
+
+// Worker count fields, read from the api.MinerWorkerCount.
+func (w *workerCountResolver) Online() int32  { return int32(w.count.Online) }
+func (w *workerCountResolver) Offline() int32 { return int32(w.count.Offline) }
+
+// Block count fields, read from the api.PoolBlockCount.
+func (b *blockCountResolver) Confirmed() int32   { return int32(b.count.Confirmed) }
+func (b *blockCountResolver) Unconfirmed() int32 { return int32(b.count.Unconfirmed) }
+
+// Daily stats fields, shared by miners and workers.
+func (d *dailyStatsResolver) EffectiveHashrate() float64 { return d.effectiveHashrate }
+func (d *dailyStatsResolver) ReportedHashrate() float64  { return d.reportedHashrate }
+func (d *dailyStatsResolver) ValidShares() int32         { return int32(d.validShares) }
+func (d *dailyStatsResolver) StaleShares() int32         { return int32(d.staleShares) }
+func (d *dailyStatsResolver) InvalidShares() int32       { return int32(d.invalidShares) }
+
+// Chart point fields, read from the api.WorkerChartData.
+func (c *chartPointResolver) Timestamp() float64         { return float64(c.point.Timestamp) }
+func (c *chartPointResolver) EffectiveHashrate() float64 { return float64(c.point.EffectiveHashrate) }
+func (c *chartPointResolver) AverageEffectiveHashrate() float64 {
+	return c.point.AverageEffectiveHashrate
+}
+func (c *chartPointResolver) ReportedHashrate() float64 { return float64(c.point.ReportedHashrate) }
+func (c *chartPointResolver) ValidShares() int32        { return int32(c.point.ValidShares) }
+func (c *chartPointResolver) StaleShares() int32        { return int32(c.point.StaleShares) }
+func (c *chartPointResolver) InvalidShares() int32      { return int32(c.point.InvalidShares) }
+
+// Miner details fields, read from the api.MinerDetails. Amounts are in gwei.
+func (m *minerDetailsResolver) MinPayoutThreshold() float64 {
+	return float64(m.details.MinPayoutThreshold)
+}
+func (m *minerDetailsResolver) PoolDonation() float64 { return m.details.PoolDonation }
+func (m *minerDetailsResolver) MaxFeePrice() float64  { return float64(m.details.MaxFeePrice) }
+func (m *minerDetailsResolver) FirstJoined() float64  { return float64(m.details.FirstJoined) }
+
+// Pool hashrate fields, read from the api.PoolHashrate, with one for each region.
+func (p *poolHashrateResolver) Total() float64 { return float64(p.hashrate.Total) }
+func (p *poolHashrateResolver) As() float64    { return float64(p.hashrate.As) }
+func (p *poolHashrateResolver) Au() float64    { return float64(p.hashrate.Au) }
+func (p *poolHashrateResolver) Eu() float64    { return float64(p.hashrate.Eu) }
+func (p *poolHashrateResolver) Sa() float64    { return float64(p.hashrate.Sa) }
+func (p *poolHashrateResolver) Us() float64    { return float64(p.hashrate.Us) }
+
+// Top miner fields, read from the api.PoolMinerInfo.
+func (t *topMinerResolver) Address() string       { return t.miner.Address }
+func (t *topMinerResolver) Hashrate() float64     { return float64(t.miner.Hashrate) }
+func (t *topMinerResolver) TotalWorkers() int32   { return int32(t.miner.TotalWorkers) }
+func (t *topMinerResolver) Balance() float64      { return float64(t.miner.Balance) }
+func (t *topMinerResolver) PoolDonation() float64 { return t.miner.PoolDonation }
+func (t *topMinerResolver) FirstJoined() float64  { return float64(t.miner.FirstJoined) }
+
+// Page info fields, describing where a connection's page ends.
+func (p *pageInfoResolver) HasNextPage() bool  { return p.hasNextPage }
+func (p *pageInfoResolver) EndCursor() *string { return p.endCursor }
+
+// Edges resolves the payments in the connection's page, with their cursors.
+func (c *paymentConnectionResolver) Edges() []*paymentEdgeResolver {
+	edges := make([]*paymentEdgeResolver, len(c.edges))
+	for i, e := range c.edges {
+		edges[i] = &paymentEdgeResolver{e}
+	}
+
+	return edges
+}
+
+// Payment connection and edge fields.
+func (c *paymentConnectionResolver) PageInfo() *pageInfoResolver { return c.pageInfo }
+func (c *paymentConnectionResolver) TotalCount() int32           { return int32(c.totalCount) }
+func (e *paymentEdgeResolver) Cursor() string                    { return e.cursor }
+func (e *paymentEdgeResolver) Node() *paymentResolver {
+	return &paymentResolver{payment: e.node.(api.MinerPayment)}
+}
+
+// Edges resolves the blocks in the connection's page, with their cursors.
+func (c *blockConnectionResolver) Edges() []*blockEdgeResolver {
+	edges := make([]*blockEdgeResolver, len(c.edges))
+	for i, e := range c.edges {
+		edges[i] = &blockEdgeResolver{e}
+	}
+
+	return edges
+}
+
+// Block connection and edge fields.
+func (c *blockConnectionResolver) PageInfo() *pageInfoResolver { return c.pageInfo }
+func (c *blockConnectionResolver) TotalCount() int32           { return int32(c.totalCount) }
+func (e *blockEdgeResolver) Cursor() string                    { return e.cursor }
+func (e *blockEdgeResolver) Node() *blockResolver              { return &blockResolver{block: e.node.(api.Block)} }
+
+// blockItems converts a slice of blocks for paginate.
+func blockItems(blocks []api.Block) []interface{} {
+	items := make([]interface{}, len(blocks))
+	for i, block := range blocks {
+		items[i] = block
+	}
+
+	return items
+}
+
+// paginate takes connection arguments and a function that fetches a page of the underlying API list, returning its
+// items, page size and total item count. Cursors are positions in the whole list, so a connection can span API pages.
+// Returns the connection and nil on success, or an empty connection and error on failure.
+func paginate(args connectionArgs, fetch func(page int) ([]interface{}, int, int, error)) (connection, error) {
+	first := 10
+	if args.First != nil {
+		first = int(*args.First)
+	}
+
+	if first < 0 || first > maxFirst {
+		return connection{}, ErrInvalidFirst
+	}
+
+	start := 0
+	if args.After != nil {
+		index, err := decodeCursor(*args.After)
+		if err != nil {
+			return connection{}, err
+		}
+
+		start = index + 1
+	}
+
+	// The first page gives the page size and total, which are needed to know which pages to fetch
+	items, perPage, total, err := fetch(0)
+	if err != nil {
+		return connection{}, err
+	}
+
+	if perPage <= 0 {
+		perPage = len(items)
+	}
+
+	conn := connection{edges: []edge{}, totalCount: total}
+
+	for index := start; index < total && len(conn.edges) < first && perPage > 0; {
+		page := index / perPage
+
+		// Page 0 was already fetched above, and the loader returns it again without another API call
+		if items, _, _, err = fetch(page); err != nil {
+			return connection{}, err
+		}
+
+		offset := index - page*perPage
+		if offset >= len(items) {
+			break
+		}
+
+		for ; offset < len(items) && len(conn.edges) < first; offset++ {
+			conn.edges = append(conn.edges, edge{cursor: encodeCursor(index), node: items[offset]})
+			index++
+		}
+	}
+
+	conn.pageInfo = &pageInfoResolver{hasNextPage: start+len(conn.edges) < total}
+	if len(conn.edges) > 0 {
+		conn.pageInfo.endCursor = &conn.edges[len(conn.edges)-1].cursor
+	}
+
+	return conn, nil
+}
+
+// encodeCursor takes a position in a list and returns an opaque cursor for it.
+func encodeCursor(index int) string {
+	return base64.StdEncoding.EncodeToString([]byte("index:" + strconv.Itoa(index)))
+}
+
+// decodeCursor takes a cursor and returns the list position it points at, or ErrInvalidCursor.
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), "index:") {
+		return 0, ErrInvalidCursor
+	}
+
+	index, err := strconv.Atoi(strings.TrimPrefix(string(decoded), "index:"))
+	if err != nil || index < 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return index, nil
+}
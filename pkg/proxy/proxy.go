@@ -0,0 +1,304 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/cache"
+	"github.com/cryptogenic/goflexpool/pkg/ratelimit"
+)
+
+// StatsPath is the route the proxy serves its statistics on. It can't clash with an API route since those all start
+// with /miner, /worker or /pool.
+const StatsPath = "/_proxy/stats"
+
+// apiPrefix is the path the real API is served under. Requests may include it or leave it off, so clients can point at
+// the proxy by changing either the whole base URL or just the host.
+const apiPrefix = "/api/v1"
+
+// Errors returned for invalid options and requests.
+var (
+	ErrInvalidUpstream = errors.New("upstream must be an http or https URL")
+	ErrUnknownRoute    = errors.New("unknown route")
+)
+
+// Methods that can be proxied for each endpoint, matching the routes the api package builds.
+var (
+	minerMethods = map[string]bool{
+		"balance": true, "current": true, "daily": true, "stats": true, "workerCount": true, "workers": true,
+		"chart": true, "payments": true, "paymentCount": true, "paymentsChart": true, "blocks": true, "blockCount": true,
+		"details": true, "estimatedDailyRevenue": true, "roundShare": true, "totalPaid": true, "totalDonated": true,
+	}
+	workerMethods = map[string]bool{"current": true, "daily": true, "stats": true, "chart": true}
+	poolMethods   = map[string]bool{
+		"hashrate": true, "hashrateChart": true, "minersOnline": true, "workersOnline": true, "blocks": true,
+		"blockCount": true, "topMiners": true, "topDonators": true, "avgLuckRoundtime": true, "currentLuck": true,
+		"averageBlockReward": true,
+	}
+)
+
+// DefaultMaxWait is how long an upstream fetch waits for the rate limiter when Options leaves MaxWait unset.
+const DefaultMaxWait = 30 * time.Second
+
+// Options contains the settings for a Proxy. Rate is the maximum number of upstream requests per second, with up to
+// Burst made at once, and 0 disables rate limiting. An upstream fetch waits up to MaxWait for the rate limiter.
+// Responses are cached for TTL, and kept for a further StaleTTL to be served if the upstream fails.
+type Options struct {
+	Upstream   string
+	TTL        time.Duration
+	StaleTTL   time.Duration
+	Rate       float64
+	Burst      int
+	MaxWait    time.Duration
+	HTTPClient *http.Client
+}
+
+// Proxy is an http.Handler that serves the same routes as the flexpool API, forwarding cache misses upstream.
+type Proxy struct {
+	upstream *url.URL
+	client   *http.Client
+	cache    *cache.Cache
+	limiter  *ratelimit.Limiter
+	maxWait  time.Duration
+
+	// Upstream fetches are shared by every client waiting on the same key, so they run under the proxy's context
+	// rather than any one client's, and are only cancelled by Close
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	upstreamRequests uint64
+	upstreamErrors   uint64
+	rateLimited      uint64
+}
+
+// Stats contains the proxy's cache and upstream statistics.
+type Stats struct {
+	Cache            cache.Stats `json:"cache"`
+	UpstreamRequests uint64      `json:"upstream_requests"`
+	UpstreamErrors   uint64      `json:"upstream_errors"`
+	RateLimited      uint64      `json:"rate_limited"`
+}
+
+// response is a cached upstream response.
+type response struct {
+	status      int
+	contentType string
+	body        []byte
+}
+
+// upstreamError is returned when the upstream responds with a non-200 status, keeping the response so it can be passed
+// through to the client when there's no stale copy to serve instead.
+type upstreamError struct {
+	response response
+}
+
+// apiError mirrors the API's error body, so clients of the proxy see errors in the same shape as the real API.
+type apiError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	Result interface{} `json:"result"`
+}
+
+// New takes a set of Options and creates a Proxy with an empty cache. Returns the Proxy and nil on success, or nil and
+// error if the upstream URL is invalid.
+func New(options Options) (*Proxy, error) {
+	upstream, err := url.Parse(strings.TrimSuffix(options.Upstream, "/"))
+	if err != nil || (upstream.Scheme != "http" && upstream.Scheme != "https") || upstream.Host == "" {
+		return nil, ErrInvalidUpstream
+	}
+
+	client := options.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	if options.MaxWait <= 0 {
+		options.MaxWait = DefaultMaxWait
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Proxy{
+		upstream: upstream,
+		client:   client,
+		cache:    cache.NewWithStale(options.TTL, options.StaleTTL),
+		limiter:  ratelimit.New(options.Rate, options.Burst),
+		maxWait:  options.MaxWait,
+		ctx:      ctx,
+		cancel:   cancel,
+	}, nil
+}
+
+// Error returns the upstream status.
+func (e *upstreamError) Error() string {
+	return fmt.Sprintf("upstream responded with %d", e.response.status)
+}
+
+// ServeHTTP serves a proxied API route, or the proxy's statistics.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == StatsPath {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(p.Stats())
+
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.EscapedPath(), apiPrefix)
+	if !validRoute(route) {
+		writeAPIError(w, http.StatusNotFound, ErrUnknownRoute.Error())
+		return
+	}
+
+	// Query parameters are re-encoded so the same request always gets the same key, whatever order they're given in
+	key := route
+	if query := r.URL.Query().Encode(); query != "" {
+		key += "?" + query
+	}
+
+	value, status, err := p.cache.Fetch(key, func() (interface{}, error) {
+		return p.forward(key)
+	})
+
+	if err != nil {
+		var failed *upstreamError
+		if errors.As(err, &failed) {
+			writeResponse(w, failed.response, "MISS")
+		} else {
+			writeAPIError(w, http.StatusBadGateway, err.Error())
+		}
+
+		return
+	}
+
+	cacheStatus := map[cache.Status]string{cache.Miss: "MISS", cache.Hit: "HIT", cache.Coalesced: "HIT", cache.Stale: "STALE"}
+	writeResponse(w, value.(response), cacheStatus[status])
+}
+
+// Stats returns the proxy's cache and upstream statistics.
+func (p *Proxy) Stats() Stats {
+	return Stats{
+		Cache:            p.cache.Stats(),
+		UpstreamRequests: atomic.LoadUint64(&p.upstreamRequests),
+		UpstreamErrors:   atomic.LoadUint64(&p.upstreamErrors),
+		RateLimited:      atomic.LoadUint64(&p.rateLimited),
+	}
+}
+
+// Purge removes expired responses from the proxy's cache.
+func (p *Proxy) Purge() {
+	p.cache.Purge()
+}
+
+// Close cancels any upstream fetches in flight. Requests served after Close fail.
+func (p *Proxy) Close() {
+	p.cancel()
+}
+
+// forward sends a request upstream once the rate limiter allows it, waiting up to maxWait. It's shared by every client
+// waiting on key, so it doesn't depend on any of their contexts - one disconnecting mustn't fail the rest. Returns the
+// response and nil on a 200, or nil and error otherwise.
+func (p *Proxy) forward(key string) (interface{}, error) {
+	if !p.limiter.Allow() {
+		atomic.AddUint64(&p.rateLimited, 1)
+
+		ctx, cancel := context.WithTimeout(p.ctx, p.maxWait)
+		err := p.limiter.Wait(ctx)
+		cancel()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	atomic.AddUint64(&p.upstreamRequests, 1)
+
+	req, err := http.NewRequestWithContext(p.ctx, "GET", p.upstream.String()+key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		atomic.AddUint64(&p.upstreamErrors, 1)
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		atomic.AddUint64(&p.upstreamErrors, 1)
+		return nil, err
+	}
+
+	result := response{status: resp.StatusCode, contentType: resp.Header.Get("Content-Type"), body: body}
+
+	if resp.StatusCode != http.StatusOK {
+		atomic.AddUint64(&p.upstreamErrors, 1)
+		return nil, &upstreamError{response: result}
+	}
+
+	return result, nil
+}
+
+// validRoute returns whether a path is one of the routes the api package requests - /miner/{address}/{method},
+// /worker/{address}/{worker}/{method} or /pool/{method}.
+func validRoute(route string) bool {
+	parts := strings.Split(strings.TrimPrefix(route, "/"), "/")
+
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+
+	switch parts[0] {
+	case "miner":
+		return len(parts) == 3 && minerMethods[parts[2]]
+	case "worker":
+		return len(parts) == 4 && workerMethods[parts[3]]
+	case "pool":
+		return len(parts) == 2 && poolMethods[parts[1]]
+	}
+
+	return false
+}
+
+// writeResponse writes a cached response, with an X-Cache header saying where it came from.
+func writeResponse(w http.ResponseWriter, resp response, cacheStatus string) {
+	if resp.contentType != "" {
+		w.Header().Set("Content-Type", resp.contentType)
+	}
+
+	w.Header().Set("X-Cache", cacheStatus)
+	w.WriteHeader(resp.status)
+	w.Write(resp.body)
+}
+
+// writeAPIError writes an error in the same shape as the API's own errors.
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	var body apiError
+	body.Error.Code = status
+	body.Error.Message = message
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
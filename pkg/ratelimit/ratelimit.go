@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket rate limiter. Tokens are added at a steady rate up to a maximum burst, and each request
+// takes one. A Limiter with a rate of 0 or less never limits.
+type Limiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// New takes a rate in requests per second and the number of requests that can be made at once, and creates a Limiter
+// that starts with a full bucket.
+func New(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Allow takes a token if one is available. Returns whether a request can be made now.
+func (l *Limiter) Allow() bool {
+	if l.rate <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+
+	if l.tokens < 1 {
+		return false
+	}
+
+	l.tokens--
+
+	return true
+}
+
+// Reserve takes a token, borrowing against future tokens if none are available. Returns how long the caller must wait
+// before making its request.
+func (l *Limiter) Reserve() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// Wait blocks until a request can be made. Returns nil when the caller can go ahead, or the context's error if it's
+// cancelled first. A cancelled wait still uses up its token, which keeps the limiter simple at the cost of being
+// slightly conservative.
+func (l *Limiter) Wait(ctx context.Context) error {
+	delay := l.Reserve()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// refill adds the tokens earned since the last call. l.mu must be held.
+func (l *Limiter) refill() {
+	now := time.Now()
+
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+
+	l.last = now
+}
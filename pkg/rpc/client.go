@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client bundles clients for the miner, worker and pool services on one connection.
+type Client struct {
+	Miner  MinerServiceClient
+	Worker WorkerServiceClient
+	Pool   PoolServiceClient
+
+	conn *grpc.ClientConn
+}
+
+// Dial takes a server address and any dial options, and creates a Client connected to it. Without options the
+// connection is unencrypted, which suits a server on the same host or private network. Returns the Client and nil on
+// success, or nil and error on failure.
+func Dial(target string, options ...grpc.DialOption) (*Client, error) {
+	if len(options) == 0 {
+		options = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	conn, err := grpc.NewClient(target, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Miner:  NewMinerServiceClient(conn),
+		Worker: NewWorkerServiceClient(conn),
+		Pool:   NewPoolServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+// Close closes the client's connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,2671 @@
+// Services exposing the flexpool API through goflexpool. Messages mirror the api package's types, with amounts in gwei
+// and hashrates in hashes per second.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: flexpool.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WorkerEvent_Type int32
+
+const (
+	WorkerEvent_TYPE_UNSPECIFIED WorkerEvent_Type = 0
+	WorkerEvent_ADDED            WorkerEvent_Type = 1
+	WorkerEvent_CHANGED          WorkerEvent_Type = 2
+	WorkerEvent_REMOVED          WorkerEvent_Type = 3
+)
+
+// Enum value maps for WorkerEvent_Type.
+var (
+	WorkerEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "ADDED",
+		2: "CHANGED",
+		3: "REMOVED",
+	}
+	WorkerEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"ADDED":            1,
+		"CHANGED":          2,
+		"REMOVED":          3,
+	}
+)
+
+func (x WorkerEvent_Type) Enum() *WorkerEvent_Type {
+	p := new(WorkerEvent_Type)
+	*p = x
+	return p
+}
+
+func (x WorkerEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkerEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_flexpool_proto_enumTypes[0].Descriptor()
+}
+
+func (WorkerEvent_Type) Type() protoreflect.EnumType {
+	return &file_flexpool_proto_enumTypes[0]
+}
+
+func (x WorkerEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkerEvent_Type.Descriptor instead.
+func (WorkerEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{15, 0}
+}
+
+type MinerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MinerRequest) Reset() {
+	*x = MinerRequest{}
+	mi := &file_flexpool_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinerRequest) ProtoMessage() {}
+
+func (x *MinerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinerRequest.ProtoReflect.Descriptor instead.
+func (*MinerRequest) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MinerRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type PagedMinerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Page          int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PagedMinerRequest) Reset() {
+	*x = PagedMinerRequest{}
+	mi := &file_flexpool_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PagedMinerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagedMinerRequest) ProtoMessage() {}
+
+func (x *PagedMinerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagedMinerRequest.ProtoReflect.Descriptor instead.
+func (*PagedMinerRequest) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PagedMinerRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PagedMinerRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type WorkerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Worker        string                 `protobuf:"bytes,2,opt,name=worker,proto3" json:"worker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerRequest) Reset() {
+	*x = WorkerRequest{}
+	mi := &file_flexpool_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerRequest) ProtoMessage() {}
+
+func (x *WorkerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerRequest.ProtoReflect.Descriptor instead.
+func (*WorkerRequest) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WorkerRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *WorkerRequest) GetWorker() string {
+	if x != nil {
+		return x.Worker
+	}
+	return ""
+}
+
+type PoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolRequest) Reset() {
+	*x = PoolRequest{}
+	mi := &file_flexpool_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolRequest) ProtoMessage() {}
+
+func (x *PoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolRequest.ProtoReflect.Descriptor instead.
+func (*PoolRequest) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{3}
+}
+
+type PagedPoolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int64                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PagedPoolRequest) Reset() {
+	*x = PagedPoolRequest{}
+	mi := &file_flexpool_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PagedPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagedPoolRequest) ProtoMessage() {}
+
+func (x *PagedPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagedPoolRequest.ProtoReflect.Descriptor instead.
+func (*PagedPoolRequest) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PagedPoolRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type Amount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gwei          uint64                 `protobuf:"varint,1,opt,name=gwei,proto3" json:"gwei,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Amount) Reset() {
+	*x = Amount{}
+	mi := &file_flexpool_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Amount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Amount) ProtoMessage() {}
+
+func (x *Amount) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Amount.ProtoReflect.Descriptor instead.
+func (*Amount) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Amount) GetGwei() uint64 {
+	if x != nil {
+		return x.Gwei
+	}
+	return 0
+}
+
+type Count struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Count) Reset() {
+	*x = Count{}
+	mi := &file_flexpool_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Count) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Count) ProtoMessage() {}
+
+func (x *Count) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Count.ProtoReflect.Descriptor instead.
+func (*Count) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Count) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type RoundShare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         float64                `protobuf:"fixed64,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoundShare) Reset() {
+	*x = RoundShare{}
+	mi := &file_flexpool_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoundShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundShare) ProtoMessage() {}
+
+func (x *RoundShare) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundShare.ProtoReflect.Descriptor instead.
+func (*RoundShare) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RoundShare) GetShare() float64 {
+	if x != nil {
+		return x.Share
+	}
+	return 0
+}
+
+type Luck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Luck          float64                `protobuf:"fixed64,1,opt,name=luck,proto3" json:"luck,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Luck) Reset() {
+	*x = Luck{}
+	mi := &file_flexpool_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Luck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Luck) ProtoMessage() {}
+
+func (x *Luck) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Luck.ProtoReflect.Descriptor instead.
+func (*Luck) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Luck) GetLuck() float64 {
+	if x != nil {
+		return x.Luck
+	}
+	return 0
+}
+
+type CurrentStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	EffectiveHashrate uint64                 `protobuf:"varint,1,opt,name=effective_hashrate,json=effectiveHashrate,proto3" json:"effective_hashrate,omitempty"`
+	ReportedHashrate  uint64                 `protobuf:"varint,2,opt,name=reported_hashrate,json=reportedHashrate,proto3" json:"reported_hashrate,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CurrentStats) Reset() {
+	*x = CurrentStats{}
+	mi := &file_flexpool_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrentStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrentStats) ProtoMessage() {}
+
+func (x *CurrentStats) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrentStats.ProtoReflect.Descriptor instead.
+func (*CurrentStats) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CurrentStats) GetEffectiveHashrate() uint64 {
+	if x != nil {
+		return x.EffectiveHashrate
+	}
+	return 0
+}
+
+func (x *CurrentStats) GetReportedHashrate() uint64 {
+	if x != nil {
+		return x.ReportedHashrate
+	}
+	return 0
+}
+
+type MinerDailyStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	EffectiveHashrate float64                `protobuf:"fixed64,1,opt,name=effective_hashrate,json=effectiveHashrate,proto3" json:"effective_hashrate,omitempty"`
+	ReportedHashrate  float64                `protobuf:"fixed64,2,opt,name=reported_hashrate,json=reportedHashrate,proto3" json:"reported_hashrate,omitempty"`
+	ValidShares       int64                  `protobuf:"varint,3,opt,name=valid_shares,json=validShares,proto3" json:"valid_shares,omitempty"`
+	StaleShares       int64                  `protobuf:"varint,4,opt,name=stale_shares,json=staleShares,proto3" json:"stale_shares,omitempty"`
+	InvalidShares     int64                  `protobuf:"varint,5,opt,name=invalid_shares,json=invalidShares,proto3" json:"invalid_shares,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MinerDailyStats) Reset() {
+	*x = MinerDailyStats{}
+	mi := &file_flexpool_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinerDailyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinerDailyStats) ProtoMessage() {}
+
+func (x *MinerDailyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinerDailyStats.ProtoReflect.Descriptor instead.
+func (*MinerDailyStats) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MinerDailyStats) GetEffectiveHashrate() float64 {
+	if x != nil {
+		return x.EffectiveHashrate
+	}
+	return 0
+}
+
+func (x *MinerDailyStats) GetReportedHashrate() float64 {
+	if x != nil {
+		return x.ReportedHashrate
+	}
+	return 0
+}
+
+func (x *MinerDailyStats) GetValidShares() int64 {
+	if x != nil {
+		return x.ValidShares
+	}
+	return 0
+}
+
+func (x *MinerDailyStats) GetStaleShares() int64 {
+	if x != nil {
+		return x.StaleShares
+	}
+	return 0
+}
+
+func (x *MinerDailyStats) GetInvalidShares() int64 {
+	if x != nil {
+		return x.InvalidShares
+	}
+	return 0
+}
+
+type MinerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       *CurrentStats          `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	Daily         *MinerDailyStats       `protobuf:"bytes,2,opt,name=daily,proto3" json:"daily,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MinerStats) Reset() {
+	*x = MinerStats{}
+	mi := &file_flexpool_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinerStats) ProtoMessage() {}
+
+func (x *MinerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinerStats.ProtoReflect.Descriptor instead.
+func (*MinerStats) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MinerStats) GetCurrent() *CurrentStats {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *MinerStats) GetDaily() *MinerDailyStats {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+type WorkerCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Online        int64                  `protobuf:"varint,1,opt,name=online,proto3" json:"online,omitempty"`
+	Offline       int64                  `protobuf:"varint,2,opt,name=offline,proto3" json:"offline,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerCount) Reset() {
+	*x = WorkerCount{}
+	mi := &file_flexpool_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerCount) ProtoMessage() {}
+
+func (x *WorkerCount) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerCount.ProtoReflect.Descriptor instead.
+func (*WorkerCount) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WorkerCount) GetOnline() int64 {
+	if x != nil {
+		return x.Online
+	}
+	return 0
+}
+
+func (x *WorkerCount) GetOffline() int64 {
+	if x != nil {
+		return x.Offline
+	}
+	return 0
+}
+
+type Worker struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Name                   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Online                 bool                   `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	DuplicateWorkersMerged int64                  `protobuf:"varint,3,opt,name=duplicate_workers_merged,json=duplicateWorkersMerged,proto3" json:"duplicate_workers_merged,omitempty"`
+	ReportedHashrate       uint64                 `protobuf:"varint,4,opt,name=reported_hashrate,json=reportedHashrate,proto3" json:"reported_hashrate,omitempty"`
+	EffectiveHashrate      uint64                 `protobuf:"varint,5,opt,name=effective_hashrate,json=effectiveHashrate,proto3" json:"effective_hashrate,omitempty"`
+	ValidShares            int64                  `protobuf:"varint,6,opt,name=valid_shares,json=validShares,proto3" json:"valid_shares,omitempty"`
+	StaleShares            int64                  `protobuf:"varint,7,opt,name=stale_shares,json=staleShares,proto3" json:"stale_shares,omitempty"`
+	InvalidShares          int64                  `protobuf:"varint,8,opt,name=invalid_shares,json=invalidShares,proto3" json:"invalid_shares,omitempty"`
+	LastSeen               int64                  `protobuf:"varint,9,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Worker) Reset() {
+	*x = Worker{}
+	mi := &file_flexpool_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Worker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Worker) ProtoMessage() {}
+
+func (x *Worker) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Worker.ProtoReflect.Descriptor instead.
+func (*Worker) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Worker) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Worker) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *Worker) GetDuplicateWorkersMerged() int64 {
+	if x != nil {
+		return x.DuplicateWorkersMerged
+	}
+	return 0
+}
+
+func (x *Worker) GetReportedHashrate() uint64 {
+	if x != nil {
+		return x.ReportedHashrate
+	}
+	return 0
+}
+
+func (x *Worker) GetEffectiveHashrate() uint64 {
+	if x != nil {
+		return x.EffectiveHashrate
+	}
+	return 0
+}
+
+func (x *Worker) GetValidShares() int64 {
+	if x != nil {
+		return x.ValidShares
+	}
+	return 0
+}
+
+func (x *Worker) GetStaleShares() int64 {
+	if x != nil {
+		return x.StaleShares
+	}
+	return 0
+}
+
+func (x *Worker) GetInvalidShares() int64 {
+	if x != nil {
+		return x.InvalidShares
+	}
+	return 0
+}
+
+func (x *Worker) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+type WorkerList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workers       []*Worker              `protobuf:"bytes,1,rep,name=workers,proto3" json:"workers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerList) Reset() {
+	*x = WorkerList{}
+	mi := &file_flexpool_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerList) ProtoMessage() {}
+
+func (x *WorkerList) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerList.ProtoReflect.Descriptor instead.
+func (*WorkerList) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WorkerList) GetWorkers() []*Worker {
+	if x != nil {
+		return x.Workers
+	}
+	return nil
+}
+
+type WorkerEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  WorkerEvent_Type       `protobuf:"varint,1,opt,name=type,proto3,enum=flexpool.v1.WorkerEvent_Type" json:"type,omitempty"`
+	// Only the name is set for REMOVED events.
+	Worker        *Worker `protobuf:"bytes,2,opt,name=worker,proto3" json:"worker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerEvent) Reset() {
+	*x = WorkerEvent{}
+	mi := &file_flexpool_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerEvent) ProtoMessage() {}
+
+func (x *WorkerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerEvent.ProtoReflect.Descriptor instead.
+func (*WorkerEvent) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WorkerEvent) GetType() WorkerEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return WorkerEvent_TYPE_UNSPECIFIED
+}
+
+func (x *WorkerEvent) GetWorker() *Worker {
+	if x != nil {
+		return x.Worker
+	}
+	return nil
+}
+
+type ChartPoint struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp                uint64                 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	EffectiveHashrate        uint64                 `protobuf:"varint,2,opt,name=effective_hashrate,json=effectiveHashrate,proto3" json:"effective_hashrate,omitempty"`
+	AverageEffectiveHashrate float64                `protobuf:"fixed64,3,opt,name=average_effective_hashrate,json=averageEffectiveHashrate,proto3" json:"average_effective_hashrate,omitempty"`
+	ReportedHashrate         uint64                 `protobuf:"varint,4,opt,name=reported_hashrate,json=reportedHashrate,proto3" json:"reported_hashrate,omitempty"`
+	ValidShares              int64                  `protobuf:"varint,5,opt,name=valid_shares,json=validShares,proto3" json:"valid_shares,omitempty"`
+	StaleShares              int64                  `protobuf:"varint,6,opt,name=stale_shares,json=staleShares,proto3" json:"stale_shares,omitempty"`
+	InvalidShares            int64                  `protobuf:"varint,7,opt,name=invalid_shares,json=invalidShares,proto3" json:"invalid_shares,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ChartPoint) Reset() {
+	*x = ChartPoint{}
+	mi := &file_flexpool_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChartPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChartPoint) ProtoMessage() {}
+
+func (x *ChartPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChartPoint.ProtoReflect.Descriptor instead.
+func (*ChartPoint) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ChartPoint) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ChartPoint) GetEffectiveHashrate() uint64 {
+	if x != nil {
+		return x.EffectiveHashrate
+	}
+	return 0
+}
+
+func (x *ChartPoint) GetAverageEffectiveHashrate() float64 {
+	if x != nil {
+		return x.AverageEffectiveHashrate
+	}
+	return 0
+}
+
+func (x *ChartPoint) GetReportedHashrate() uint64 {
+	if x != nil {
+		return x.ReportedHashrate
+	}
+	return 0
+}
+
+func (x *ChartPoint) GetValidShares() int64 {
+	if x != nil {
+		return x.ValidShares
+	}
+	return 0
+}
+
+func (x *ChartPoint) GetStaleShares() int64 {
+	if x != nil {
+		return x.StaleShares
+	}
+	return 0
+}
+
+func (x *ChartPoint) GetInvalidShares() int64 {
+	if x != nil {
+		return x.InvalidShares
+	}
+	return 0
+}
+
+type MinerChart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*ChartPoint          `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MinerChart) Reset() {
+	*x = MinerChart{}
+	mi := &file_flexpool_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinerChart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinerChart) ProtoMessage() {}
+
+func (x *MinerChart) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinerChart.ProtoReflect.Descriptor instead.
+func (*MinerChart) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MinerChart) GetPoints() []*ChartPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type Payment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txid          string                 `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Amount        uint64                 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Timestamp     uint64                 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Duration      uint64                 `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_flexpool_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Payment) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *Payment) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Payment) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Payment) GetDuration() uint64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type PaymentPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	ItemsPerPage  int64                  `protobuf:"varint,2,opt,name=items_per_page,json=itemsPerPage,proto3" json:"items_per_page,omitempty"`
+	TotalItems    int64                  `protobuf:"varint,3,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentPage) Reset() {
+	*x = PaymentPage{}
+	mi := &file_flexpool_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentPage) ProtoMessage() {}
+
+func (x *PaymentPage) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentPage.ProtoReflect.Descriptor instead.
+func (*PaymentPage) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PaymentPage) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *PaymentPage) GetItemsPerPage() int64 {
+	if x != nil {
+		return x.ItemsPerPage
+	}
+	return 0
+}
+
+func (x *PaymentPage) GetTotalItems() int64 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *PaymentPage) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type PaymentChartPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        uint64                 `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Timestamp     uint64                 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentChartPoint) Reset() {
+	*x = PaymentChartPoint{}
+	mi := &file_flexpool_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentChartPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentChartPoint) ProtoMessage() {}
+
+func (x *PaymentChartPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentChartPoint.ProtoReflect.Descriptor instead.
+func (*PaymentChartPoint) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PaymentChartPoint) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentChartPoint) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type PaymentChart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*PaymentChartPoint   `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentChart) Reset() {
+	*x = PaymentChart{}
+	mi := &file_flexpool_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentChart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentChart) ProtoMessage() {}
+
+func (x *PaymentChart) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentChart.ProtoReflect.Descriptor instead.
+func (*PaymentChart) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PaymentChart) GetPoints() []*PaymentChartPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type Block struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Hash                  string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Number                uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Type                  string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Miner                 string                 `protobuf:"bytes,4,opt,name=miner,proto3" json:"miner,omitempty"`
+	Difficulty            uint64                 `protobuf:"varint,5,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Timestamp             uint64                 `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Confirmed             bool                   `protobuf:"varint,7,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	RoundTime             uint64                 `protobuf:"varint,8,opt,name=round_time,json=roundTime,proto3" json:"round_time,omitempty"`
+	Luck                  float64                `protobuf:"fixed64,9,opt,name=luck,proto3" json:"luck,omitempty"`
+	ServerName            string                 `protobuf:"bytes,10,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	BlockReward           uint64                 `protobuf:"varint,11,opt,name=block_reward,json=blockReward,proto3" json:"block_reward,omitempty"`
+	BlockFees             uint64                 `protobuf:"varint,12,opt,name=block_fees,json=blockFees,proto3" json:"block_fees,omitempty"`
+	UncleInclusionRewards uint64                 `protobuf:"varint,13,opt,name=uncle_inclusion_rewards,json=uncleInclusionRewards,proto3" json:"uncle_inclusion_rewards,omitempty"`
+	TotalRewards          uint64                 `protobuf:"varint,14,opt,name=total_rewards,json=totalRewards,proto3" json:"total_rewards,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_flexpool_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Block) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Block) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Block) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Block) GetMiner() string {
+	if x != nil {
+		return x.Miner
+	}
+	return ""
+}
+
+func (x *Block) GetDifficulty() uint64 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *Block) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Block) GetConfirmed() bool {
+	if x != nil {
+		return x.Confirmed
+	}
+	return false
+}
+
+func (x *Block) GetRoundTime() uint64 {
+	if x != nil {
+		return x.RoundTime
+	}
+	return 0
+}
+
+func (x *Block) GetLuck() float64 {
+	if x != nil {
+		return x.Luck
+	}
+	return 0
+}
+
+func (x *Block) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *Block) GetBlockReward() uint64 {
+	if x != nil {
+		return x.BlockReward
+	}
+	return 0
+}
+
+func (x *Block) GetBlockFees() uint64 {
+	if x != nil {
+		return x.BlockFees
+	}
+	return 0
+}
+
+func (x *Block) GetUncleInclusionRewards() uint64 {
+	if x != nil {
+		return x.UncleInclusionRewards
+	}
+	return 0
+}
+
+func (x *Block) GetTotalRewards() uint64 {
+	if x != nil {
+		return x.TotalRewards
+	}
+	return 0
+}
+
+type BlockPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []*Block               `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	ItemsPerPage  int64                  `protobuf:"varint,2,opt,name=items_per_page,json=itemsPerPage,proto3" json:"items_per_page,omitempty"`
+	TotalItems    int64                  `protobuf:"varint,3,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockPage) Reset() {
+	*x = BlockPage{}
+	mi := &file_flexpool_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockPage) ProtoMessage() {}
+
+func (x *BlockPage) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockPage.ProtoReflect.Descriptor instead.
+func (*BlockPage) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BlockPage) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *BlockPage) GetItemsPerPage() int64 {
+	if x != nil {
+		return x.ItemsPerPage
+	}
+	return 0
+}
+
+func (x *BlockPage) GetTotalItems() int64 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *BlockPage) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type BlockCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Confirmed     int64                  `protobuf:"varint,1,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	Unconfirmed   int64                  `protobuf:"varint,2,opt,name=unconfirmed,proto3" json:"unconfirmed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockCount) Reset() {
+	*x = BlockCount{}
+	mi := &file_flexpool_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockCount) ProtoMessage() {}
+
+func (x *BlockCount) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockCount.ProtoReflect.Descriptor instead.
+func (*BlockCount) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BlockCount) GetConfirmed() int64 {
+	if x != nil {
+		return x.Confirmed
+	}
+	return 0
+}
+
+func (x *BlockCount) GetUnconfirmed() int64 {
+	if x != nil {
+		return x.Unconfirmed
+	}
+	return 0
+}
+
+type MinerDetails struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	MinPayoutThreshold uint64                 `protobuf:"varint,1,opt,name=min_payout_threshold,json=minPayoutThreshold,proto3" json:"min_payout_threshold,omitempty"`
+	PoolDonation       float64                `protobuf:"fixed64,2,opt,name=pool_donation,json=poolDonation,proto3" json:"pool_donation,omitempty"`
+	MaxFeePrice        uint64                 `protobuf:"varint,3,opt,name=max_fee_price,json=maxFeePrice,proto3" json:"max_fee_price,omitempty"`
+	CensoredEmail      string                 `protobuf:"bytes,4,opt,name=censored_email,json=censoredEmail,proto3" json:"censored_email,omitempty"`
+	CensoredIp         string                 `protobuf:"bytes,5,opt,name=censored_ip,json=censoredIp,proto3" json:"censored_ip,omitempty"`
+	FirstJoined        uint64                 `protobuf:"varint,6,opt,name=first_joined,json=firstJoined,proto3" json:"first_joined,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *MinerDetails) Reset() {
+	*x = MinerDetails{}
+	mi := &file_flexpool_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinerDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinerDetails) ProtoMessage() {}
+
+func (x *MinerDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinerDetails.ProtoReflect.Descriptor instead.
+func (*MinerDetails) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MinerDetails) GetMinPayoutThreshold() uint64 {
+	if x != nil {
+		return x.MinPayoutThreshold
+	}
+	return 0
+}
+
+func (x *MinerDetails) GetPoolDonation() float64 {
+	if x != nil {
+		return x.PoolDonation
+	}
+	return 0
+}
+
+func (x *MinerDetails) GetMaxFeePrice() uint64 {
+	if x != nil {
+		return x.MaxFeePrice
+	}
+	return 0
+}
+
+func (x *MinerDetails) GetCensoredEmail() string {
+	if x != nil {
+		return x.CensoredEmail
+	}
+	return ""
+}
+
+func (x *MinerDetails) GetCensoredIp() string {
+	if x != nil {
+		return x.CensoredIp
+	}
+	return ""
+}
+
+func (x *MinerDetails) GetFirstJoined() uint64 {
+	if x != nil {
+		return x.FirstJoined
+	}
+	return 0
+}
+
+type WorkerDailyStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	EffectiveHashrate uint64                 `protobuf:"varint,1,opt,name=effective_hashrate,json=effectiveHashrate,proto3" json:"effective_hashrate,omitempty"`
+	ReportedHashrate  uint64                 `protobuf:"varint,2,opt,name=reported_hashrate,json=reportedHashrate,proto3" json:"reported_hashrate,omitempty"`
+	ValidShares       int64                  `protobuf:"varint,3,opt,name=valid_shares,json=validShares,proto3" json:"valid_shares,omitempty"`
+	StaleShares       int64                  `protobuf:"varint,4,opt,name=stale_shares,json=staleShares,proto3" json:"stale_shares,omitempty"`
+	InvalidShares     int64                  `protobuf:"varint,5,opt,name=invalid_shares,json=invalidShares,proto3" json:"invalid_shares,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *WorkerDailyStats) Reset() {
+	*x = WorkerDailyStats{}
+	mi := &file_flexpool_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerDailyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerDailyStats) ProtoMessage() {}
+
+func (x *WorkerDailyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerDailyStats.ProtoReflect.Descriptor instead.
+func (*WorkerDailyStats) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WorkerDailyStats) GetEffectiveHashrate() uint64 {
+	if x != nil {
+		return x.EffectiveHashrate
+	}
+	return 0
+}
+
+func (x *WorkerDailyStats) GetReportedHashrate() uint64 {
+	if x != nil {
+		return x.ReportedHashrate
+	}
+	return 0
+}
+
+func (x *WorkerDailyStats) GetValidShares() int64 {
+	if x != nil {
+		return x.ValidShares
+	}
+	return 0
+}
+
+func (x *WorkerDailyStats) GetStaleShares() int64 {
+	if x != nil {
+		return x.StaleShares
+	}
+	return 0
+}
+
+func (x *WorkerDailyStats) GetInvalidShares() int64 {
+	if x != nil {
+		return x.InvalidShares
+	}
+	return 0
+}
+
+type WorkerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       *CurrentStats          `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	Daily         *WorkerDailyStats      `protobuf:"bytes,2,opt,name=daily,proto3" json:"daily,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerStats) Reset() {
+	*x = WorkerStats{}
+	mi := &file_flexpool_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerStats) ProtoMessage() {}
+
+func (x *WorkerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerStats.ProtoReflect.Descriptor instead.
+func (*WorkerStats) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WorkerStats) GetCurrent() *CurrentStats {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *WorkerStats) GetDaily() *WorkerDailyStats {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+type WorkerChart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*ChartPoint          `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerChart) Reset() {
+	*x = WorkerChart{}
+	mi := &file_flexpool_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerChart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerChart) ProtoMessage() {}
+
+func (x *WorkerChart) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerChart.ProtoReflect.Descriptor instead.
+func (*WorkerChart) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *WorkerChart) GetPoints() []*ChartPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type PoolHashrate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	As            uint64                 `protobuf:"varint,1,opt,name=as,proto3" json:"as,omitempty"`
+	Au            uint64                 `protobuf:"varint,2,opt,name=au,proto3" json:"au,omitempty"`
+	Eu            uint64                 `protobuf:"varint,3,opt,name=eu,proto3" json:"eu,omitempty"`
+	Sa            uint64                 `protobuf:"varint,4,opt,name=sa,proto3" json:"sa,omitempty"`
+	Us            uint64                 `protobuf:"varint,5,opt,name=us,proto3" json:"us,omitempty"`
+	Total         uint64                 `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolHashrate) Reset() {
+	*x = PoolHashrate{}
+	mi := &file_flexpool_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolHashrate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolHashrate) ProtoMessage() {}
+
+func (x *PoolHashrate) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolHashrate.ProtoReflect.Descriptor instead.
+func (*PoolHashrate) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PoolHashrate) GetAs() uint64 {
+	if x != nil {
+		return x.As
+	}
+	return 0
+}
+
+func (x *PoolHashrate) GetAu() uint64 {
+	if x != nil {
+		return x.Au
+	}
+	return 0
+}
+
+func (x *PoolHashrate) GetEu() uint64 {
+	if x != nil {
+		return x.Eu
+	}
+	return 0
+}
+
+func (x *PoolHashrate) GetSa() uint64 {
+	if x != nil {
+		return x.Sa
+	}
+	return 0
+}
+
+func (x *PoolHashrate) GetUs() uint64 {
+	if x != nil {
+		return x.Us
+	}
+	return 0
+}
+
+func (x *PoolHashrate) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type PoolHashrateChartPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     uint64                 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Hashrate      *PoolHashrate          `protobuf:"bytes,2,opt,name=hashrate,proto3" json:"hashrate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolHashrateChartPoint) Reset() {
+	*x = PoolHashrateChartPoint{}
+	mi := &file_flexpool_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolHashrateChartPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolHashrateChartPoint) ProtoMessage() {}
+
+func (x *PoolHashrateChartPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolHashrateChartPoint.ProtoReflect.Descriptor instead.
+func (*PoolHashrateChartPoint) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PoolHashrateChartPoint) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *PoolHashrateChartPoint) GetHashrate() *PoolHashrate {
+	if x != nil {
+		return x.Hashrate
+	}
+	return nil
+}
+
+type PoolHashrateChart struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Points        []*PoolHashrateChartPoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolHashrateChart) Reset() {
+	*x = PoolHashrateChart{}
+	mi := &file_flexpool_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolHashrateChart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolHashrateChart) ProtoMessage() {}
+
+func (x *PoolHashrateChart) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolHashrateChart.ProtoReflect.Descriptor instead.
+func (*PoolHashrateChart) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PoolHashrateChart) GetPoints() []*PoolHashrateChartPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type TopMiner struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Hashrate      uint64                 `protobuf:"varint,2,opt,name=hashrate,proto3" json:"hashrate,omitempty"`
+	TotalWorkers  int64                  `protobuf:"varint,3,opt,name=total_workers,json=totalWorkers,proto3" json:"total_workers,omitempty"`
+	Balance       uint64                 `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	PoolDonation  float64                `protobuf:"fixed64,5,opt,name=pool_donation,json=poolDonation,proto3" json:"pool_donation,omitempty"`
+	FirstJoined   uint64                 `protobuf:"varint,6,opt,name=first_joined,json=firstJoined,proto3" json:"first_joined,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopMiner) Reset() {
+	*x = TopMiner{}
+	mi := &file_flexpool_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopMiner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopMiner) ProtoMessage() {}
+
+func (x *TopMiner) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopMiner.ProtoReflect.Descriptor instead.
+func (*TopMiner) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *TopMiner) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TopMiner) GetHashrate() uint64 {
+	if x != nil {
+		return x.Hashrate
+	}
+	return 0
+}
+
+func (x *TopMiner) GetTotalWorkers() int64 {
+	if x != nil {
+		return x.TotalWorkers
+	}
+	return 0
+}
+
+func (x *TopMiner) GetBalance() uint64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *TopMiner) GetPoolDonation() float64 {
+	if x != nil {
+		return x.PoolDonation
+	}
+	return 0
+}
+
+func (x *TopMiner) GetFirstJoined() uint64 {
+	if x != nil {
+		return x.FirstJoined
+	}
+	return 0
+}
+
+type TopMiners struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Miners        []*TopMiner            `protobuf:"bytes,1,rep,name=miners,proto3" json:"miners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopMiners) Reset() {
+	*x = TopMiners{}
+	mi := &file_flexpool_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopMiners) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopMiners) ProtoMessage() {}
+
+func (x *TopMiners) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopMiners.ProtoReflect.Descriptor instead.
+func (*TopMiners) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TopMiners) GetMiners() []*TopMiner {
+	if x != nil {
+		return x.Miners
+	}
+	return nil
+}
+
+type TopDonator struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	PoolDonation  float64                `protobuf:"fixed64,2,opt,name=pool_donation,json=poolDonation,proto3" json:"pool_donation,omitempty"`
+	TotalDonated  uint64                 `protobuf:"varint,3,opt,name=total_donated,json=totalDonated,proto3" json:"total_donated,omitempty"`
+	FirstJoined   uint64                 `protobuf:"varint,4,opt,name=first_joined,json=firstJoined,proto3" json:"first_joined,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopDonator) Reset() {
+	*x = TopDonator{}
+	mi := &file_flexpool_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopDonator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopDonator) ProtoMessage() {}
+
+func (x *TopDonator) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopDonator.ProtoReflect.Descriptor instead.
+func (*TopDonator) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TopDonator) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TopDonator) GetPoolDonation() float64 {
+	if x != nil {
+		return x.PoolDonation
+	}
+	return 0
+}
+
+func (x *TopDonator) GetTotalDonated() uint64 {
+	if x != nil {
+		return x.TotalDonated
+	}
+	return 0
+}
+
+func (x *TopDonator) GetFirstJoined() uint64 {
+	if x != nil {
+		return x.FirstJoined
+	}
+	return 0
+}
+
+type TopDonators struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Donators      []*TopDonator          `protobuf:"bytes,1,rep,name=donators,proto3" json:"donators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopDonators) Reset() {
+	*x = TopDonators{}
+	mi := &file_flexpool_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopDonators) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopDonators) ProtoMessage() {}
+
+func (x *TopDonators) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopDonators.ProtoReflect.Descriptor instead.
+func (*TopDonators) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TopDonators) GetDonators() []*TopDonator {
+	if x != nil {
+		return x.Donators
+	}
+	return nil
+}
+
+type LuckRoundTime struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Luck          float64                `protobuf:"fixed64,1,opt,name=luck,proto3" json:"luck,omitempty"`
+	RoundTime     float64                `protobuf:"fixed64,2,opt,name=round_time,json=roundTime,proto3" json:"round_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LuckRoundTime) Reset() {
+	*x = LuckRoundTime{}
+	mi := &file_flexpool_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LuckRoundTime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LuckRoundTime) ProtoMessage() {}
+
+func (x *LuckRoundTime) ProtoReflect() protoreflect.Message {
+	mi := &file_flexpool_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LuckRoundTime.ProtoReflect.Descriptor instead.
+func (*LuckRoundTime) Descriptor() ([]byte, []int) {
+	return file_flexpool_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LuckRoundTime) GetLuck() float64 {
+	if x != nil {
+		return x.Luck
+	}
+	return 0
+}
+
+func (x *LuckRoundTime) GetRoundTime() float64 {
+	if x != nil {
+		return x.RoundTime
+	}
+	return 0
+}
+
+var File_flexpool_proto protoreflect.FileDescriptor
+
+const file_flexpool_proto_rawDesc = "" +
+	"\n" +
+	"\x0eflexpool.proto\x12\vflexpool.v1\"(\n" +
+	"\fMinerRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"A\n" +
+	"\x11PagedMinerRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\"A\n" +
+	"\rWorkerRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06worker\x18\x02 \x01(\tR\x06worker\"\r\n" +
+	"\vPoolRequest\"&\n" +
+	"\x10PagedPoolRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x03R\x04page\"\x1c\n" +
+	"\x06Amount\x12\x12\n" +
+	"\x04gwei\x18\x01 \x01(\x04R\x04gwei\"\x1d\n" +
+	"\x05Count\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"\"\n" +
+	"\n" +
+	"RoundShare\x12\x14\n" +
+	"\x05share\x18\x01 \x01(\x01R\x05share\"\x1a\n" +
+	"\x04Luck\x12\x12\n" +
+	"\x04luck\x18\x01 \x01(\x01R\x04luck\"j\n" +
+	"\fCurrentStats\x12-\n" +
+	"\x12effective_hashrate\x18\x01 \x01(\x04R\x11effectiveHashrate\x12+\n" +
+	"\x11reported_hashrate\x18\x02 \x01(\x04R\x10reportedHashrate\"\xda\x01\n" +
+	"\x0fMinerDailyStats\x12-\n" +
+	"\x12effective_hashrate\x18\x01 \x01(\x01R\x11effectiveHashrate\x12+\n" +
+	"\x11reported_hashrate\x18\x02 \x01(\x01R\x10reportedHashrate\x12!\n" +
+	"\fvalid_shares\x18\x03 \x01(\x03R\vvalidShares\x12!\n" +
+	"\fstale_shares\x18\x04 \x01(\x03R\vstaleShares\x12%\n" +
+	"\x0einvalid_shares\x18\x05 \x01(\x03R\rinvalidShares\"u\n" +
+	"\n" +
+	"MinerStats\x123\n" +
+	"\acurrent\x18\x01 \x01(\v2\x19.flexpool.v1.CurrentStatsR\acurrent\x122\n" +
+	"\x05daily\x18\x02 \x01(\v2\x1c.flexpool.v1.MinerDailyStatsR\x05daily\"?\n" +
+	"\vWorkerCount\x12\x16\n" +
+	"\x06online\x18\x01 \x01(\x03R\x06online\x12\x18\n" +
+	"\aoffline\x18\x02 \x01(\x03R\aoffline\"\xd4\x02\n" +
+	"\x06Worker\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06online\x18\x02 \x01(\bR\x06online\x128\n" +
+	"\x18duplicate_workers_merged\x18\x03 \x01(\x03R\x16duplicateWorkersMerged\x12+\n" +
+	"\x11reported_hashrate\x18\x04 \x01(\x04R\x10reportedHashrate\x12-\n" +
+	"\x12effective_hashrate\x18\x05 \x01(\x04R\x11effectiveHashrate\x12!\n" +
+	"\fvalid_shares\x18\x06 \x01(\x03R\vvalidShares\x12!\n" +
+	"\fstale_shares\x18\a \x01(\x03R\vstaleShares\x12%\n" +
+	"\x0einvalid_shares\x18\b \x01(\x03R\rinvalidShares\x12\x1b\n" +
+	"\tlast_seen\x18\t \x01(\x03R\blastSeen\";\n" +
+	"\n" +
+	"WorkerList\x12-\n" +
+	"\aworkers\x18\x01 \x03(\v2\x13.flexpool.v1.WorkerR\aworkers\"\xb0\x01\n" +
+	"\vWorkerEvent\x121\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1d.flexpool.v1.WorkerEvent.TypeR\x04type\x12+\n" +
+	"\x06worker\x18\x02 \x01(\v2\x13.flexpool.v1.WorkerR\x06worker\"A\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05ADDED\x10\x01\x12\v\n" +
+	"\aCHANGED\x10\x02\x12\v\n" +
+	"\aREMOVED\x10\x03\"\xb1\x02\n" +
+	"\n" +
+	"ChartPoint\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x04R\ttimestamp\x12-\n" +
+	"\x12effective_hashrate\x18\x02 \x01(\x04R\x11effectiveHashrate\x12<\n" +
+	"\x1aaverage_effective_hashrate\x18\x03 \x01(\x01R\x18averageEffectiveHashrate\x12+\n" +
+	"\x11reported_hashrate\x18\x04 \x01(\x04R\x10reportedHashrate\x12!\n" +
+	"\fvalid_shares\x18\x05 \x01(\x03R\vvalidShares\x12!\n" +
+	"\fstale_shares\x18\x06 \x01(\x03R\vstaleShares\x12%\n" +
+	"\x0einvalid_shares\x18\a \x01(\x03R\rinvalidShares\"=\n" +
+	"\n" +
+	"MinerChart\x12/\n" +
+	"\x06points\x18\x01 \x03(\v2\x17.flexpool.v1.ChartPointR\x06points\"o\n" +
+	"\aPayment\x12\x12\n" +
+	"\x04txid\x18\x01 \x01(\tR\x04txid\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x04R\x06amount\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x04R\ttimestamp\x12\x1a\n" +
+	"\bduration\x18\x04 \x01(\x04R\bduration\"\xa7\x01\n" +
+	"\vPaymentPage\x120\n" +
+	"\bpayments\x18\x01 \x03(\v2\x14.flexpool.v1.PaymentR\bpayments\x12$\n" +
+	"\x0eitems_per_page\x18\x02 \x01(\x03R\fitemsPerPage\x12\x1f\n" +
+	"\vtotal_items\x18\x03 \x01(\x03R\n" +
+	"totalItems\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x03R\n" +
+	"totalPages\"I\n" +
+	"\x11PaymentChartPoint\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x04R\x06amount\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x04R\ttimestamp\"F\n" +
+	"\fPaymentChart\x126\n" +
+	"\x06points\x18\x01 \x03(\v2\x1e.flexpool.v1.PaymentChartPointR\x06points\"\xac\x03\n" +
+	"\x05Block\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x04R\x06number\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05miner\x18\x04 \x01(\tR\x05miner\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x05 \x01(\x04R\n" +
+	"difficulty\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x04R\ttimestamp\x12\x1c\n" +
+	"\tconfirmed\x18\a \x01(\bR\tconfirmed\x12\x1d\n" +
+	"\n" +
+	"round_time\x18\b \x01(\x04R\troundTime\x12\x12\n" +
+	"\x04luck\x18\t \x01(\x01R\x04luck\x12\x1f\n" +
+	"\vserver_name\x18\n" +
+	" \x01(\tR\n" +
+	"serverName\x12!\n" +
+	"\fblock_reward\x18\v \x01(\x04R\vblockReward\x12\x1d\n" +
+	"\n" +
+	"block_fees\x18\f \x01(\x04R\tblockFees\x126\n" +
+	"\x17uncle_inclusion_rewards\x18\r \x01(\x04R\x15uncleInclusionRewards\x12#\n" +
+	"\rtotal_rewards\x18\x0e \x01(\x04R\ftotalRewards\"\x9f\x01\n" +
+	"\tBlockPage\x12*\n" +
+	"\x06blocks\x18\x01 \x03(\v2\x12.flexpool.v1.BlockR\x06blocks\x12$\n" +
+	"\x0eitems_per_page\x18\x02 \x01(\x03R\fitemsPerPage\x12\x1f\n" +
+	"\vtotal_items\x18\x03 \x01(\x03R\n" +
+	"totalItems\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x03R\n" +
+	"totalPages\"L\n" +
+	"\n" +
+	"BlockCount\x12\x1c\n" +
+	"\tconfirmed\x18\x01 \x01(\x03R\tconfirmed\x12 \n" +
+	"\vunconfirmed\x18\x02 \x01(\x03R\vunconfirmed\"\xf4\x01\n" +
+	"\fMinerDetails\x120\n" +
+	"\x14min_payout_threshold\x18\x01 \x01(\x04R\x12minPayoutThreshold\x12#\n" +
+	"\rpool_donation\x18\x02 \x01(\x01R\fpoolDonation\x12\"\n" +
+	"\rmax_fee_price\x18\x03 \x01(\x04R\vmaxFeePrice\x12%\n" +
+	"\x0ecensored_email\x18\x04 \x01(\tR\rcensoredEmail\x12\x1f\n" +
+	"\vcensored_ip\x18\x05 \x01(\tR\n" +
+	"censoredIp\x12!\n" +
+	"\ffirst_joined\x18\x06 \x01(\x04R\vfirstJoined\"\xdb\x01\n" +
+	"\x10WorkerDailyStats\x12-\n" +
+	"\x12effective_hashrate\x18\x01 \x01(\x04R\x11effectiveHashrate\x12+\n" +
+	"\x11reported_hashrate\x18\x02 \x01(\x04R\x10reportedHashrate\x12!\n" +
+	"\fvalid_shares\x18\x03 \x01(\x03R\vvalidShares\x12!\n" +
+	"\fstale_shares\x18\x04 \x01(\x03R\vstaleShares\x12%\n" +
+	"\x0einvalid_shares\x18\x05 \x01(\x03R\rinvalidShares\"w\n" +
+	"\vWorkerStats\x123\n" +
+	"\acurrent\x18\x01 \x01(\v2\x19.flexpool.v1.CurrentStatsR\acurrent\x123\n" +
+	"\x05daily\x18\x02 \x01(\v2\x1d.flexpool.v1.WorkerDailyStatsR\x05daily\">\n" +
+	"\vWorkerChart\x12/\n" +
+	"\x06points\x18\x01 \x03(\v2\x17.flexpool.v1.ChartPointR\x06points\"t\n" +
+	"\fPoolHashrate\x12\x0e\n" +
+	"\x02as\x18\x01 \x01(\x04R\x02as\x12\x0e\n" +
+	"\x02au\x18\x02 \x01(\x04R\x02au\x12\x0e\n" +
+	"\x02eu\x18\x03 \x01(\x04R\x02eu\x12\x0e\n" +
+	"\x02sa\x18\x04 \x01(\x04R\x02sa\x12\x0e\n" +
+	"\x02us\x18\x05 \x01(\x04R\x02us\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x04R\x05total\"m\n" +
+	"\x16PoolHashrateChartPoint\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x04R\ttimestamp\x125\n" +
+	"\bhashrate\x18\x02 \x01(\v2\x19.flexpool.v1.PoolHashrateR\bhashrate\"P\n" +
+	"\x11PoolHashrateChart\x12;\n" +
+	"\x06points\x18\x01 \x03(\v2#.flexpool.v1.PoolHashrateChartPointR\x06points\"\xc7\x01\n" +
+	"\bTopMiner\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1a\n" +
+	"\bhashrate\x18\x02 \x01(\x04R\bhashrate\x12#\n" +
+	"\rtotal_workers\x18\x03 \x01(\x03R\ftotalWorkers\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x04R\abalance\x12#\n" +
+	"\rpool_donation\x18\x05 \x01(\x01R\fpoolDonation\x12!\n" +
+	"\ffirst_joined\x18\x06 \x01(\x04R\vfirstJoined\":\n" +
+	"\tTopMiners\x12-\n" +
+	"\x06miners\x18\x01 \x03(\v2\x15.flexpool.v1.TopMinerR\x06miners\"\x93\x01\n" +
+	"\n" +
+	"TopDonator\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12#\n" +
+	"\rpool_donation\x18\x02 \x01(\x01R\fpoolDonation\x12#\n" +
+	"\rtotal_donated\x18\x03 \x01(\x04R\ftotalDonated\x12!\n" +
+	"\ffirst_joined\x18\x04 \x01(\x04R\vfirstJoined\"B\n" +
+	"\vTopDonators\x123\n" +
+	"\bdonators\x18\x01 \x03(\v2\x17.flexpool.v1.TopDonatorR\bdonators\"B\n" +
+	"\rLuckRoundTime\x12\x12\n" +
+	"\x04luck\x18\x01 \x01(\x01R\x04luck\x12\x1d\n" +
+	"\n" +
+	"round_time\x18\x02 \x01(\x01R\troundTime2\xdb\t\n" +
+	"\fMinerService\x12<\n" +
+	"\n" +
+	"GetBalance\x12\x19.flexpool.v1.MinerRequest\x1a\x13.flexpool.v1.Amount\x12B\n" +
+	"\n" +
+	"GetCurrent\x12\x19.flexpool.v1.MinerRequest\x1a\x19.flexpool.v1.CurrentStats\x12C\n" +
+	"\bGetDaily\x12\x19.flexpool.v1.MinerRequest\x1a\x1c.flexpool.v1.MinerDailyStats\x12>\n" +
+	"\bGetStats\x12\x19.flexpool.v1.MinerRequest\x1a\x17.flexpool.v1.MinerStats\x12E\n" +
+	"\x0eGetWorkerCount\x12\x19.flexpool.v1.MinerRequest\x1a\x18.flexpool.v1.WorkerCount\x12@\n" +
+	"\n" +
+	"GetWorkers\x12\x19.flexpool.v1.MinerRequest\x1a\x17.flexpool.v1.WorkerList\x12>\n" +
+	"\bGetChart\x12\x19.flexpool.v1.MinerRequest\x1a\x17.flexpool.v1.MinerChart\x12G\n" +
+	"\vGetPayments\x12\x1e.flexpool.v1.PagedMinerRequest\x1a\x18.flexpool.v1.PaymentPage\x12@\n" +
+	"\x0fGetPaymentCount\x12\x19.flexpool.v1.MinerRequest\x1a\x12.flexpool.v1.Count\x12G\n" +
+	"\x0fGetPaymentChart\x12\x19.flexpool.v1.MinerRequest\x1a\x19.flexpool.v1.PaymentChart\x12C\n" +
+	"\tGetBlocks\x12\x1e.flexpool.v1.PagedMinerRequest\x1a\x16.flexpool.v1.BlockPage\x12C\n" +
+	"\rGetBlockCount\x12\x19.flexpool.v1.MinerRequest\x1a\x17.flexpool.v1.BlockCount\x12B\n" +
+	"\n" +
+	"GetDetails\x12\x19.flexpool.v1.MinerRequest\x1a\x19.flexpool.v1.MinerDetails\x12J\n" +
+	"\x18GetEstimatedDailyRevenue\x12\x19.flexpool.v1.MinerRequest\x1a\x13.flexpool.v1.Amount\x12C\n" +
+	"\rGetRoundShare\x12\x19.flexpool.v1.MinerRequest\x1a\x17.flexpool.v1.RoundShare\x12>\n" +
+	"\fGetTotalPaid\x12\x19.flexpool.v1.MinerRequest\x1a\x13.flexpool.v1.Amount\x12A\n" +
+	"\x0fGetTotalDonated\x12\x19.flexpool.v1.MinerRequest\x1a\x13.flexpool.v1.Amount\x12E\n" +
+	"\fWatchWorkers\x12\x19.flexpool.v1.MinerRequest\x1a\x18.flexpool.v1.WorkerEvent0\x012\x9f\x02\n" +
+	"\rWorkerService\x12C\n" +
+	"\n" +
+	"GetCurrent\x12\x1a.flexpool.v1.WorkerRequest\x1a\x19.flexpool.v1.CurrentStats\x12E\n" +
+	"\bGetDaily\x12\x1a.flexpool.v1.WorkerRequest\x1a\x1d.flexpool.v1.WorkerDailyStats\x12@\n" +
+	"\bGetStats\x12\x1a.flexpool.v1.WorkerRequest\x1a\x18.flexpool.v1.WorkerStats\x12@\n" +
+	"\bGetChart\x12\x1a.flexpool.v1.WorkerRequest\x1a\x18.flexpool.v1.WorkerChart2\xc9\x06\n" +
+	"\vPoolService\x12B\n" +
+	"\vGetHashrate\x12\x18.flexpool.v1.PoolRequest\x1a\x19.flexpool.v1.PoolHashrate\x12L\n" +
+	"\x10GetHashrateChart\x12\x18.flexpool.v1.PoolRequest\x1a\x1e.flexpool.v1.PoolHashrateChart\x12?\n" +
+	"\x0fGetMinersOnline\x12\x18.flexpool.v1.PoolRequest\x1a\x12.flexpool.v1.Count\x12@\n" +
+	"\x10GetWorkersOnline\x12\x18.flexpool.v1.PoolRequest\x1a\x12.flexpool.v1.Count\x12B\n" +
+	"\tGetBlocks\x12\x1d.flexpool.v1.PagedPoolRequest\x1a\x16.flexpool.v1.BlockPage\x12B\n" +
+	"\rGetBlockCount\x12\x18.flexpool.v1.PoolRequest\x1a\x17.flexpool.v1.BlockCount\x12@\n" +
+	"\fGetTopMiners\x12\x18.flexpool.v1.PoolRequest\x1a\x16.flexpool.v1.TopMiners\x12D\n" +
+	"\x0eGetTopDonators\x12\x18.flexpool.v1.PoolRequest\x1a\x18.flexpool.v1.TopDonators\x12O\n" +
+	"\x17GetAverageLuckRoundTime\x12\x18.flexpool.v1.PoolRequest\x1a\x1a.flexpool.v1.LuckRoundTime\x12=\n" +
+	"\x0eGetCurrentLuck\x12\x18.flexpool.v1.PoolRequest\x1a\x11.flexpool.v1.Luck\x12F\n" +
+	"\x15GetAverageBlockReward\x12\x18.flexpool.v1.PoolRequest\x1a\x13.flexpool.v1.Amount\x12=\n" +
+	"\vWatchBlocks\x12\x18.flexpool.v1.PoolRequest\x1a\x12.flexpool.v1.Block0\x01B+Z)github.com/cryptogenic/goflexpool/pkg/rpcb\x06proto3"
+
+var (
+	file_flexpool_proto_rawDescOnce sync.Once
+	file_flexpool_proto_rawDescData []byte
+)
+
+func file_flexpool_proto_rawDescGZIP() []byte {
+	file_flexpool_proto_rawDescOnce.Do(func() {
+		file_flexpool_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_flexpool_proto_rawDesc), len(file_flexpool_proto_rawDesc)))
+	})
+	return file_flexpool_proto_rawDescData
+}
+
+var file_flexpool_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_flexpool_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_flexpool_proto_goTypes = []any{
+	(WorkerEvent_Type)(0),          // 0: flexpool.v1.WorkerEvent.Type
+	(*MinerRequest)(nil),           // 1: flexpool.v1.MinerRequest
+	(*PagedMinerRequest)(nil),      // 2: flexpool.v1.PagedMinerRequest
+	(*WorkerRequest)(nil),          // 3: flexpool.v1.WorkerRequest
+	(*PoolRequest)(nil),            // 4: flexpool.v1.PoolRequest
+	(*PagedPoolRequest)(nil),       // 5: flexpool.v1.PagedPoolRequest
+	(*Amount)(nil),                 // 6: flexpool.v1.Amount
+	(*Count)(nil),                  // 7: flexpool.v1.Count
+	(*RoundShare)(nil),             // 8: flexpool.v1.RoundShare
+	(*Luck)(nil),                   // 9: flexpool.v1.Luck
+	(*CurrentStats)(nil),           // 10: flexpool.v1.CurrentStats
+	(*MinerDailyStats)(nil),        // 11: flexpool.v1.MinerDailyStats
+	(*MinerStats)(nil),             // 12: flexpool.v1.MinerStats
+	(*WorkerCount)(nil),            // 13: flexpool.v1.WorkerCount
+	(*Worker)(nil),                 // 14: flexpool.v1.Worker
+	(*WorkerList)(nil),             // 15: flexpool.v1.WorkerList
+	(*WorkerEvent)(nil),            // 16: flexpool.v1.WorkerEvent
+	(*ChartPoint)(nil),             // 17: flexpool.v1.ChartPoint
+	(*MinerChart)(nil),             // 18: flexpool.v1.MinerChart
+	(*Payment)(nil),                // 19: flexpool.v1.Payment
+	(*PaymentPage)(nil),            // 20: flexpool.v1.PaymentPage
+	(*PaymentChartPoint)(nil),      // 21: flexpool.v1.PaymentChartPoint
+	(*PaymentChart)(nil),           // 22: flexpool.v1.PaymentChart
+	(*Block)(nil),                  // 23: flexpool.v1.Block
+	(*BlockPage)(nil),              // 24: flexpool.v1.BlockPage
+	(*BlockCount)(nil),             // 25: flexpool.v1.BlockCount
+	(*MinerDetails)(nil),           // 26: flexpool.v1.MinerDetails
+	(*WorkerDailyStats)(nil),       // 27: flexpool.v1.WorkerDailyStats
+	(*WorkerStats)(nil),            // 28: flexpool.v1.WorkerStats
+	(*WorkerChart)(nil),            // 29: flexpool.v1.WorkerChart
+	(*PoolHashrate)(nil),           // 30: flexpool.v1.PoolHashrate
+	(*PoolHashrateChartPoint)(nil), // 31: flexpool.v1.PoolHashrateChartPoint
+	(*PoolHashrateChart)(nil),      // 32: flexpool.v1.PoolHashrateChart
+	(*TopMiner)(nil),               // 33: flexpool.v1.TopMiner
+	(*TopMiners)(nil),              // 34: flexpool.v1.TopMiners
+	(*TopDonator)(nil),             // 35: flexpool.v1.TopDonator
+	(*TopDonators)(nil),            // 36: flexpool.v1.TopDonators
+	(*LuckRoundTime)(nil),          // 37: flexpool.v1.LuckRoundTime
+}
+var file_flexpool_proto_depIdxs = []int32{
+	10, // 0: flexpool.v1.MinerStats.current:type_name -> flexpool.v1.CurrentStats
+	11, // 1: flexpool.v1.MinerStats.daily:type_name -> flexpool.v1.MinerDailyStats
+	14, // 2: flexpool.v1.WorkerList.workers:type_name -> flexpool.v1.Worker
+	0,  // 3: flexpool.v1.WorkerEvent.type:type_name -> flexpool.v1.WorkerEvent.Type
+	14, // 4: flexpool.v1.WorkerEvent.worker:type_name -> flexpool.v1.Worker
+	17, // 5: flexpool.v1.MinerChart.points:type_name -> flexpool.v1.ChartPoint
+	19, // 6: flexpool.v1.PaymentPage.payments:type_name -> flexpool.v1.Payment
+	21, // 7: flexpool.v1.PaymentChart.points:type_name -> flexpool.v1.PaymentChartPoint
+	23, // 8: flexpool.v1.BlockPage.blocks:type_name -> flexpool.v1.Block
+	10, // 9: flexpool.v1.WorkerStats.current:type_name -> flexpool.v1.CurrentStats
+	27, // 10: flexpool.v1.WorkerStats.daily:type_name -> flexpool.v1.WorkerDailyStats
+	17, // 11: flexpool.v1.WorkerChart.points:type_name -> flexpool.v1.ChartPoint
+	30, // 12: flexpool.v1.PoolHashrateChartPoint.hashrate:type_name -> flexpool.v1.PoolHashrate
+	31, // 13: flexpool.v1.PoolHashrateChart.points:type_name -> flexpool.v1.PoolHashrateChartPoint
+	33, // 14: flexpool.v1.TopMiners.miners:type_name -> flexpool.v1.TopMiner
+	35, // 15: flexpool.v1.TopDonators.donators:type_name -> flexpool.v1.TopDonator
+	1,  // 16: flexpool.v1.MinerService.GetBalance:input_type -> flexpool.v1.MinerRequest
+	1,  // 17: flexpool.v1.MinerService.GetCurrent:input_type -> flexpool.v1.MinerRequest
+	1,  // 18: flexpool.v1.MinerService.GetDaily:input_type -> flexpool.v1.MinerRequest
+	1,  // 19: flexpool.v1.MinerService.GetStats:input_type -> flexpool.v1.MinerRequest
+	1,  // 20: flexpool.v1.MinerService.GetWorkerCount:input_type -> flexpool.v1.MinerRequest
+	1,  // 21: flexpool.v1.MinerService.GetWorkers:input_type -> flexpool.v1.MinerRequest
+	1,  // 22: flexpool.v1.MinerService.GetChart:input_type -> flexpool.v1.MinerRequest
+	2,  // 23: flexpool.v1.MinerService.GetPayments:input_type -> flexpool.v1.PagedMinerRequest
+	1,  // 24: flexpool.v1.MinerService.GetPaymentCount:input_type -> flexpool.v1.MinerRequest
+	1,  // 25: flexpool.v1.MinerService.GetPaymentChart:input_type -> flexpool.v1.MinerRequest
+	2,  // 26: flexpool.v1.MinerService.GetBlocks:input_type -> flexpool.v1.PagedMinerRequest
+	1,  // 27: flexpool.v1.MinerService.GetBlockCount:input_type -> flexpool.v1.MinerRequest
+	1,  // 28: flexpool.v1.MinerService.GetDetails:input_type -> flexpool.v1.MinerRequest
+	1,  // 29: flexpool.v1.MinerService.GetEstimatedDailyRevenue:input_type -> flexpool.v1.MinerRequest
+	1,  // 30: flexpool.v1.MinerService.GetRoundShare:input_type -> flexpool.v1.MinerRequest
+	1,  // 31: flexpool.v1.MinerService.GetTotalPaid:input_type -> flexpool.v1.MinerRequest
+	1,  // 32: flexpool.v1.MinerService.GetTotalDonated:input_type -> flexpool.v1.MinerRequest
+	1,  // 33: flexpool.v1.MinerService.WatchWorkers:input_type -> flexpool.v1.MinerRequest
+	3,  // 34: flexpool.v1.WorkerService.GetCurrent:input_type -> flexpool.v1.WorkerRequest
+	3,  // 35: flexpool.v1.WorkerService.GetDaily:input_type -> flexpool.v1.WorkerRequest
+	3,  // 36: flexpool.v1.WorkerService.GetStats:input_type -> flexpool.v1.WorkerRequest
+	3,  // 37: flexpool.v1.WorkerService.GetChart:input_type -> flexpool.v1.WorkerRequest
+	4,  // 38: flexpool.v1.PoolService.GetHashrate:input_type -> flexpool.v1.PoolRequest
+	4,  // 39: flexpool.v1.PoolService.GetHashrateChart:input_type -> flexpool.v1.PoolRequest
+	4,  // 40: flexpool.v1.PoolService.GetMinersOnline:input_type -> flexpool.v1.PoolRequest
+	4,  // 41: flexpool.v1.PoolService.GetWorkersOnline:input_type -> flexpool.v1.PoolRequest
+	5,  // 42: flexpool.v1.PoolService.GetBlocks:input_type -> flexpool.v1.PagedPoolRequest
+	4,  // 43: flexpool.v1.PoolService.GetBlockCount:input_type -> flexpool.v1.PoolRequest
+	4,  // 44: flexpool.v1.PoolService.GetTopMiners:input_type -> flexpool.v1.PoolRequest
+	4,  // 45: flexpool.v1.PoolService.GetTopDonators:input_type -> flexpool.v1.PoolRequest
+	4,  // 46: flexpool.v1.PoolService.GetAverageLuckRoundTime:input_type -> flexpool.v1.PoolRequest
+	4,  // 47: flexpool.v1.PoolService.GetCurrentLuck:input_type -> flexpool.v1.PoolRequest
+	4,  // 48: flexpool.v1.PoolService.GetAverageBlockReward:input_type -> flexpool.v1.PoolRequest
+	4,  // 49: flexpool.v1.PoolService.WatchBlocks:input_type -> flexpool.v1.PoolRequest
+	6,  // 50: flexpool.v1.MinerService.GetBalance:output_type -> flexpool.v1.Amount
+	10, // 51: flexpool.v1.MinerService.GetCurrent:output_type -> flexpool.v1.CurrentStats
+	11, // 52: flexpool.v1.MinerService.GetDaily:output_type -> flexpool.v1.MinerDailyStats
+	12, // 53: flexpool.v1.MinerService.GetStats:output_type -> flexpool.v1.MinerStats
+	13, // 54: flexpool.v1.MinerService.GetWorkerCount:output_type -> flexpool.v1.WorkerCount
+	15, // 55: flexpool.v1.MinerService.GetWorkers:output_type -> flexpool.v1.WorkerList
+	18, // 56: flexpool.v1.MinerService.GetChart:output_type -> flexpool.v1.MinerChart
+	20, // 57: flexpool.v1.MinerService.GetPayments:output_type -> flexpool.v1.PaymentPage
+	7,  // 58: flexpool.v1.MinerService.GetPaymentCount:output_type -> flexpool.v1.Count
+	22, // 59: flexpool.v1.MinerService.GetPaymentChart:output_type -> flexpool.v1.PaymentChart
+	24, // 60: flexpool.v1.MinerService.GetBlocks:output_type -> flexpool.v1.BlockPage
+	25, // 61: flexpool.v1.MinerService.GetBlockCount:output_type -> flexpool.v1.BlockCount
+	26, // 62: flexpool.v1.MinerService.GetDetails:output_type -> flexpool.v1.MinerDetails
+	6,  // 63: flexpool.v1.MinerService.GetEstimatedDailyRevenue:output_type -> flexpool.v1.Amount
+	8,  // 64: flexpool.v1.MinerService.GetRoundShare:output_type -> flexpool.v1.RoundShare
+	6,  // 65: flexpool.v1.MinerService.GetTotalPaid:output_type -> flexpool.v1.Amount
+	6,  // 66: flexpool.v1.MinerService.GetTotalDonated:output_type -> flexpool.v1.Amount
+	16, // 67: flexpool.v1.MinerService.WatchWorkers:output_type -> flexpool.v1.WorkerEvent
+	10, // 68: flexpool.v1.WorkerService.GetCurrent:output_type -> flexpool.v1.CurrentStats
+	27, // 69: flexpool.v1.WorkerService.GetDaily:output_type -> flexpool.v1.WorkerDailyStats
+	28, // 70: flexpool.v1.WorkerService.GetStats:output_type -> flexpool.v1.WorkerStats
+	29, // 71: flexpool.v1.WorkerService.GetChart:output_type -> flexpool.v1.WorkerChart
+	30, // 72: flexpool.v1.PoolService.GetHashrate:output_type -> flexpool.v1.PoolHashrate
+	32, // 73: flexpool.v1.PoolService.GetHashrateChart:output_type -> flexpool.v1.PoolHashrateChart
+	7,  // 74: flexpool.v1.PoolService.GetMinersOnline:output_type -> flexpool.v1.Count
+	7,  // 75: flexpool.v1.PoolService.GetWorkersOnline:output_type -> flexpool.v1.Count
+	24, // 76: flexpool.v1.PoolService.GetBlocks:output_type -> flexpool.v1.BlockPage
+	25, // 77: flexpool.v1.PoolService.GetBlockCount:output_type -> flexpool.v1.BlockCount
+	34, // 78: flexpool.v1.PoolService.GetTopMiners:output_type -> flexpool.v1.TopMiners
+	36, // 79: flexpool.v1.PoolService.GetTopDonators:output_type -> flexpool.v1.TopDonators
+	37, // 80: flexpool.v1.PoolService.GetAverageLuckRoundTime:output_type -> flexpool.v1.LuckRoundTime
+	9,  // 81: flexpool.v1.PoolService.GetCurrentLuck:output_type -> flexpool.v1.Luck
+	6,  // 82: flexpool.v1.PoolService.GetAverageBlockReward:output_type -> flexpool.v1.Amount
+	23, // 83: flexpool.v1.PoolService.WatchBlocks:output_type -> flexpool.v1.Block
+	50, // [50:84] is the sub-list for method output_type
+	16, // [16:50] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_flexpool_proto_init() }
+func file_flexpool_proto_init() {
+	if File_flexpool_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_flexpool_proto_rawDesc), len(file_flexpool_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   37,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_flexpool_proto_goTypes,
+		DependencyIndexes: file_flexpool_proto_depIdxs,
+		EnumInfos:         file_flexpool_proto_enumTypes,
+		MessageInfos:      file_flexpool_proto_msgTypes,
+	}.Build()
+	File_flexpool_proto = out.File
+	file_flexpool_proto_goTypes = nil
+	file_flexpool_proto_depIdxs = nil
+}
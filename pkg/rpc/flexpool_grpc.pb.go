@@ -0,0 +1,1530 @@
+// Services exposing the flexpool API through goflexpool. Messages mirror the api package's types, with amounts in gwei
+// and hashrates in hashes per second.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: flexpool.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MinerService_GetBalance_FullMethodName               = "/flexpool.v1.MinerService/GetBalance"
+	MinerService_GetCurrent_FullMethodName               = "/flexpool.v1.MinerService/GetCurrent"
+	MinerService_GetDaily_FullMethodName                 = "/flexpool.v1.MinerService/GetDaily"
+	MinerService_GetStats_FullMethodName                 = "/flexpool.v1.MinerService/GetStats"
+	MinerService_GetWorkerCount_FullMethodName           = "/flexpool.v1.MinerService/GetWorkerCount"
+	MinerService_GetWorkers_FullMethodName               = "/flexpool.v1.MinerService/GetWorkers"
+	MinerService_GetChart_FullMethodName                 = "/flexpool.v1.MinerService/GetChart"
+	MinerService_GetPayments_FullMethodName              = "/flexpool.v1.MinerService/GetPayments"
+	MinerService_GetPaymentCount_FullMethodName          = "/flexpool.v1.MinerService/GetPaymentCount"
+	MinerService_GetPaymentChart_FullMethodName          = "/flexpool.v1.MinerService/GetPaymentChart"
+	MinerService_GetBlocks_FullMethodName                = "/flexpool.v1.MinerService/GetBlocks"
+	MinerService_GetBlockCount_FullMethodName            = "/flexpool.v1.MinerService/GetBlockCount"
+	MinerService_GetDetails_FullMethodName               = "/flexpool.v1.MinerService/GetDetails"
+	MinerService_GetEstimatedDailyRevenue_FullMethodName = "/flexpool.v1.MinerService/GetEstimatedDailyRevenue"
+	MinerService_GetRoundShare_FullMethodName            = "/flexpool.v1.MinerService/GetRoundShare"
+	MinerService_GetTotalPaid_FullMethodName             = "/flexpool.v1.MinerService/GetTotalPaid"
+	MinerService_GetTotalDonated_FullMethodName          = "/flexpool.v1.MinerService/GetTotalDonated"
+	MinerService_WatchWorkers_FullMethodName             = "/flexpool.v1.MinerService/WatchWorkers"
+)
+
+// MinerServiceClient is the client API for MinerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MinerService wraps the /miner/{address} endpoints.
+type MinerServiceClient interface {
+	GetBalance(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*Amount, error)
+	GetCurrent(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*CurrentStats, error)
+	GetDaily(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*MinerDailyStats, error)
+	GetStats(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*MinerStats, error)
+	GetWorkerCount(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*WorkerCount, error)
+	GetWorkers(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*WorkerList, error)
+	GetChart(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*MinerChart, error)
+	GetPayments(ctx context.Context, in *PagedMinerRequest, opts ...grpc.CallOption) (*PaymentPage, error)
+	GetPaymentCount(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*Count, error)
+	GetPaymentChart(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*PaymentChart, error)
+	GetBlocks(ctx context.Context, in *PagedMinerRequest, opts ...grpc.CallOption) (*BlockPage, error)
+	GetBlockCount(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*BlockCount, error)
+	GetDetails(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*MinerDetails, error)
+	GetEstimatedDailyRevenue(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*Amount, error)
+	GetRoundShare(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*RoundShare, error)
+	GetTotalPaid(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*Amount, error)
+	GetTotalDonated(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*Amount, error)
+	// WatchWorkers sends every worker as ADDED when the stream starts, then each worker that's added, changed or removed.
+	WatchWorkers(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WorkerEvent], error)
+}
+
+type minerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMinerServiceClient(cc grpc.ClientConnInterface) MinerServiceClient {
+	return &minerServiceClient{cc}
+}
+
+func (c *minerServiceClient) GetBalance(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*Amount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Amount)
+	err := c.cc.Invoke(ctx, MinerService_GetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetCurrent(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*CurrentStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CurrentStats)
+	err := c.cc.Invoke(ctx, MinerService_GetCurrent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetDaily(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*MinerDailyStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MinerDailyStats)
+	err := c.cc.Invoke(ctx, MinerService_GetDaily_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetStats(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*MinerStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MinerStats)
+	err := c.cc.Invoke(ctx, MinerService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetWorkerCount(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*WorkerCount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerCount)
+	err := c.cc.Invoke(ctx, MinerService_GetWorkerCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetWorkers(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*WorkerList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerList)
+	err := c.cc.Invoke(ctx, MinerService_GetWorkers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetChart(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*MinerChart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MinerChart)
+	err := c.cc.Invoke(ctx, MinerService_GetChart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetPayments(ctx context.Context, in *PagedMinerRequest, opts ...grpc.CallOption) (*PaymentPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PaymentPage)
+	err := c.cc.Invoke(ctx, MinerService_GetPayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetPaymentCount(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*Count, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Count)
+	err := c.cc.Invoke(ctx, MinerService_GetPaymentCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetPaymentChart(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*PaymentChart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PaymentChart)
+	err := c.cc.Invoke(ctx, MinerService_GetPaymentChart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetBlocks(ctx context.Context, in *PagedMinerRequest, opts ...grpc.CallOption) (*BlockPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockPage)
+	err := c.cc.Invoke(ctx, MinerService_GetBlocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetBlockCount(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*BlockCount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockCount)
+	err := c.cc.Invoke(ctx, MinerService_GetBlockCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetDetails(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*MinerDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MinerDetails)
+	err := c.cc.Invoke(ctx, MinerService_GetDetails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetEstimatedDailyRevenue(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*Amount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Amount)
+	err := c.cc.Invoke(ctx, MinerService_GetEstimatedDailyRevenue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetRoundShare(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*RoundShare, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoundShare)
+	err := c.cc.Invoke(ctx, MinerService_GetRoundShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetTotalPaid(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*Amount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Amount)
+	err := c.cc.Invoke(ctx, MinerService_GetTotalPaid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) GetTotalDonated(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (*Amount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Amount)
+	err := c.cc.Invoke(ctx, MinerService_GetTotalDonated_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *minerServiceClient) WatchWorkers(ctx context.Context, in *MinerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WorkerEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MinerService_ServiceDesc.Streams[0], MinerService_WatchWorkers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MinerRequest, WorkerEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MinerService_WatchWorkersClient = grpc.ServerStreamingClient[WorkerEvent]
+
+// MinerServiceServer is the server API for MinerService service.
+// All implementations must embed UnimplementedMinerServiceServer
+// for forward compatibility.
+//
+// MinerService wraps the /miner/{address} endpoints.
+type MinerServiceServer interface {
+	GetBalance(context.Context, *MinerRequest) (*Amount, error)
+	GetCurrent(context.Context, *MinerRequest) (*CurrentStats, error)
+	GetDaily(context.Context, *MinerRequest) (*MinerDailyStats, error)
+	GetStats(context.Context, *MinerRequest) (*MinerStats, error)
+	GetWorkerCount(context.Context, *MinerRequest) (*WorkerCount, error)
+	GetWorkers(context.Context, *MinerRequest) (*WorkerList, error)
+	GetChart(context.Context, *MinerRequest) (*MinerChart, error)
+	GetPayments(context.Context, *PagedMinerRequest) (*PaymentPage, error)
+	GetPaymentCount(context.Context, *MinerRequest) (*Count, error)
+	GetPaymentChart(context.Context, *MinerRequest) (*PaymentChart, error)
+	GetBlocks(context.Context, *PagedMinerRequest) (*BlockPage, error)
+	GetBlockCount(context.Context, *MinerRequest) (*BlockCount, error)
+	GetDetails(context.Context, *MinerRequest) (*MinerDetails, error)
+	GetEstimatedDailyRevenue(context.Context, *MinerRequest) (*Amount, error)
+	GetRoundShare(context.Context, *MinerRequest) (*RoundShare, error)
+	GetTotalPaid(context.Context, *MinerRequest) (*Amount, error)
+	GetTotalDonated(context.Context, *MinerRequest) (*Amount, error)
+	// WatchWorkers sends every worker as ADDED when the stream starts, then each worker that's added, changed or removed.
+	WatchWorkers(*MinerRequest, grpc.ServerStreamingServer[WorkerEvent]) error
+	mustEmbedUnimplementedMinerServiceServer()
+}
+
+// UnimplementedMinerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMinerServiceServer struct{}
+
+func (UnimplementedMinerServiceServer) GetBalance(context.Context, *MinerRequest) (*Amount, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedMinerServiceServer) GetCurrent(context.Context, *MinerRequest) (*CurrentStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCurrent not implemented")
+}
+func (UnimplementedMinerServiceServer) GetDaily(context.Context, *MinerRequest) (*MinerDailyStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDaily not implemented")
+}
+func (UnimplementedMinerServiceServer) GetStats(context.Context, *MinerRequest) (*MinerStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedMinerServiceServer) GetWorkerCount(context.Context, *MinerRequest) (*WorkerCount, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWorkerCount not implemented")
+}
+func (UnimplementedMinerServiceServer) GetWorkers(context.Context, *MinerRequest) (*WorkerList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWorkers not implemented")
+}
+func (UnimplementedMinerServiceServer) GetChart(context.Context, *MinerRequest) (*MinerChart, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetChart not implemented")
+}
+func (UnimplementedMinerServiceServer) GetPayments(context.Context, *PagedMinerRequest) (*PaymentPage, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPayments not implemented")
+}
+func (UnimplementedMinerServiceServer) GetPaymentCount(context.Context, *MinerRequest) (*Count, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPaymentCount not implemented")
+}
+func (UnimplementedMinerServiceServer) GetPaymentChart(context.Context, *MinerRequest) (*PaymentChart, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPaymentChart not implemented")
+}
+func (UnimplementedMinerServiceServer) GetBlocks(context.Context, *PagedMinerRequest) (*BlockPage, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlocks not implemented")
+}
+func (UnimplementedMinerServiceServer) GetBlockCount(context.Context, *MinerRequest) (*BlockCount, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlockCount not implemented")
+}
+func (UnimplementedMinerServiceServer) GetDetails(context.Context, *MinerRequest) (*MinerDetails, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDetails not implemented")
+}
+func (UnimplementedMinerServiceServer) GetEstimatedDailyRevenue(context.Context, *MinerRequest) (*Amount, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEstimatedDailyRevenue not implemented")
+}
+func (UnimplementedMinerServiceServer) GetRoundShare(context.Context, *MinerRequest) (*RoundShare, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRoundShare not implemented")
+}
+func (UnimplementedMinerServiceServer) GetTotalPaid(context.Context, *MinerRequest) (*Amount, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTotalPaid not implemented")
+}
+func (UnimplementedMinerServiceServer) GetTotalDonated(context.Context, *MinerRequest) (*Amount, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTotalDonated not implemented")
+}
+func (UnimplementedMinerServiceServer) WatchWorkers(*MinerRequest, grpc.ServerStreamingServer[WorkerEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchWorkers not implemented")
+}
+func (UnimplementedMinerServiceServer) mustEmbedUnimplementedMinerServiceServer() {}
+func (UnimplementedMinerServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeMinerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MinerServiceServer will
+// result in compilation errors.
+type UnsafeMinerServiceServer interface {
+	mustEmbedUnimplementedMinerServiceServer()
+}
+
+func RegisterMinerServiceServer(s grpc.ServiceRegistrar, srv MinerServiceServer) {
+	// If the following call panics, it indicates UnimplementedMinerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MinerService_ServiceDesc, srv)
+}
+
+func _MinerService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetBalance(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetCurrent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetCurrent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetCurrent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetCurrent(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetDaily_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetDaily(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetDaily_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetDaily(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetStats(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetWorkerCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetWorkerCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetWorkerCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetWorkerCount(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetWorkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetWorkers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetWorkers(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetChart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetChart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetChart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetChart(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PagedMinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetPayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetPayments(ctx, req.(*PagedMinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetPaymentCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetPaymentCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetPaymentCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetPaymentCount(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetPaymentChart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetPaymentChart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetPaymentChart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetPaymentChart(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PagedMinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetBlocks(ctx, req.(*PagedMinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetBlockCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetBlockCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetBlockCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetBlockCount(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetDetails(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetEstimatedDailyRevenue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetEstimatedDailyRevenue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetEstimatedDailyRevenue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetEstimatedDailyRevenue(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetRoundShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetRoundShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetRoundShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetRoundShare(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetTotalPaid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetTotalPaid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetTotalPaid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetTotalPaid(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_GetTotalDonated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MinerServiceServer).GetTotalDonated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MinerService_GetTotalDonated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MinerServiceServer).GetTotalDonated(ctx, req.(*MinerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MinerService_WatchWorkers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MinerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MinerServiceServer).WatchWorkers(m, &grpc.GenericServerStream[MinerRequest, WorkerEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MinerService_WatchWorkersServer = grpc.ServerStreamingServer[WorkerEvent]
+
+// MinerService_ServiceDesc is the grpc.ServiceDesc for MinerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MinerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flexpool.v1.MinerService",
+	HandlerType: (*MinerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBalance",
+			Handler:    _MinerService_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetCurrent",
+			Handler:    _MinerService_GetCurrent_Handler,
+		},
+		{
+			MethodName: "GetDaily",
+			Handler:    _MinerService_GetDaily_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _MinerService_GetStats_Handler,
+		},
+		{
+			MethodName: "GetWorkerCount",
+			Handler:    _MinerService_GetWorkerCount_Handler,
+		},
+		{
+			MethodName: "GetWorkers",
+			Handler:    _MinerService_GetWorkers_Handler,
+		},
+		{
+			MethodName: "GetChart",
+			Handler:    _MinerService_GetChart_Handler,
+		},
+		{
+			MethodName: "GetPayments",
+			Handler:    _MinerService_GetPayments_Handler,
+		},
+		{
+			MethodName: "GetPaymentCount",
+			Handler:    _MinerService_GetPaymentCount_Handler,
+		},
+		{
+			MethodName: "GetPaymentChart",
+			Handler:    _MinerService_GetPaymentChart_Handler,
+		},
+		{
+			MethodName: "GetBlocks",
+			Handler:    _MinerService_GetBlocks_Handler,
+		},
+		{
+			MethodName: "GetBlockCount",
+			Handler:    _MinerService_GetBlockCount_Handler,
+		},
+		{
+			MethodName: "GetDetails",
+			Handler:    _MinerService_GetDetails_Handler,
+		},
+		{
+			MethodName: "GetEstimatedDailyRevenue",
+			Handler:    _MinerService_GetEstimatedDailyRevenue_Handler,
+		},
+		{
+			MethodName: "GetRoundShare",
+			Handler:    _MinerService_GetRoundShare_Handler,
+		},
+		{
+			MethodName: "GetTotalPaid",
+			Handler:    _MinerService_GetTotalPaid_Handler,
+		},
+		{
+			MethodName: "GetTotalDonated",
+			Handler:    _MinerService_GetTotalDonated_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchWorkers",
+			Handler:       _MinerService_WatchWorkers_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "flexpool.proto",
+}
+
+const (
+	WorkerService_GetCurrent_FullMethodName = "/flexpool.v1.WorkerService/GetCurrent"
+	WorkerService_GetDaily_FullMethodName   = "/flexpool.v1.WorkerService/GetDaily"
+	WorkerService_GetStats_FullMethodName   = "/flexpool.v1.WorkerService/GetStats"
+	WorkerService_GetChart_FullMethodName   = "/flexpool.v1.WorkerService/GetChart"
+)
+
+// WorkerServiceClient is the client API for WorkerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// WorkerService wraps the /worker/{address}/{worker} endpoints.
+type WorkerServiceClient interface {
+	GetCurrent(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*CurrentStats, error)
+	GetDaily(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerDailyStats, error)
+	GetStats(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerStats, error)
+	GetChart(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerChart, error)
+}
+
+type workerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkerServiceClient(cc grpc.ClientConnInterface) WorkerServiceClient {
+	return &workerServiceClient{cc}
+}
+
+func (c *workerServiceClient) GetCurrent(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*CurrentStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CurrentStats)
+	err := c.cc.Invoke(ctx, WorkerService_GetCurrent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) GetDaily(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerDailyStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerDailyStats)
+	err := c.cc.Invoke(ctx, WorkerService_GetDaily_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) GetStats(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerStats)
+	err := c.cc.Invoke(ctx, WorkerService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) GetChart(ctx context.Context, in *WorkerRequest, opts ...grpc.CallOption) (*WorkerChart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerChart)
+	err := c.cc.Invoke(ctx, WorkerService_GetChart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkerServiceServer is the server API for WorkerService service.
+// All implementations must embed UnimplementedWorkerServiceServer
+// for forward compatibility.
+//
+// WorkerService wraps the /worker/{address}/{worker} endpoints.
+type WorkerServiceServer interface {
+	GetCurrent(context.Context, *WorkerRequest) (*CurrentStats, error)
+	GetDaily(context.Context, *WorkerRequest) (*WorkerDailyStats, error)
+	GetStats(context.Context, *WorkerRequest) (*WorkerStats, error)
+	GetChart(context.Context, *WorkerRequest) (*WorkerChart, error)
+	mustEmbedUnimplementedWorkerServiceServer()
+}
+
+// UnimplementedWorkerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWorkerServiceServer struct{}
+
+func (UnimplementedWorkerServiceServer) GetCurrent(context.Context, *WorkerRequest) (*CurrentStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCurrent not implemented")
+}
+func (UnimplementedWorkerServiceServer) GetDaily(context.Context, *WorkerRequest) (*WorkerDailyStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDaily not implemented")
+}
+func (UnimplementedWorkerServiceServer) GetStats(context.Context, *WorkerRequest) (*WorkerStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedWorkerServiceServer) GetChart(context.Context, *WorkerRequest) (*WorkerChart, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetChart not implemented")
+}
+func (UnimplementedWorkerServiceServer) mustEmbedUnimplementedWorkerServiceServer() {}
+func (UnimplementedWorkerServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeWorkerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorkerServiceServer will
+// result in compilation errors.
+type UnsafeWorkerServiceServer interface {
+	mustEmbedUnimplementedWorkerServiceServer()
+}
+
+func RegisterWorkerServiceServer(s grpc.ServiceRegistrar, srv WorkerServiceServer) {
+	// If the following call panics, it indicates UnimplementedWorkerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WorkerService_ServiceDesc, srv)
+}
+
+func _WorkerService_GetCurrent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).GetCurrent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_GetCurrent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).GetCurrent(ctx, req.(*WorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_GetDaily_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).GetDaily(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_GetDaily_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).GetDaily(ctx, req.(*WorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).GetStats(ctx, req.(*WorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_GetChart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).GetChart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_GetChart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).GetChart(ctx, req.(*WorkerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WorkerService_ServiceDesc is the grpc.ServiceDesc for WorkerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WorkerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flexpool.v1.WorkerService",
+	HandlerType: (*WorkerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCurrent",
+			Handler:    _WorkerService_GetCurrent_Handler,
+		},
+		{
+			MethodName: "GetDaily",
+			Handler:    _WorkerService_GetDaily_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _WorkerService_GetStats_Handler,
+		},
+		{
+			MethodName: "GetChart",
+			Handler:    _WorkerService_GetChart_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "flexpool.proto",
+}
+
+const (
+	PoolService_GetHashrate_FullMethodName             = "/flexpool.v1.PoolService/GetHashrate"
+	PoolService_GetHashrateChart_FullMethodName        = "/flexpool.v1.PoolService/GetHashrateChart"
+	PoolService_GetMinersOnline_FullMethodName         = "/flexpool.v1.PoolService/GetMinersOnline"
+	PoolService_GetWorkersOnline_FullMethodName        = "/flexpool.v1.PoolService/GetWorkersOnline"
+	PoolService_GetBlocks_FullMethodName               = "/flexpool.v1.PoolService/GetBlocks"
+	PoolService_GetBlockCount_FullMethodName           = "/flexpool.v1.PoolService/GetBlockCount"
+	PoolService_GetTopMiners_FullMethodName            = "/flexpool.v1.PoolService/GetTopMiners"
+	PoolService_GetTopDonators_FullMethodName          = "/flexpool.v1.PoolService/GetTopDonators"
+	PoolService_GetAverageLuckRoundTime_FullMethodName = "/flexpool.v1.PoolService/GetAverageLuckRoundTime"
+	PoolService_GetCurrentLuck_FullMethodName          = "/flexpool.v1.PoolService/GetCurrentLuck"
+	PoolService_GetAverageBlockReward_FullMethodName   = "/flexpool.v1.PoolService/GetAverageBlockReward"
+	PoolService_WatchBlocks_FullMethodName             = "/flexpool.v1.PoolService/WatchBlocks"
+)
+
+// PoolServiceClient is the client API for PoolService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PoolService wraps the /pool endpoints.
+type PoolServiceClient interface {
+	GetHashrate(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*PoolHashrate, error)
+	GetHashrateChart(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*PoolHashrateChart, error)
+	GetMinersOnline(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*Count, error)
+	GetWorkersOnline(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*Count, error)
+	GetBlocks(ctx context.Context, in *PagedPoolRequest, opts ...grpc.CallOption) (*BlockPage, error)
+	GetBlockCount(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*BlockCount, error)
+	GetTopMiners(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*TopMiners, error)
+	GetTopDonators(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*TopDonators, error)
+	GetAverageLuckRoundTime(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*LuckRoundTime, error)
+	GetCurrentLuck(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*Luck, error)
+	GetAverageBlockReward(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*Amount, error)
+	// WatchBlocks sends each block the pool finds from when the stream starts.
+	WatchBlocks(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Block], error)
+}
+
+type poolServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPoolServiceClient(cc grpc.ClientConnInterface) PoolServiceClient {
+	return &poolServiceClient{cc}
+}
+
+func (c *poolServiceClient) GetHashrate(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*PoolHashrate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PoolHashrate)
+	err := c.cc.Invoke(ctx, PoolService_GetHashrate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetHashrateChart(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*PoolHashrateChart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PoolHashrateChart)
+	err := c.cc.Invoke(ctx, PoolService_GetHashrateChart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetMinersOnline(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*Count, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Count)
+	err := c.cc.Invoke(ctx, PoolService_GetMinersOnline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetWorkersOnline(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*Count, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Count)
+	err := c.cc.Invoke(ctx, PoolService_GetWorkersOnline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetBlocks(ctx context.Context, in *PagedPoolRequest, opts ...grpc.CallOption) (*BlockPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockPage)
+	err := c.cc.Invoke(ctx, PoolService_GetBlocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetBlockCount(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*BlockCount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockCount)
+	err := c.cc.Invoke(ctx, PoolService_GetBlockCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetTopMiners(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*TopMiners, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopMiners)
+	err := c.cc.Invoke(ctx, PoolService_GetTopMiners_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetTopDonators(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*TopDonators, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopDonators)
+	err := c.cc.Invoke(ctx, PoolService_GetTopDonators_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetAverageLuckRoundTime(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*LuckRoundTime, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LuckRoundTime)
+	err := c.cc.Invoke(ctx, PoolService_GetAverageLuckRoundTime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetCurrentLuck(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*Luck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Luck)
+	err := c.cc.Invoke(ctx, PoolService_GetCurrentLuck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) GetAverageBlockReward(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (*Amount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Amount)
+	err := c.cc.Invoke(ctx, PoolService_GetAverageBlockReward_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poolServiceClient) WatchBlocks(ctx context.Context, in *PoolRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Block], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PoolService_ServiceDesc.Streams[0], PoolService_WatchBlocks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PoolRequest, Block]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PoolService_WatchBlocksClient = grpc.ServerStreamingClient[Block]
+
+// PoolServiceServer is the server API for PoolService service.
+// All implementations must embed UnimplementedPoolServiceServer
+// for forward compatibility.
+//
+// PoolService wraps the /pool endpoints.
+type PoolServiceServer interface {
+	GetHashrate(context.Context, *PoolRequest) (*PoolHashrate, error)
+	GetHashrateChart(context.Context, *PoolRequest) (*PoolHashrateChart, error)
+	GetMinersOnline(context.Context, *PoolRequest) (*Count, error)
+	GetWorkersOnline(context.Context, *PoolRequest) (*Count, error)
+	GetBlocks(context.Context, *PagedPoolRequest) (*BlockPage, error)
+	GetBlockCount(context.Context, *PoolRequest) (*BlockCount, error)
+	GetTopMiners(context.Context, *PoolRequest) (*TopMiners, error)
+	GetTopDonators(context.Context, *PoolRequest) (*TopDonators, error)
+	GetAverageLuckRoundTime(context.Context, *PoolRequest) (*LuckRoundTime, error)
+	GetCurrentLuck(context.Context, *PoolRequest) (*Luck, error)
+	GetAverageBlockReward(context.Context, *PoolRequest) (*Amount, error)
+	// WatchBlocks sends each block the pool finds from when the stream starts.
+	WatchBlocks(*PoolRequest, grpc.ServerStreamingServer[Block]) error
+	mustEmbedUnimplementedPoolServiceServer()
+}
+
+// UnimplementedPoolServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPoolServiceServer struct{}
+
+func (UnimplementedPoolServiceServer) GetHashrate(context.Context, *PoolRequest) (*PoolHashrate, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHashrate not implemented")
+}
+func (UnimplementedPoolServiceServer) GetHashrateChart(context.Context, *PoolRequest) (*PoolHashrateChart, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHashrateChart not implemented")
+}
+func (UnimplementedPoolServiceServer) GetMinersOnline(context.Context, *PoolRequest) (*Count, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMinersOnline not implemented")
+}
+func (UnimplementedPoolServiceServer) GetWorkersOnline(context.Context, *PoolRequest) (*Count, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWorkersOnline not implemented")
+}
+func (UnimplementedPoolServiceServer) GetBlocks(context.Context, *PagedPoolRequest) (*BlockPage, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlocks not implemented")
+}
+func (UnimplementedPoolServiceServer) GetBlockCount(context.Context, *PoolRequest) (*BlockCount, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlockCount not implemented")
+}
+func (UnimplementedPoolServiceServer) GetTopMiners(context.Context, *PoolRequest) (*TopMiners, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTopMiners not implemented")
+}
+func (UnimplementedPoolServiceServer) GetTopDonators(context.Context, *PoolRequest) (*TopDonators, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTopDonators not implemented")
+}
+func (UnimplementedPoolServiceServer) GetAverageLuckRoundTime(context.Context, *PoolRequest) (*LuckRoundTime, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAverageLuckRoundTime not implemented")
+}
+func (UnimplementedPoolServiceServer) GetCurrentLuck(context.Context, *PoolRequest) (*Luck, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCurrentLuck not implemented")
+}
+func (UnimplementedPoolServiceServer) GetAverageBlockReward(context.Context, *PoolRequest) (*Amount, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAverageBlockReward not implemented")
+}
+func (UnimplementedPoolServiceServer) WatchBlocks(*PoolRequest, grpc.ServerStreamingServer[Block]) error {
+	return status.Error(codes.Unimplemented, "method WatchBlocks not implemented")
+}
+func (UnimplementedPoolServiceServer) mustEmbedUnimplementedPoolServiceServer() {}
+func (UnimplementedPoolServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafePoolServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PoolServiceServer will
+// result in compilation errors.
+type UnsafePoolServiceServer interface {
+	mustEmbedUnimplementedPoolServiceServer()
+}
+
+func RegisterPoolServiceServer(s grpc.ServiceRegistrar, srv PoolServiceServer) {
+	// If the following call panics, it indicates UnimplementedPoolServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PoolService_ServiceDesc, srv)
+}
+
+func _PoolService_GetHashrate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetHashrate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetHashrate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetHashrate(ctx, req.(*PoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetHashrateChart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetHashrateChart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetHashrateChart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetHashrateChart(ctx, req.(*PoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetMinersOnline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetMinersOnline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetMinersOnline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetMinersOnline(ctx, req.(*PoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetWorkersOnline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetWorkersOnline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetWorkersOnline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetWorkersOnline(ctx, req.(*PoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PagedPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetBlocks(ctx, req.(*PagedPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetBlockCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetBlockCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetBlockCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetBlockCount(ctx, req.(*PoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetTopMiners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetTopMiners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetTopMiners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetTopMiners(ctx, req.(*PoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetTopDonators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetTopDonators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetTopDonators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetTopDonators(ctx, req.(*PoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetAverageLuckRoundTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetAverageLuckRoundTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetAverageLuckRoundTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetAverageLuckRoundTime(ctx, req.(*PoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetCurrentLuck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetCurrentLuck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetCurrentLuck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetCurrentLuck(ctx, req.(*PoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_GetAverageBlockReward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoolServiceServer).GetAverageBlockReward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoolService_GetAverageBlockReward_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoolServiceServer).GetAverageBlockReward(ctx, req.(*PoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoolService_WatchBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PoolRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PoolServiceServer).WatchBlocks(m, &grpc.GenericServerStream[PoolRequest, Block]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PoolService_WatchBlocksServer = grpc.ServerStreamingServer[Block]
+
+// PoolService_ServiceDesc is the grpc.ServiceDesc for PoolService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PoolService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flexpool.v1.PoolService",
+	HandlerType: (*PoolServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHashrate",
+			Handler:    _PoolService_GetHashrate_Handler,
+		},
+		{
+			MethodName: "GetHashrateChart",
+			Handler:    _PoolService_GetHashrateChart_Handler,
+		},
+		{
+			MethodName: "GetMinersOnline",
+			Handler:    _PoolService_GetMinersOnline_Handler,
+		},
+		{
+			MethodName: "GetWorkersOnline",
+			Handler:    _PoolService_GetWorkersOnline_Handler,
+		},
+		{
+			MethodName: "GetBlocks",
+			Handler:    _PoolService_GetBlocks_Handler,
+		},
+		{
+			MethodName: "GetBlockCount",
+			Handler:    _PoolService_GetBlockCount_Handler,
+		},
+		{
+			MethodName: "GetTopMiners",
+			Handler:    _PoolService_GetTopMiners_Handler,
+		},
+		{
+			MethodName: "GetTopDonators",
+			Handler:    _PoolService_GetTopDonators_Handler,
+		},
+		{
+			MethodName: "GetAverageLuckRoundTime",
+			Handler:    _PoolService_GetAverageLuckRoundTime_Handler,
+		},
+		{
+			MethodName: "GetCurrentLuck",
+			Handler:    _PoolService_GetCurrentLuck_Handler,
+		},
+		{
+			MethodName: "GetAverageBlockReward",
+			Handler:    _PoolService_GetAverageBlockReward_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchBlocks",
+			Handler:       _PoolService_WatchBlocks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "flexpool.proto",
+}
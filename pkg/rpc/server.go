@@ -0,0 +1,829 @@
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative flexpool.proto
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/cache"
+	"github.com/cryptogenic/goflexpool/pkg/feed"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors returned for invalid requests, as InvalidArgument statuses.
+var (
+	ErrInvalidAddress = errors.New("address must be 0x followed by 40 hex digits")
+	ErrInvalidWorker  = errors.New("worker name can't be empty")
+	ErrInvalidPage    = errors.New("page must be a non-negative integer")
+)
+
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// Options contains the settings for a Server. Responses are cached for CacheTTL, and streams poll for changes every
+// WatchInterval.
+type Options struct {
+	CacheTTL      time.Duration
+	WatchInterval time.Duration
+}
+
+// Server implements the miner, worker and pool services with the api package, sharing one cache between them.
+type Server struct {
+	cache *cache.Cache
+	hub   *feed.Hub
+}
+
+// minerServer implements MinerServiceServer.
+type minerServer struct {
+	UnimplementedMinerServiceServer
+	*Server
+}
+
+// workerServer implements WorkerServiceServer.
+type workerServer struct {
+	UnimplementedWorkerServiceServer
+	*Server
+}
+
+// poolServer implements PoolServiceServer.
+type poolServer struct {
+	UnimplementedPoolServiceServer
+	*Server
+}
+
+// NewServer takes a set of Options and creates a Server with an empty cache.
+func NewServer(options Options) *Server {
+	return &Server{
+		cache: cache.New(options.CacheTTL),
+		hub:   feed.NewHub(feed.Options{Interval: options.WatchInterval}),
+	}
+}
+
+// Register registers the miner, worker and pool services with a gRPC server.
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	RegisterMinerServiceServer(registrar, &minerServer{Server: s})
+	RegisterWorkerServiceServer(registrar, &workerServer{Server: s})
+	RegisterPoolServiceServer(registrar, &poolServer{Server: s})
+}
+
+// Purge removes expired responses from the server's cache.
+func (s *Server) Purge() {
+	s.cache.Purge()
+}
+
+// Close ends every open stream.
+func (s *Server) Close() {
+	s.hub.Close()
+}
+
+// fetch returns the cached value for key, fetching it if needed. Returns the value and nil on success, or nil and the
+// status from apiStatus on failure.
+func (s *Server) fetch(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	value, err := s.cache.Get(key, fetch)
+	if err != nil {
+		return nil, apiStatus(err)
+	}
+
+	return value, nil
+}
+
+// apiStatus takes an error from the api package, and returns it as a gRPC status. Errors the API answered with below 500
+// are the caller's fault, so a 404 is NotFound and the rest are InvalidArgument. Transport errors and 5xx responses are
+// Unavailable.
+func apiStatus(err error) error {
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	// Errors reported in the body of a successful response carry their code there
+	code := apiErr.StatusCode
+	if code < 400 && apiErr.Code != 0 {
+		code = apiErr.Code
+	}
+
+	switch {
+	case code == http.StatusNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case code < 500:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+// miner validates an address and returns the cached value of one of its endpoints.
+func (s *Server) miner(address string, method string, fetch func() (interface{}, error)) (interface{}, error) {
+	if !addressPattern.MatchString(address) {
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidAddress.Error())
+	}
+
+	return s.fetch("miner/"+address+"/"+method, fetch)
+}
+
+// minerPage validates an address and page, and returns the cached value of one of its paged endpoints.
+func (s *Server) minerPage(address string, method string, page int64, fetch func() (interface{}, error)) (interface{}, error) {
+	if page < 0 {
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidPage.Error())
+	}
+
+	return s.miner(address, method+"?page="+strconv.FormatInt(page, 10), fetch)
+}
+
+// worker validates an address and worker name, and returns the cached value of one of the worker's endpoints.
+func (s *Server) worker(address string, worker string, method string, fetch func() (interface{}, error)) (interface{}, error) {
+	if worker == "" {
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidWorker.Error())
+	}
+
+	return s.miner(address, "worker/"+worker+"/"+method, fetch)
+}
+
+// GetBalance gets a miner's balance in gwei.
+func (m *minerServer) GetBalance(ctx context.Context, req *MinerRequest) (*Amount, error) {
+	value, err := m.miner(req.GetAddress(), "balance", func() (interface{}, error) {
+		return api.MinerGetBalance(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Amount{Gwei: uint64(value.(uint))}, nil
+}
+
+// GetCurrent gets a miner's current effective and reported hashrate.
+func (m *minerServer) GetCurrent(ctx context.Context, req *MinerRequest) (*CurrentStats, error) {
+	value, err := m.miner(req.GetAddress(), "current", func() (interface{}, error) {
+		return api.MinerGetCurrent(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toCurrentStats(value.(api.WorkerCurrentStats)), nil
+}
+
+// GetDaily gets a miner's daily hashrate and share stats.
+func (m *minerServer) GetDaily(ctx context.Context, req *MinerRequest) (*MinerDailyStats, error) {
+	value, err := m.miner(req.GetAddress(), "daily", func() (interface{}, error) {
+		return api.MinerGetDaily(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toMinerDailyStats(value.(api.MinerDailyStats)), nil
+}
+
+// GetStats gets a miner's current and daily stats.
+func (m *minerServer) GetStats(ctx context.Context, req *MinerRequest) (*MinerStats, error) {
+	value, err := m.miner(req.GetAddress(), "stats", func() (interface{}, error) {
+		return api.MinerGetStats(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := value.(api.MinerStats)
+
+	return &MinerStats{Current: toCurrentStats(stats.Current), Daily: toMinerDailyStats(stats.Daily)}, nil
+}
+
+// GetWorkerCount gets a miner's online and offline worker counts.
+func (m *minerServer) GetWorkerCount(ctx context.Context, req *MinerRequest) (*WorkerCount, error) {
+	value, err := m.miner(req.GetAddress(), "workerCount", func() (interface{}, error) {
+		return api.MinerGetWorkerCount(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	count := value.(api.MinerWorkerCount)
+
+	return &WorkerCount{Online: int64(count.Online), Offline: int64(count.Offline)}, nil
+}
+
+// GetWorkers gets a miner's workers.
+func (m *minerServer) GetWorkers(ctx context.Context, req *MinerRequest) (*WorkerList, error) {
+	value, err := m.miner(req.GetAddress(), "workers", func() (interface{}, error) {
+		return api.MinerGetWorkers(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := &WorkerList{}
+	for _, worker := range value.([]api.MinerWorker) {
+		list.Workers = append(list.Workers, toWorker(worker))
+	}
+
+	return list, nil
+}
+
+// GetChart gets a miner's hashrate and share chart.
+func (m *minerServer) GetChart(ctx context.Context, req *MinerRequest) (*MinerChart, error) {
+	value, err := m.miner(req.GetAddress(), "chart", func() (interface{}, error) {
+		return api.MinerGetChart(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chart := &MinerChart{}
+	for _, point := range value.([]api.MinerChartData) {
+		chart.Points = append(chart.Points, &ChartPoint{
+			Timestamp:                uint64(point.Timestamp),
+			EffectiveHashrate:        uint64(point.EffectiveHashrate),
+			AverageEffectiveHashrate: point.AverageEffectiveHashrate,
+			ReportedHashrate:         uint64(point.ReportedHashrate),
+			ValidShares:              int64(point.ValidShares),
+			StaleShares:              int64(point.StaleShares),
+			InvalidShares:            int64(point.InvalidShares),
+		})
+	}
+
+	return chart, nil
+}
+
+// GetPayments gets a page of a miner's payments.
+func (m *minerServer) GetPayments(ctx context.Context, req *PagedMinerRequest) (*PaymentPage, error) {
+	value, err := m.minerPage(req.GetAddress(), "payments", req.GetPage(), func() (interface{}, error) {
+		return api.MinerGetPayments(req.GetAddress(), int(req.GetPage()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := value.(api.MinerPaymentData)
+	page := &PaymentPage{
+		ItemsPerPage: int64(data.ItemsPerPage),
+		TotalItems:   int64(data.TotalItems),
+		TotalPages:   int64(data.TotalPages),
+	}
+
+	for _, payment := range data.Data {
+		page.Payments = append(page.Payments, toPayment(payment))
+	}
+
+	return page, nil
+}
+
+// GetPaymentCount gets the number of payments a miner has received.
+func (m *minerServer) GetPaymentCount(ctx context.Context, req *MinerRequest) (*Count, error) {
+	value, err := m.miner(req.GetAddress(), "paymentCount", func() (interface{}, error) {
+		return api.MinerGetPaymentCount(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Count{Count: int64(value.(int))}, nil
+}
+
+// GetPaymentChart gets a miner's payment chart.
+func (m *minerServer) GetPaymentChart(ctx context.Context, req *MinerRequest) (*PaymentChart, error) {
+	value, err := m.miner(req.GetAddress(), "paymentsChart", func() (interface{}, error) {
+		return api.MinerGetPaymentChart(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chart := &PaymentChart{}
+	for _, point := range value.([]api.MinerPaymentChart) {
+		chart.Points = append(chart.Points, &PaymentChartPoint{Amount: uint64(point.Amount), Timestamp: uint64(point.Timestamp)})
+	}
+
+	return chart, nil
+}
+
+// GetBlocks gets a page of the blocks a miner has found.
+func (m *minerServer) GetBlocks(ctx context.Context, req *PagedMinerRequest) (*BlockPage, error) {
+	value, err := m.minerPage(req.GetAddress(), "blocks", req.GetPage(), func() (interface{}, error) {
+		return api.MinerGetBlocks(req.GetAddress(), int(req.GetPage()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := value.(api.MinerBlockData)
+
+	return toBlockPage(data.Data, data.ItemsPerPage, data.TotalItems, data.TotalPages), nil
+}
+
+// GetBlockCount gets the number of confirmed and unconfirmed blocks a miner has found.
+func (m *minerServer) GetBlockCount(ctx context.Context, req *MinerRequest) (*BlockCount, error) {
+	value, err := m.miner(req.GetAddress(), "blockCount", func() (interface{}, error) {
+		return api.MinerGetBlockCount(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The miner endpoint only reports the total, so it's given as confirmed
+	return &BlockCount{Confirmed: int64(value.(int))}, nil
+}
+
+// GetDetails gets a miner's settings and account details.
+func (m *minerServer) GetDetails(ctx context.Context, req *MinerRequest) (*MinerDetails, error) {
+	value, err := m.miner(req.GetAddress(), "details", func() (interface{}, error) {
+		return api.MinerGetDetails(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	details := value.(api.MinerDetails)
+
+	return &MinerDetails{
+		MinPayoutThreshold: uint64(details.MinPayoutThreshold),
+		PoolDonation:       details.PoolDonation,
+		MaxFeePrice:        uint64(details.MaxFeePrice),
+		CensoredEmail:      details.CensoredEmail,
+		CensoredIp:         details.CensoredIp,
+		FirstJoined:        uint64(details.FirstJoined),
+	}, nil
+}
+
+// GetEstimatedDailyRevenue gets a miner's estimated daily revenue in gwei.
+func (m *minerServer) GetEstimatedDailyRevenue(ctx context.Context, req *MinerRequest) (*Amount, error) {
+	value, err := m.miner(req.GetAddress(), "estimatedDailyRevenue", func() (interface{}, error) {
+		return api.MinerGetEstimatedDailyRevenue(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Amount{Gwei: uint64(value.(uint))}, nil
+}
+
+// GetRoundShare gets a miner's share of the current round.
+func (m *minerServer) GetRoundShare(ctx context.Context, req *MinerRequest) (*RoundShare, error) {
+	value, err := m.miner(req.GetAddress(), "roundShare", func() (interface{}, error) {
+		return api.MinerGetRoundShare(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RoundShare{Share: value.(float64)}, nil
+}
+
+// GetTotalPaid gets the total a miner has been paid in gwei.
+func (m *minerServer) GetTotalPaid(ctx context.Context, req *MinerRequest) (*Amount, error) {
+	value, err := m.miner(req.GetAddress(), "totalPaid", func() (interface{}, error) {
+		return api.MinerGetTotalPaid(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Amount{Gwei: uint64(value.(uint))}, nil
+}
+
+// GetTotalDonated gets the total a miner has donated to the pool in gwei.
+func (m *minerServer) GetTotalDonated(ctx context.Context, req *MinerRequest) (*Amount, error) {
+	value, err := m.miner(req.GetAddress(), "totalDonated", func() (interface{}, error) {
+		return api.MinerGetTotalDonated(req.GetAddress())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Amount{Gwei: uint64(value.(uint))}, nil
+}
+
+// WatchWorkers streams a miner's worker changes until the client cancels the stream.
+func (m *minerServer) WatchWorkers(req *MinerRequest, stream grpc.ServerStreamingServer[WorkerEvent]) error {
+	if !addressPattern.MatchString(req.GetAddress()) {
+		return status.Error(codes.InvalidArgument, ErrInvalidAddress.Error())
+	}
+
+	return m.watch(stream.Context(), "miner/"+req.GetAddress()+"/workers", func(event feed.Event, first bool) error {
+		var events []*WorkerEvent
+
+		switch data := event.Data.(type) {
+		case []api.MinerWorker:
+			// Snapshots are only sent as workers when the stream starts. Later ones are replays of what was already sent.
+			if !first {
+				return nil
+			}
+
+			for _, worker := range data {
+				events = append(events, &WorkerEvent{Type: WorkerEvent_ADDED, Worker: toWorker(worker)})
+			}
+		case feed.WorkerChanges:
+			for _, worker := range data.Added {
+				events = append(events, &WorkerEvent{Type: WorkerEvent_ADDED, Worker: toWorker(worker)})
+			}
+
+			for _, worker := range data.Changed {
+				events = append(events, &WorkerEvent{Type: WorkerEvent_CHANGED, Worker: toWorker(worker)})
+			}
+
+			for _, name := range data.Removed {
+				events = append(events, &WorkerEvent{Type: WorkerEvent_REMOVED, Worker: &Worker{Name: name}})
+			}
+		}
+
+		for _, e := range events {
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetCurrent gets a worker's current effective and reported hashrate.
+func (w *workerServer) GetCurrent(ctx context.Context, req *WorkerRequest) (*CurrentStats, error) {
+	value, err := w.worker(req.GetAddress(), req.GetWorker(), "current", func() (interface{}, error) {
+		return api.WorkerGetCurrent(req.GetAddress(), req.GetWorker())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toCurrentStats(value.(api.WorkerCurrentStats)), nil
+}
+
+// GetDaily gets a worker's daily hashrate and share stats.
+func (w *workerServer) GetDaily(ctx context.Context, req *WorkerRequest) (*WorkerDailyStats, error) {
+	value, err := w.worker(req.GetAddress(), req.GetWorker(), "daily", func() (interface{}, error) {
+		return api.WorkerGetDaily(req.GetAddress(), req.GetWorker())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toWorkerDailyStats(value.(api.WorkerDailyStats)), nil
+}
+
+// GetStats gets a worker's current and daily stats.
+func (w *workerServer) GetStats(ctx context.Context, req *WorkerRequest) (*WorkerStats, error) {
+	value, err := w.worker(req.GetAddress(), req.GetWorker(), "stats", func() (interface{}, error) {
+		return api.WorkerGetStats(req.GetAddress(), req.GetWorker())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := value.(api.WorkerStats)
+
+	return &WorkerStats{Current: toCurrentStats(stats.Current), Daily: toWorkerDailyStats(stats.Daily)}, nil
+}
+
+// GetChart gets a worker's hashrate and share chart.
+func (w *workerServer) GetChart(ctx context.Context, req *WorkerRequest) (*WorkerChart, error) {
+	value, err := w.worker(req.GetAddress(), req.GetWorker(), "chart", func() (interface{}, error) {
+		return api.WorkerGetChart(req.GetAddress(), req.GetWorker())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chart := &WorkerChart{}
+	for _, point := range value.([]api.WorkerChartData) {
+		chart.Points = append(chart.Points, &ChartPoint{
+			Timestamp:                uint64(point.Timestamp),
+			EffectiveHashrate:        uint64(point.EffectiveHashrate),
+			AverageEffectiveHashrate: point.AverageEffectiveHashrate,
+			ReportedHashrate:         uint64(point.ReportedHashrate),
+			ValidShares:              int64(point.ValidShares),
+			StaleShares:              int64(point.StaleShares),
+			InvalidShares:            int64(point.InvalidShares),
+		})
+	}
+
+	return chart, nil
+}
+
+// GetHashrate gets the pool's hashrate by region.
+func (p *poolServer) GetHashrate(ctx context.Context, req *PoolRequest) (*PoolHashrate, error) {
+	value, err := p.fetch("pool/hashrate", func() (interface{}, error) {
+		return api.PoolGetHashrate()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPoolHashrate(value.(api.PoolHashrate)), nil
+}
+
+// GetHashrateChart gets the pool's hashrate chart by region.
+func (p *poolServer) GetHashrateChart(ctx context.Context, req *PoolRequest) (*PoolHashrateChart, error) {
+	value, err := p.fetch("pool/hashrateChart", func() (interface{}, error) {
+		return api.PoolGetHashrateChart()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chart := &PoolHashrateChart{}
+	for _, point := range value.([]api.PoolHashrateChartData) {
+		chart.Points = append(chart.Points, &PoolHashrateChartPoint{
+			Timestamp: uint64(point.Timestamp),
+			Hashrate: toPoolHashrate(api.PoolHashrate{
+				As: point.As, Au: point.Au, Eu: point.Eu, Sa: point.Sa, Us: point.Us, Total: point.Total,
+			}),
+		})
+	}
+
+	return chart, nil
+}
+
+// GetMinersOnline gets the number of miners online.
+func (p *poolServer) GetMinersOnline(ctx context.Context, req *PoolRequest) (*Count, error) {
+	value, err := p.fetch("pool/minersOnline", func() (interface{}, error) {
+		return api.PoolGetMinersOnline()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Count{Count: int64(value.(int))}, nil
+}
+
+// GetWorkersOnline gets the number of workers online.
+func (p *poolServer) GetWorkersOnline(ctx context.Context, req *PoolRequest) (*Count, error) {
+	value, err := p.fetch("pool/workersOnline", func() (interface{}, error) {
+		return api.PoolGetWorkersOnline()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Count{Count: int64(value.(int))}, nil
+}
+
+// GetBlocks gets a page of the pool's blocks.
+func (p *poolServer) GetBlocks(ctx context.Context, req *PagedPoolRequest) (*BlockPage, error) {
+	if req.GetPage() < 0 {
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidPage.Error())
+	}
+
+	value, err := p.fetch("pool/blocks?page="+strconv.FormatInt(req.GetPage(), 10), func() (interface{}, error) {
+		return api.PoolGetBlocks(int(req.GetPage()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := value.(api.PoolBlockData)
+
+	return toBlockPage(data.Data, data.ItemsPerPage, data.TotalItems, data.TotalPages), nil
+}
+
+// GetBlockCount gets the number of confirmed and unconfirmed blocks the pool has found.
+func (p *poolServer) GetBlockCount(ctx context.Context, req *PoolRequest) (*BlockCount, error) {
+	value, err := p.fetch("pool/blockCount", func() (interface{}, error) {
+		return api.PoolGetBlockCount()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	count := value.(api.PoolBlockCount)
+
+	return &BlockCount{Confirmed: int64(count.Confirmed), Unconfirmed: int64(count.Unconfirmed)}, nil
+}
+
+// GetTopMiners gets the pool's top miners by hashrate.
+func (p *poolServer) GetTopMiners(ctx context.Context, req *PoolRequest) (*TopMiners, error) {
+	value, err := p.fetch("pool/topMiners", func() (interface{}, error) {
+		return api.PoolGetTopMiners()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	miners := &TopMiners{}
+	for _, miner := range value.([]api.PoolMinerInfo) {
+		miners.Miners = append(miners.Miners, &TopMiner{
+			Address:      miner.Address,
+			Hashrate:     uint64(miner.Hashrate),
+			TotalWorkers: int64(miner.TotalWorkers),
+			Balance:      uint64(miner.Balance),
+			PoolDonation: miner.PoolDonation,
+			FirstJoined:  uint64(miner.FirstJoined),
+		})
+	}
+
+	return miners, nil
+}
+
+// GetTopDonators gets the pool's top donators.
+func (p *poolServer) GetTopDonators(ctx context.Context, req *PoolRequest) (*TopDonators, error) {
+	value, err := p.fetch("pool/topDonators", func() (interface{}, error) {
+		return api.PoolGetTopDonators()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	donators := &TopDonators{}
+	for _, donator := range value.([]api.PoolDonatorInfo) {
+		donators.Donators = append(donators.Donators, &TopDonator{
+			Address:      donator.Address,
+			PoolDonation: donator.PoolDonation,
+			TotalDonated: uint64(donator.TotalDonated),
+			FirstJoined:  uint64(donator.FirstJoined),
+		})
+	}
+
+	return donators, nil
+}
+
+// GetAverageLuckRoundTime gets the pool's average luck and round time.
+func (p *poolServer) GetAverageLuckRoundTime(ctx context.Context, req *PoolRequest) (*LuckRoundTime, error) {
+	value, err := p.fetch("pool/avgLuckRoundtime", func() (interface{}, error) {
+		return api.PoolGetAverageLuckRoundTime()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	luck := value.(api.PoolAvgLuckRoundTime)
+
+	return &LuckRoundTime{Luck: luck.Luck, RoundTime: luck.RoundTime}, nil
+}
+
+// GetCurrentLuck gets the luck of the pool's current round.
+func (p *poolServer) GetCurrentLuck(ctx context.Context, req *PoolRequest) (*Luck, error) {
+	value, err := p.fetch("pool/currentLuck", func() (interface{}, error) {
+		return api.PoolGetCurrentLuck()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Luck{Luck: value.(float64)}, nil
+}
+
+// GetAverageBlockReward gets the pool's average block reward in gwei.
+func (p *poolServer) GetAverageBlockReward(ctx context.Context, req *PoolRequest) (*Amount, error) {
+	value, err := p.fetch("pool/averageBlockReward", func() (interface{}, error) {
+		return api.PoolGetAverageBlockReward()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Amount{Gwei: uint64(value.(uint))}, nil
+}
+
+// WatchBlocks streams the blocks the pool finds until the client cancels the stream.
+func (p *poolServer) WatchBlocks(req *PoolRequest, stream grpc.ServerStreamingServer[Block]) error {
+	return p.watch(stream.Context(), "pool/blocks", func(event feed.Event, first bool) error {
+		// Snapshots are blocks found before the stream started, so only updates are sent
+		if event.Type != feed.Update {
+			return nil
+		}
+
+		for _, block := range event.Data.([]api.Block) {
+			if err := stream.Send(toBlock(block)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// watch subscribes to a feed topic and passes its events to send until the context is done, send fails or the
+// subscription ends. first is set for the first event received. Returns nil when the context is done, or error
+// otherwise.
+func (s *Server) watch(ctx context.Context, topic string, send func(event feed.Event, first bool) error) error {
+	sub, err := s.hub.Subscribe(topic)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	defer sub.Close()
+
+	first := true
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				if sub.Err() == feed.ErrSlowConsumer {
+					return status.Error(codes.ResourceExhausted, sub.Err().Error())
+				}
+
+				return status.Error(codes.Unavailable, "server is shutting down")
+			}
+
+			if err := send(event, first); err != nil {
+				return err
+			}
+
+			first = false
+		}
+	}
+}
+
+// toCurrentStats converts api.WorkerCurrentStats to a CurrentStats message.
+func toCurrentStats(stats api.WorkerCurrentStats) *CurrentStats {
+	return &CurrentStats{EffectiveHashrate: uint64(stats.EffectiveHashrate), ReportedHashrate: uint64(stats.ReportedHashrate)}
+}
+
+// toMinerDailyStats converts api.MinerDailyStats to a MinerDailyStats message.
+func toMinerDailyStats(stats api.MinerDailyStats) *MinerDailyStats {
+	return &MinerDailyStats{
+		EffectiveHashrate: stats.EffectiveHashrate,
+		ReportedHashrate:  stats.ReportedHashrate,
+		ValidShares:       int64(stats.ValidShares),
+		StaleShares:       int64(stats.StaleShares),
+		InvalidShares:     int64(stats.InvalidShares),
+	}
+}
+
+// toWorkerDailyStats converts api.WorkerDailyStats to a WorkerDailyStats message.
+func toWorkerDailyStats(stats api.WorkerDailyStats) *WorkerDailyStats {
+	return &WorkerDailyStats{
+		EffectiveHashrate: uint64(stats.EffectiveHashrate),
+		ReportedHashrate:  uint64(stats.ReportedHashrate),
+		ValidShares:       int64(stats.ValidShares),
+		StaleShares:       int64(stats.StaleShares),
+		InvalidShares:     int64(stats.InvalidShares),
+	}
+}
+
+// toWorker converts an api.MinerWorker to a Worker message.
+func toWorker(worker api.MinerWorker) *Worker {
+	return &Worker{
+		Name:                   worker.Name,
+		Online:                 worker.Online,
+		DuplicateWorkersMerged: int64(worker.DuplicateWorkersMerged),
+		ReportedHashrate:       uint64(worker.ReportedHashrate),
+		EffectiveHashrate:      uint64(worker.EffectiveHashrate),
+		ValidShares:            int64(worker.ValidShares),
+		StaleShares:            int64(worker.StaleShares),
+		InvalidShares:          int64(worker.InvalidShares),
+		LastSeen:               int64(worker.LastSeen),
+	}
+}
+
+// toPayment converts an api.MinerPayment to a Payment message.
+func toPayment(payment api.MinerPayment) *Payment {
+	return &Payment{
+		Txid:      payment.Txid,
+		Amount:    uint64(payment.Amount),
+		Timestamp: uint64(payment.Timestamp),
+		Duration:  uint64(payment.Duration),
+	}
+}
+
+// toBlock converts an api.Block to a Block message.
+func toBlock(block api.Block) *Block {
+	return &Block{
+		Hash:                  block.Hash,
+		Number:                uint64(block.Number),
+		Type:                  block.Type,
+		Miner:                 block.Miner,
+		Difficulty:            uint64(block.Difficulty),
+		Timestamp:             uint64(block.Timestamp),
+		Confirmed:             block.Confirmed,
+		RoundTime:             uint64(block.RoundTime),
+		Luck:                  block.Luck,
+		ServerName:            block.ServerName,
+		BlockReward:           uint64(block.BlockReward),
+		BlockFees:             uint64(block.BlockFees),
+		UncleInclusionRewards: uint64(block.UncleInclusionRewards),
+		TotalRewards:          uint64(block.TotalRewards),
+	}
+}
+
+// toBlockPage converts a page of api.Blocks to a BlockPage message.
+func toBlockPage(blocks []api.Block, itemsPerPage int, totalItems int, totalPages int) *BlockPage {
+	page := &BlockPage{ItemsPerPage: int64(itemsPerPage), TotalItems: int64(totalItems), TotalPages: int64(totalPages)}
+
+	for _, block := range blocks {
+		page.Blocks = append(page.Blocks, toBlock(block))
+	}
+
+	return page
+}
+
+// toPoolHashrate converts an api.PoolHashrate to a PoolHashrate message.
+func toPoolHashrate(hashrate api.PoolHashrate) *PoolHashrate {
+	return &PoolHashrate{
+		As:    uint64(hashrate.As),
+		Au:    uint64(hashrate.Au),
+		Eu:    uint64(hashrate.Eu),
+		Sa:    uint64(hashrate.Sa),
+		Us:    uint64(hashrate.Us),
+		Total: uint64(hashrate.Total),
+	}
+}
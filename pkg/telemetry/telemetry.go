@@ -0,0 +1,143 @@
+package telemetry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifies the spans and metrics recorded by this package.
+const InstrumentationName = "github.com/cryptogenic/goflexpool/pkg/telemetry"
+
+// Attribute keys set on spans and metrics.
+const (
+	EndpointKey    = attribute.Key("flexpool.endpoint")
+	MethodKey      = attribute.Key("flexpool.method")
+	AddressHashKey = attribute.Key("flexpool.address_hash")
+	PageKey        = attribute.Key("flexpool.page")
+	ErrorCodeKey   = attribute.Key("flexpool.error.code")
+	StatusCodeKey  = attribute.Key("http.response.status_code")
+)
+
+// Options contains the providers used for tracing and metrics. Either can be nil to use the global provider, which does
+// nothing until one is registered with otel.SetTracerProvider or otel.SetMeterProvider.
+type Options struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+}
+
+// Middleware takes a set of Options and returns api middleware that records a span for every API call, along with a
+// latency histogram and an error counter. Add it to a client with api.DefaultClient.Use. Spans are children of the span
+// in the request's context, so calls made through Client.WithContext nest under the caller's trace. Returns the middleware and nil
+// on success, or nil and error if the metric instruments can't be created.
+func Middleware(options Options) (api.Middleware, error) {
+	if options.TracerProvider == nil {
+		options.TracerProvider = otel.GetTracerProvider()
+	}
+
+	if options.MeterProvider == nil {
+		options.MeterProvider = otel.GetMeterProvider()
+	}
+
+	tracer := options.TracerProvider.Tracer(InstrumentationName)
+	meter := options.MeterProvider.Meter(InstrumentationName)
+
+	duration, err := meter.Float64Histogram("flexpool.client.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of flexpool API calls"))
+	if err != nil {
+		return nil, err
+	}
+
+	errorCount, err := meter.Int64Counter("flexpool.client.errors",
+		metric.WithDescription("Failed flexpool API calls, by API error code when the API returned one"))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next api.Handler) api.Handler {
+		return func(call *api.Call) error {
+			method := callMethod(call)
+			attributes := callAttributes(call, method)
+
+			ctx, span := tracer.Start(call.Request.Context(), "flexpool."+call.Endpoint.String()+"."+method,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attributes...))
+			defer span.End()
+
+			// Pass the span on in the request's context, so instrumented transports can link to it
+			call.Request = call.Request.WithContext(ctx)
+
+			start := time.Now()
+			err := next(call)
+			elapsed := time.Since(start).Seconds()
+
+			if call.Response != nil {
+				span.SetAttributes(StatusCodeKey.Int(call.Response.StatusCode))
+			}
+
+			metricAttributes := metric.WithAttributes(attributes[0], attributes[1])
+			duration.Record(ctx, elapsed, metricAttributes)
+
+			// The API's error code is recorded whenever the response carries one, even if the call also returned an error
+			switch {
+			case call.Result.Error.Code != 0:
+				span.SetAttributes(ErrorCodeKey.Int(call.Result.Error.Code))
+				span.SetStatus(codes.Error, call.Result.Error.Message)
+				errorCount.Add(ctx, 1, metricAttributes, metric.WithAttributes(ErrorCodeKey.Int(call.Result.Error.Code)))
+			case err != nil:
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				errorCount.Add(ctx, 1, metricAttributes)
+			}
+
+			return err
+		}
+	}, nil
+}
+
+// callMethod returns the API method a call is for. The worker endpoint's method comes after the worker name, so it's
+// taken from the params.
+func callMethod(call *api.Call) string {
+	if call.Endpoint == api.Worker && len(call.Params) > 0 {
+		return call.Params[0]
+	}
+
+	return call.Method
+}
+
+// callAttributes takes a call and its method, and returns its endpoint and method attributes, followed by its hashed
+// address and page if it has them.
+func callAttributes(call *api.Call, method string) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{EndpointKey.String(call.Endpoint.String()), MethodKey.String(method)}
+
+	if call.Endpoint != api.Pool && call.Query != "" {
+		attributes = append(attributes, AddressHashKey.String(HashAddress(call.Query)))
+	}
+
+	for _, param := range call.Params {
+		if strings.HasPrefix(param, "page=") {
+			if page, err := strconv.Atoi(strings.TrimPrefix(param, "page=")); err == nil {
+				attributes = append(attributes, PageKey.Int(page))
+			}
+		}
+	}
+
+	return attributes
+}
+
+// HashAddress takes a wallet address and returns a short, stable hash of it, so traces can be grouped by address
+// without recording the address itself. Addresses are hashed case-insensitively.
+func HashAddress(address string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(address)))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,285 @@
+package blocks
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+// Block types, as returned in api.Block's Type.
+const (
+	TypeBlock = "block"
+	TypeUncle = "uncle"
+)
+
+// Sort keys accepted by Sort.
+const (
+	SortNumber    = "number"
+	SortLuck      = "luck"
+	SortReward    = "reward"
+	SortRoundTime = "round-time"
+)
+
+// AllServers is the server name of the Stats row covering every server.
+const AllServers = "all"
+
+// Errors returned for invalid input.
+var (
+	ErrInvalidType    = errors.New("block type must be block or uncle")
+	ErrInvalidSortKey = errors.New("sort key must be number, luck, reward or round-time")
+)
+
+// PageFunc fetches a page of blocks, returning the page's blocks and the total number of pages.
+type PageFunc func(page int) ([]api.Block, int, error)
+
+// Filter selects blocks. Zero values match every block, so an empty Filter matches them all. Confirmed only matches
+// blocks with that confirmation status when it's set. Since and Until bound the block timestamp, and MinNumber and
+// MaxNumber the block number, inclusively. ServerName and Miner are compared case-insensitively.
+type Filter struct {
+	Type       string
+	Confirmed  *bool
+	ServerName string
+	Miner      string
+	Since      time.Time
+	Until      time.Time
+	MinNumber  uint
+	MaxNumber  uint
+}
+
+// Stats contains aggregated stats of a set of blocks mined through one server. Rewards are in gwei, and round times in
+// seconds. RewardShare, FeeShare and UncleInclusionShare break the total rewards down into the share that came from
+// block rewards, fees and uncle inclusion rewards, and UncleRate is the share of blocks that were uncles.
+type Stats struct {
+	ServerName            string  `json:"server_name"`
+	Blocks                int     `json:"blocks"`
+	Uncles                int     `json:"uncles"`
+	Confirmed             int     `json:"confirmed"`
+	Unconfirmed           int     `json:"unconfirmed"`
+	TotalRewards          uint    `json:"total_rewards"`
+	BlockRewards          uint    `json:"block_rewards"`
+	BlockFees             uint    `json:"block_fees"`
+	UncleInclusionRewards uint    `json:"uncle_inclusion_rewards"`
+	RewardShare           float64 `json:"reward_share"`
+	FeeShare              float64 `json:"fee_share"`
+	UncleInclusionShare   float64 `json:"uncle_inclusion_share"`
+	UncleRate             float64 `json:"uncle_rate"`
+	AverageLuck           float64 `json:"average_luck"`
+	AverageRoundTime      float64 `json:"average_round_time"`
+}
+
+// PoolPages returns a PageFunc for the pool's blocks.
+func PoolPages() PageFunc {
+	return func(page int) ([]api.Block, int, error) {
+		data, err := api.PoolGetBlocks(page)
+		return data.Data, data.TotalPages, err
+	}
+}
+
+// MinerPages takes a mining wallet address and returns a PageFunc for the blocks it mined.
+func MinerPages(address string) PageFunc {
+	return func(page int) ([]api.Block, int, error) {
+		data, err := api.MinerGetBlocks(address, page)
+		return data.Data, data.TotalPages, err
+	}
+}
+
+// Validate checks that the filter's type is valid. Returns nil if it's valid, or error otherwise.
+func (f Filter) Validate() error {
+	if f.Type != "" && f.Type != TypeBlock && f.Type != TypeUncle {
+		return ErrInvalidType
+	}
+
+	return nil
+}
+
+// Match takes a block, and returns whether the filter selects it.
+func (f Filter) Match(block api.Block) bool {
+	switch {
+	case f.Type != "" && block.Type != f.Type:
+		return false
+	case f.Confirmed != nil && block.Confirmed != *f.Confirmed:
+		return false
+	case f.ServerName != "" && !strings.EqualFold(block.ServerName, f.ServerName):
+		return false
+	case f.Miner != "" && !strings.EqualFold(block.Miner, f.Miner):
+		return false
+	case !f.Since.IsZero() && int64(block.Timestamp) < f.Since.Unix():
+		return false
+	case !f.Until.IsZero() && int64(block.Timestamp) > f.Until.Unix():
+		return false
+	case f.MinNumber != 0 && block.Number < f.MinNumber:
+		return false
+	case f.MaxNumber != 0 && block.Number > f.MaxNumber:
+		return false
+	}
+
+	return true
+}
+
+// Select takes a slice of blocks and a filter, and returns the blocks the filter matches in their original order.
+func Select(blocks []api.Block, filter Filter) []api.Block {
+	selected := make([]api.Block, 0, len(blocks))
+
+	for _, block := range blocks {
+		if filter.Match(block) {
+			selected = append(selected, block)
+		}
+	}
+
+	return selected
+}
+
+// Fetch takes a PageFunc, the maximum number of pages to fetch and a filter, and pages through blocks from the newest,
+// keeping the ones the filter matches. As pages are newest first, paging stops early once a page reaches back past
+// the filter's Since time or below its MinNumber. A maximum of 0 or less fetches every page. Returns the matching
+// blocks, which are empty rather than nil when none match, and nil on success, or nil and error on failure.
+func Fetch(pages PageFunc, maxPages int, filter Filter) ([]api.Block, error) {
+	selected := make([]api.Block, 0)
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	for page := 0; maxPages <= 0 || page < maxPages; page++ {
+		blocks, totalPages, err := pages(page)
+		if err != nil {
+			return nil, err
+		}
+
+		selected = append(selected, Select(blocks, filter)...)
+
+		if page+1 >= totalPages || len(blocks) == 0 {
+			break
+		}
+
+		oldest := blocks[len(blocks)-1]
+
+		if !filter.Since.IsZero() && int64(oldest.Timestamp) < filter.Since.Unix() {
+			break
+		}
+
+		if filter.MinNumber != 0 && oldest.Number < filter.MinNumber {
+			break
+		}
+	}
+
+	return selected, nil
+}
+
+// ValidSortKey takes a sort key, and returns whether Sort accepts it.
+func ValidSortKey(key string) bool {
+	return key == SortNumber || key == SortLuck || key == SortReward || key == SortRoundTime
+}
+
+// Sort takes a slice of blocks, a sort key and whether to sort in descending order, and sorts the blocks in place.
+// Blocks that compare equal keep their relative order. Returns nil on success, or ErrInvalidSortKey if the key isn't
+// known.
+func Sort(blocks []api.Block, key string, descending bool) error {
+	var less func(a api.Block, b api.Block) bool
+
+	switch key {
+	case SortNumber:
+		less = func(a api.Block, b api.Block) bool { return a.Number < b.Number }
+	case SortLuck:
+		less = func(a api.Block, b api.Block) bool { return a.Luck < b.Luck }
+	case SortReward:
+		less = func(a api.Block, b api.Block) bool { return a.TotalRewards < b.TotalRewards }
+	case SortRoundTime:
+		less = func(a api.Block, b api.Block) bool { return a.RoundTime < b.RoundTime }
+	default:
+		return ErrInvalidSortKey
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if descending {
+			return less(blocks[j], blocks[i])
+		}
+
+		return less(blocks[i], blocks[j])
+	})
+
+	return nil
+}
+
+// Aggregate takes a slice of blocks and returns the stats of the blocks mined through each server in name order,
+// followed by the stats of every block under AllServers.
+func Aggregate(blocks []api.Block) []Stats {
+	return aggregate(blocks, func(block api.Block) string { return block.ServerName }, AllServers)
+}
+
+// aggregate takes a slice of blocks, a function returning the group each block belongs to and the name of the row
+// covering every group, and returns the stats of each group in name order followed by that row.
+func aggregate(blocks []api.Block, group func(block api.Block) string, allName string) []Stats {
+	groups := make(map[string]*Stats)
+	all := &Stats{ServerName: allName}
+
+	var names []string
+
+	for _, block := range blocks {
+		name := group(block)
+
+		stats, ok := groups[name]
+		if !ok {
+			stats = &Stats{ServerName: name}
+			groups[name] = stats
+			names = append(names, name)
+		}
+
+		add(stats, block)
+		add(all, block)
+	}
+
+	sort.Strings(names)
+
+	aggregated := make([]Stats, 0, len(names)+1)
+
+	for _, name := range names {
+		aggregated = append(aggregated, finish(*groups[name]))
+	}
+
+	return append(aggregated, finish(*all))
+}
+
+// add adds a block to stats, leaving the averages and rates to finish. AverageLuck and AverageRoundTime hold sums
+// until then.
+func add(stats *Stats, block api.Block) {
+	stats.Blocks++
+	stats.TotalRewards += block.TotalRewards
+	stats.BlockRewards += block.BlockReward
+	stats.BlockFees += block.BlockFees
+	stats.UncleInclusionRewards += block.UncleInclusionRewards
+	stats.AverageLuck += block.Luck
+	stats.AverageRoundTime += float64(block.RoundTime)
+
+	if block.Type == TypeUncle {
+		stats.Uncles++
+	}
+
+	if block.Confirmed {
+		stats.Confirmed++
+	} else {
+		stats.Unconfirmed++
+	}
+}
+
+// finish calculates the averages and rates of stats once every block has been added.
+func finish(stats Stats) Stats {
+	if stats.Blocks == 0 {
+		return stats
+	}
+
+	stats.UncleRate = float64(stats.Uncles) / float64(stats.Blocks)
+	stats.AverageLuck /= float64(stats.Blocks)
+	stats.AverageRoundTime /= float64(stats.Blocks)
+
+	if stats.TotalRewards > 0 {
+		stats.RewardShare = float64(stats.BlockRewards) / float64(stats.TotalRewards)
+		stats.FeeShare = float64(stats.BlockFees) / float64(stats.TotalRewards)
+		stats.UncleInclusionShare = float64(stats.UncleInclusionRewards) / float64(stats.TotalRewards)
+	}
+
+	return stats
+}
@@ -0,0 +1,186 @@
+package blocks
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+// Regions the pool reports hashrate for.
+const (
+	RegionAsia         = "as"
+	RegionAustralia    = "au"
+	RegionEurope       = "eu"
+	RegionSouthAmerica = "sa"
+	RegionUS           = "us"
+)
+
+// AllRegions is the region of the RegionStats row covering every region.
+const AllRegions = "all"
+
+// RegionStats contains the aggregated stats of the blocks mined through a region's servers, alongside the region's
+// hashrate over the same period. AverageHashrate is the region's mean hashrate in hashes per second across the chart
+// points from the first block to the last, and HashrateShare its share of the pool's. BlockShare is the region's share
+// of the blocks, and Efficiency is BlockShare divided by HashrateShare - above 1, the region found more blocks than its
+// hashrate alone would suggest. Regions without hashrate data have a HashrateShare and Efficiency of 0.
+type RegionStats struct {
+	Region              string  `json:"region"`
+	Servers             string  `json:"servers"`
+	Blocks              int     `json:"blocks"`
+	Uncles              int     `json:"uncles"`
+	TotalRewards        uint    `json:"total_rewards"`
+	RewardShare         float64 `json:"reward_share"`
+	FeeShare            float64 `json:"fee_share"`
+	UncleInclusionShare float64 `json:"uncle_inclusion_share"`
+	UncleRate           float64 `json:"uncle_rate"`
+	AverageLuck         float64 `json:"average_luck"`
+	AverageRoundTime    float64 `json:"average_round_time"`
+	AverageHashrate     float64 `json:"average_hashrate"`
+	HashrateShare       float64 `json:"hashrate_share"`
+	BlockShare          float64 `json:"block_share"`
+	Efficiency          float64 `json:"efficiency"`
+}
+
+// Region takes a server name and returns the region it's in, which is its leading letters in lower case, such as "eu"
+// for "eu1".
+func Region(serverName string) string {
+	serverName = strings.ToLower(serverName)
+
+	end := 0
+	for end < len(serverName) && serverName[end] >= 'a' && serverName[end] <= 'z' {
+		end++
+	}
+
+	return serverName[:end]
+}
+
+// AggregateRegions takes a slice of blocks and the pool's hashrate chart, and returns the stats of the blocks mined
+// through each region's servers in name order, followed by the stats of every block under AllRegions.
+func AggregateRegions(blocks []api.Block, chart []api.PoolHashrateChartData) []RegionStats {
+	aggregated := aggregate(blocks, func(block api.Block) string { return Region(block.ServerName) }, AllRegions)
+	hashrates := averageHashrates(window(chart, blocks))
+
+	// Servers seen in each region, for reference
+	servers := make(map[string][]string)
+	seen := make(map[string]bool)
+
+	for _, block := range blocks {
+		if !seen[block.ServerName] {
+			seen[block.ServerName] = true
+			region := Region(block.ServerName)
+			servers[region] = append(servers[region], block.ServerName)
+		}
+	}
+
+	regions := make([]RegionStats, 0, len(aggregated))
+	total := hashrates[AllRegions]
+
+	for _, stats := range aggregated {
+		sort.Strings(servers[stats.ServerName])
+
+		region := RegionStats{
+			Region:              stats.ServerName,
+			Servers:             strings.Join(servers[stats.ServerName], ","),
+			Blocks:              stats.Blocks,
+			Uncles:              stats.Uncles,
+			TotalRewards:        stats.TotalRewards,
+			RewardShare:         stats.RewardShare,
+			FeeShare:            stats.FeeShare,
+			UncleInclusionShare: stats.UncleInclusionShare,
+			UncleRate:           stats.UncleRate,
+			AverageLuck:         stats.AverageLuck,
+			AverageRoundTime:    stats.AverageRoundTime,
+			AverageHashrate:     hashrates[stats.ServerName],
+		}
+
+		if stats.ServerName == AllRegions {
+			region.Servers = strings.Join(sortedKeys(seen), ",")
+		}
+
+		if len(blocks) > 0 {
+			region.BlockShare = float64(stats.Blocks) / float64(len(blocks))
+		}
+
+		if total > 0 && region.AverageHashrate > 0 {
+			region.HashrateShare = region.AverageHashrate / total
+			region.Efficiency = region.BlockShare / region.HashrateShare
+		}
+
+		regions = append(regions, region)
+	}
+
+	return regions
+}
+
+// window takes the pool's hashrate chart and a slice of blocks, and returns the chart points from the first block's
+// timestamp to the last. If no points fall in that period, the whole chart is returned.
+func window(chart []api.PoolHashrateChartData, blocks []api.Block) []api.PoolHashrateChartData {
+	if len(blocks) == 0 {
+		return chart
+	}
+
+	first, last := blocks[0].Timestamp, blocks[0].Timestamp
+
+	for _, block := range blocks {
+		if block.Timestamp < first {
+			first = block.Timestamp
+		}
+
+		if block.Timestamp > last {
+			last = block.Timestamp
+		}
+	}
+
+	var points []api.PoolHashrateChartData
+
+	for _, point := range chart {
+		if point.Timestamp >= first && point.Timestamp <= last {
+			points = append(points, point)
+		}
+	}
+
+	if len(points) == 0 {
+		return chart
+	}
+
+	return points
+}
+
+// averageHashrates takes hashrate chart points, and returns the mean hashrate of each region and of the pool under
+// AllRegions.
+func averageHashrates(chart []api.PoolHashrateChartData) map[string]float64 {
+	averages := make(map[string]float64)
+
+	if len(chart) == 0 {
+		return averages
+	}
+
+	for _, point := range chart {
+		averages[RegionAsia] += float64(point.As)
+		averages[RegionAustralia] += float64(point.Au)
+		averages[RegionEurope] += float64(point.Eu)
+		averages[RegionSouthAmerica] += float64(point.Sa)
+		averages[RegionUS] += float64(point.Us)
+		averages[AllRegions] += float64(point.Total)
+	}
+
+	for region := range averages {
+		averages[region] /= float64(len(chart))
+	}
+
+	return averages
+}
+
+// sortedKeys returns the keys of a set in order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,341 @@
+package blocks
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+// Tracker event types. A block is seen when it's first polled unconfirmed, and is then tracked until it's confirmed or
+// orphaned. A tracked block whose type changes, such as a block turned into an uncle, is reclassified and stays tracked.
+const (
+	EventSeen         = "seen"
+	EventConfirmed    = "confirmed"
+	EventReclassified = "reclassified"
+	EventOrphaned     = "orphaned"
+)
+
+// Defaults for TrackerOptions.
+const (
+	DefaultTrackerInterval    = time.Minute
+	DefaultTrackerMaxPages    = 10
+	DefaultTrackerOrphanPolls = 2
+)
+
+// ErrEmptyPoll is returned by Poll when blocks are tracked but the first page is empty or reports no pages. That looks
+// like an upstream outage rather than every tracked block being orphaned, so the poll changes nothing.
+var ErrEmptyPoll = errors.New("first page of blocks is empty while blocks are tracked")
+
+// TrackerEvent is a change in a tracked block. Block is the block as last polled, or as last seen for an orphaned
+// block. PreviousType is the block's type before it was reclassified, and TimeToConfirm is the number of seconds from
+// the block's timestamp to the poll that saw it confirmed.
+type TrackerEvent struct {
+	Type          string    `json:"type"`
+	Time          int64     `json:"time"`
+	Block         api.Block `json:"block"`
+	PreviousType  string    `json:"previous_type,omitempty"`
+	TimeToConfirm int64     `json:"time_to_confirm,omitempty"`
+}
+
+// TrackerOptions contains the settings for a Tracker. Interval is how often Run polls. Each poll pages back from the
+// newest block until it reaches past the oldest tracked block, up to MaxPages pages. A tracked block missing from the
+// pages it should be on for OrphanPolls polls in a row is orphaned, so a block briefly missing from the API isn't
+// reported. OnError is called with any failed poll in Run, and can be nil.
+type TrackerOptions struct {
+	Interval    time.Duration
+	MaxPages    int
+	OrphanPolls int
+	OnError     func(err error)
+}
+
+// ConfirmationStats contains the outcomes of the blocks a Tracker has seen. Times to confirmation are in seconds, and
+// are measured to the poll that saw each block confirmed, so they're accurate to within the poll interval. OrphanRate is
+// the share of the blocks that were confirmed or orphaned that were orphaned.
+type ConfirmationStats struct {
+	Seen                 int     `json:"seen"`
+	Pending              int     `json:"pending"`
+	Confirmed            int     `json:"confirmed"`
+	Orphaned             int     `json:"orphaned"`
+	Reclassified         int     `json:"reclassified"`
+	OrphanRate           float64 `json:"orphan_rate"`
+	AverageTimeToConfirm float64 `json:"average_time_to_confirm"`
+	MinTimeToConfirm     int64   `json:"min_time_to_confirm"`
+	MaxTimeToConfirm     int64   `json:"max_time_to_confirm"`
+}
+
+// Tracker follows unconfirmed blocks from a PageFunc until they're confirmed or orphaned.
+type Tracker struct {
+	pages   PageFunc
+	options TrackerOptions
+
+	// pollMu serializes polls, so the tracked blocks only change under it. mu guards the tracked blocks and stats, and
+	// isn't held while pages are fetched, so Pending and Stats don't wait on the API.
+	pollMu         sync.Mutex
+	mu             sync.Mutex
+	pending        map[string]*tracked
+	stats          ConfirmationStats
+	totalToConfirm int64
+}
+
+// tracked is an unconfirmed block and the number of polls in a row it's been missing from.
+type tracked struct {
+	block  api.Block
+	missed int
+}
+
+// NewTracker takes a PageFunc, such as PoolPages or MinerPages, and a set of TrackerOptions, and creates a Tracker with
+// no blocks tracked. Zero options fall back to their defaults.
+func NewTracker(pages PageFunc, options TrackerOptions) *Tracker {
+	if options.Interval <= 0 {
+		options.Interval = DefaultTrackerInterval
+	}
+
+	if options.MaxPages <= 0 {
+		options.MaxPages = DefaultTrackerMaxPages
+	}
+
+	if options.OrphanPolls <= 0 {
+		options.OrphanPolls = DefaultTrackerOrphanPolls
+	}
+
+	return &Tracker{pages: pages, options: options, pending: make(map[string]*tracked)}
+}
+
+// Poll takes the current time, and polls the blocks once, comparing them with the tracked blocks. Returns the events
+// in the order they happened and nil on success, or nil and error on failure, in which case nothing changes.
+func (t *Tracker) Poll(now time.Time) ([]TrackerEvent, error) {
+	t.pollMu.Lock()
+	defer t.pollMu.Unlock()
+
+	t.mu.Lock()
+	lowest := t.lowest()
+	t.mu.Unlock()
+
+	polled, covered, err := t.fetch(lowest)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var events []TrackerEvent
+	found := make(map[string]bool)
+
+	emit := func(event TrackerEvent) {
+		event.Time = now.Unix()
+		events = append(events, event)
+	}
+
+	for _, block := range polled {
+		found[block.Hash] = true
+		entry, ok := t.pending[block.Hash]
+
+		if !ok {
+			if !block.Confirmed {
+				t.pending[block.Hash] = &tracked{block: block}
+				t.stats.Seen++
+				emit(TrackerEvent{Type: EventSeen, Block: block})
+			}
+
+			continue
+		}
+
+		entry.missed = 0
+
+		if block.Type != entry.block.Type {
+			t.stats.Reclassified++
+			emit(TrackerEvent{Type: EventReclassified, Block: block, PreviousType: entry.block.Type})
+		}
+
+		entry.block = block
+
+		if block.Confirmed {
+			delete(t.pending, block.Hash)
+
+			toConfirm := now.Unix() - int64(block.Timestamp)
+			if toConfirm < 0 {
+				toConfirm = 0
+			}
+
+			t.confirmed(toConfirm)
+			emit(TrackerEvent{Type: EventConfirmed, Block: block, TimeToConfirm: toConfirm})
+		}
+	}
+
+	// Blocks older than the pages fetched can't be checked, so only the ones that should have been found count as missed
+	for _, hash := range t.pendingHashes() {
+		entry := t.pending[hash]
+
+		if found[hash] || !covered(entry.block) {
+			continue
+		}
+
+		if entry.missed++; entry.missed >= t.options.OrphanPolls {
+			delete(t.pending, hash)
+			t.stats.Orphaned++
+			emit(TrackerEvent{Type: EventOrphaned, Block: entry.block})
+		}
+	}
+
+	return events, nil
+}
+
+// Run polls the blocks every interval until the context is done, sending each event to events. Failed polls are
+// passed to OnError and retried at the next interval. Returns the context's error once it's done.
+func (t *Tracker) Run(ctx context.Context, events chan<- TrackerEvent) error {
+	ticker := time.NewTicker(t.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		polled, err := t.Poll(time.Now())
+		if err != nil && t.options.OnError != nil {
+			t.options.OnError(err)
+		}
+
+		for _, event := range polled {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Pending returns the blocks still being tracked, newest first.
+func (t *Tracker) Pending() []api.Block {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	blocks := make([]api.Block, 0, len(t.pending))
+	for _, hash := range t.pendingHashes() {
+		blocks = append(blocks, t.pending[hash].block)
+	}
+
+	return blocks
+}
+
+// Stats returns the outcomes of the blocks seen so far.
+func (t *Tracker) Stats() ConfirmationStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.stats
+	stats.Pending = len(t.pending)
+
+	if resolved := stats.Confirmed + stats.Orphaned; resolved > 0 {
+		stats.OrphanRate = float64(stats.Orphaned) / float64(resolved)
+	}
+
+	if stats.Confirmed > 0 {
+		stats.AverageTimeToConfirm = float64(t.totalToConfirm) / float64(stats.Confirmed)
+	}
+
+	return stats
+}
+
+// fetch takes the oldest tracked block's number, or 0 if no blocks are tracked, and pages back from the newest block
+// until a page reaches below it, the last page or MaxPages. With no blocks tracked, only the first page is fetched.
+// Returns the blocks, a function reporting whether a block's number was within the pages fetched and nil on success, or
+// nil, nil and error on failure, including ErrEmptyPoll if the first page is empty while blocks are tracked. t.mu must
+// not be held.
+func (t *Tracker) fetch(lowest uint) ([]api.Block, func(block api.Block) bool, error) {
+	var (
+		polled []api.Block
+		oldest uint
+		ended  bool
+	)
+
+	for page := 0; page < t.options.MaxPages; page++ {
+		blocks, totalPages, err := t.pages(page)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if page == 0 && lowest > 0 && (len(blocks) == 0 || totalPages == 0) {
+			return nil, nil, ErrEmptyPoll
+		}
+
+		polled = append(polled, blocks...)
+
+		// An empty page before the last one only covers the pages fetched before it
+		if len(blocks) == 0 {
+			ended = page+1 >= totalPages
+			break
+		}
+
+		if page+1 >= totalPages {
+			ended = true
+			break
+		}
+
+		// Blocks sharing the oldest number may continue on the next page, so it's only covered once it's passed
+		oldest = blocks[len(blocks)-1].Number
+		if lowest == 0 || oldest < lowest {
+			break
+		}
+	}
+
+	covered := func(block api.Block) bool {
+		return ended || block.Number > oldest
+	}
+
+	return polled, covered, nil
+}
+
+// lowest returns the oldest tracked block's number, or 0 if no blocks are tracked. t.mu must be held.
+func (t *Tracker) lowest() uint {
+	lowest := uint(0)
+	for _, entry := range t.pending {
+		if lowest == 0 || entry.block.Number < lowest {
+			lowest = entry.block.Number
+		}
+	}
+
+	return lowest
+}
+
+// confirmed records a block's time to confirmation in seconds. t.mu must be held.
+func (t *Tracker) confirmed(toConfirm int64) {
+	if t.stats.Confirmed == 0 || toConfirm < t.stats.MinTimeToConfirm {
+		t.stats.MinTimeToConfirm = toConfirm
+	}
+
+	if toConfirm > t.stats.MaxTimeToConfirm {
+		t.stats.MaxTimeToConfirm = toConfirm
+	}
+
+	t.stats.Confirmed++
+	t.totalToConfirm += toConfirm
+}
+
+// pendingHashes returns the hashes of the tracked blocks, newest first, so events and results are in a stable order.
+// t.mu must be held.
+func (t *Tracker) pendingHashes() []string {
+	hashes := make([]string, 0, len(t.pending))
+	for hash := range t.pending {
+		hashes = append(hashes, hash)
+	}
+
+	sort.Slice(hashes, func(i, j int) bool {
+		a, b := t.pending[hashes[i]].block, t.pending[hashes[j]].block
+		if a.Number != b.Number {
+			return a.Number > b.Number
+		}
+
+		return a.Hash < b.Hash
+	})
+
+	return hashes
+}
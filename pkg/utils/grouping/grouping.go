@@ -0,0 +1,311 @@
+package grouping
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/config"
+)
+
+// Ungrouped is the group workers are put in when no rule or tag matches them.
+const Ungrouped = "ungrouped"
+
+// Errors returned for invalid rules.
+var (
+	ErrEmptyGroupName = errors.New("group name can't be empty")
+	ErrNoMatcher      = errors.New("rule must have exactly one of a glob or regex")
+)
+
+// Rule assigns workers whose name matches a glob (as used by path.Match, for example "site1-*") or a regular expression
+// to a group.
+type Rule struct {
+	Group string `json:"group"`
+	Glob  string `json:"glob,omitempty"`
+	Regex string `json:"regex,omitempty"`
+}
+
+// Grouper assigns workers to groups by rules and explicit tags. A worker can belong to more than one group.
+type Grouper struct {
+	rules  []rule
+	tags   map[string][]string
+	groups []string
+}
+
+// rule is a compiled Rule.
+type rule struct {
+	group string
+	glob  string
+	regex *regexp.Regexp
+}
+
+// Stats contains the aggregated stats of the workers in a group.
+type Stats struct {
+	Name              string `json:"name"`
+	Workers           int    `json:"workers"`
+	Online            int    `json:"online"`
+	Offline           int    `json:"offline"`
+	EffectiveHashrate uint   `json:"effective_hashrate"`
+	ReportedHashrate  uint   `json:"reported_hashrate"`
+	ValidShares       int    `json:"valid_shares"`
+	StaleShares       int    `json:"stale_shares"`
+	InvalidShares     int    `json:"invalid_shares"`
+}
+
+// Report contains the stats of every group, and each group's merged chart data keyed by group name.
+type Report struct {
+	Groups []Stats                          `json:"groups"`
+	Charts map[string][]api.WorkerChartData `json:"charts"`
+}
+
+// New takes a set of rules and a map of worker names to the groups they're tagged with, and compiles them into a Grouper.
+// Returns the Grouper and nil on success, or nil and error if a rule is invalid.
+func New(rules []Rule, tags map[string][]string) (*Grouper, error) {
+	g := &Grouper{tags: make(map[string][]string)}
+	seen := make(map[string]bool)
+
+	addGroup := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			g.groups = append(g.groups, name)
+		}
+	}
+
+	for _, r := range rules {
+		if r.Group == "" {
+			return nil, ErrEmptyGroupName
+		}
+
+		if (r.Glob == "") == (r.Regex == "") {
+			return nil, ErrNoMatcher
+		}
+
+		compiled := rule{group: r.Group, glob: r.Glob}
+
+		if r.Glob != "" {
+			if _, err := path.Match(r.Glob, ""); err != nil {
+				return nil, err
+			}
+		} else {
+			var err error
+			if compiled.regex, err = regexp.Compile(r.Regex); err != nil {
+				return nil, err
+			}
+		}
+
+		g.rules = append(g.rules, compiled)
+		addGroup(r.Group)
+	}
+
+	// Groups only created by tags are listed after rule groups, in name order so output is stable
+	workers := make([]string, 0, len(tags))
+	for worker := range tags {
+		workers = append(workers, worker)
+	}
+
+	sort.Strings(workers)
+
+	var tagGroups []string
+	for _, worker := range workers {
+		for _, group := range tags[worker] {
+			if group == "" {
+				return nil, ErrEmptyGroupName
+			}
+
+			g.tags[worker] = append(g.tags[worker], group)
+
+			if !seen[group] {
+				tagGroups = append(tagGroups, group)
+				seen[group] = true
+			}
+		}
+	}
+
+	sort.Strings(tagGroups)
+	g.groups = append(g.groups, tagGroups...)
+
+	return g, nil
+}
+
+// FromConfig takes a config and a mining wallet address, and builds a Grouper from the config's groups and tags. Groups
+// tied to a different address are left out. Returns the Grouper and nil on success, or nil and error on failure.
+func FromConfig(cfg config.Config, address string) (*Grouper, error) {
+	var rules []Rule
+
+	for _, group := range cfg.Groups {
+		if group.Address != "" && cfg.Address(group.Address) != address {
+			continue
+		}
+
+		for _, glob := range group.Patterns {
+			rules = append(rules, Rule{Group: group.Name, Glob: glob})
+		}
+
+		for _, regex := range group.Regex {
+			rules = append(rules, Rule{Group: group.Name, Regex: regex})
+		}
+	}
+
+	return New(rules, cfg.Tags)
+}
+
+// Names returns the name of every group in the order they were defined, not including Ungrouped.
+func (g *Grouper) Names() []string {
+	return append([]string(nil), g.groups...)
+}
+
+// Groups takes a worker name and returns every group it belongs to, in the order the groups were defined. Workers that
+// match no rule or tag belong to Ungrouped.
+func (g *Grouper) Groups(worker string) []string {
+	member := make(map[string]bool)
+
+	for _, r := range g.rules {
+		if r.regex != nil {
+			member[r.group] = member[r.group] || r.regex.MatchString(worker)
+		} else {
+			matched, _ := path.Match(r.glob, worker)
+			member[r.group] = member[r.group] || matched
+		}
+	}
+
+	for _, group := range g.tags[worker] {
+		member[group] = true
+	}
+
+	var groups []string
+	for _, group := range g.groups {
+		if member[group] {
+			groups = append(groups, group)
+		}
+	}
+
+	if len(groups) == 0 {
+		return []string{Ungrouped}
+	}
+
+	return groups
+}
+
+// Assign takes a list of workers and returns the workers in each group, keyed by group name.
+func (g *Grouper) Assign(workers []api.MinerWorker) map[string][]api.MinerWorker {
+	assigned := make(map[string][]api.MinerWorker)
+
+	for _, worker := range workers {
+		for _, group := range g.Groups(worker.Name) {
+			assigned[group] = append(assigned[group], worker)
+		}
+	}
+
+	return assigned
+}
+
+// Report takes a list of workers and their chart data keyed by worker name, and aggregates them by group. Every defined
+// group is included even if it has no workers, followed by Ungrouped if any workers matched nothing. charts can be nil
+// to skip merging chart data.
+func (g *Grouper) Report(workers []api.MinerWorker, charts map[string][]api.WorkerChartData) Report {
+	assigned := g.Assign(workers)
+	report := Report{Charts: make(map[string][]api.WorkerChartData)}
+
+	names := g.Names()
+	if _, ok := assigned[Ungrouped]; ok {
+		names = append(names, Ungrouped)
+	}
+
+	for _, name := range names {
+		members := assigned[name]
+		report.Groups = append(report.Groups, Aggregate(name, members))
+
+		if charts == nil {
+			continue
+		}
+
+		series := make([][]api.WorkerChartData, 0, len(members))
+		for _, member := range members {
+			series = append(series, charts[member.Name])
+		}
+
+		report.Charts[name] = MergeCharts(series...)
+	}
+
+	return report
+}
+
+// Fetch takes a mining wallet address and gets its workers, along with every worker's chart data if charts is set, and
+// builds a Report. Returns the Report and nil on success, or an empty Report and error on failure.
+func (g *Grouper) Fetch(address string, charts bool) (Report, error) {
+	workers, err := api.MinerGetWorkers(address)
+	if err != nil {
+		return Report{}, err
+	}
+
+	if !charts {
+		return g.Report(workers, nil), nil
+	}
+
+	workerCharts := make(map[string][]api.WorkerChartData)
+	for _, worker := range workers {
+		if workerCharts[worker.Name], err = api.WorkerGetChart(address, worker.Name); err != nil {
+			return Report{}, err
+		}
+	}
+
+	return g.Report(workers, workerCharts), nil
+}
+
+// Aggregate takes a group name and its workers, and sums their hashrates, share counts and online/offline counts.
+func Aggregate(name string, workers []api.MinerWorker) Stats {
+	stats := Stats{Name: name, Workers: len(workers)}
+
+	for _, worker := range workers {
+		if worker.Online {
+			stats.Online++
+		} else {
+			stats.Offline++
+		}
+
+		stats.EffectiveHashrate += worker.EffectiveHashrate
+		stats.ReportedHashrate += worker.ReportedHashrate
+		stats.ValidShares += worker.ValidShares
+		stats.StaleShares += worker.StaleShares
+		stats.InvalidShares += worker.InvalidShares
+	}
+
+	return stats
+}
+
+// MergeCharts takes the chart data of several workers and sums it into a single series, sorted oldest first. Points are
+// matched by timestamp, which flexpool aligns to the same intervals for every worker. A worker missing a point simply
+// contributes nothing to it.
+func MergeCharts(series ...[]api.WorkerChartData) []api.WorkerChartData {
+	points := make(map[uint]*api.WorkerChartData)
+
+	for _, chart := range series {
+		for _, point := range chart {
+			merged, ok := points[point.Timestamp]
+			if !ok {
+				merged = &api.WorkerChartData{Timestamp: point.Timestamp}
+				points[point.Timestamp] = merged
+			}
+
+			merged.EffectiveHashrate += point.EffectiveHashrate
+			merged.AverageEffectiveHashrate += point.AverageEffectiveHashrate
+			merged.ReportedHashrate += point.ReportedHashrate
+			merged.ValidShares += point.ValidShares
+			merged.StaleShares += point.StaleShares
+			merged.InvalidShares += point.InvalidShares
+		}
+	}
+
+	merged := make([]api.WorkerChartData, 0, len(points))
+	for _, point := range points {
+		merged = append(merged, *point)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Timestamp < merged[j].Timestamp
+	})
+
+	return merged
+}
@@ -0,0 +1,323 @@
+// Package health scores the health of a miner's workers from their share counts, hashrates and chart history. Each worker
+// is checked against fixed thresholds (stale and invalid ratios, effective/reported efficiency, hashrate drops against a
+// rolling baseline and online/offline flapping), and compared to the rest of the fleet using z-scores. The result is a
+// report ranked from least to most healthy, with the reasons for each worker's score.
+package health
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+// MaxScore is the score of a worker with no problems found.
+const MaxScore = 100
+
+// Penalties subtracted from a worker's score for each problem found.
+const (
+	OfflinePenalty      = 50
+	StaleRatioPenalty   = 20
+	InvalidRatioPenalty = 20
+	EfficiencyPenalty   = 15
+	HashrateDropPenalty = 20
+	FlappingPenalty     = 15
+	OutlierPenalty      = 10
+)
+
+// Thresholds contains the limits a worker is checked against. BaselineWindow is the number of chart points before the
+// latest one that make up the rolling hashrate baseline.
+type Thresholds struct {
+	MaxStaleRatio   float64 `json:"max_stale_ratio"`
+	MaxInvalidRatio float64 `json:"max_invalid_ratio"`
+	MinEfficiency   float64 `json:"min_efficiency"`
+	MaxHashrateDrop float64 `json:"max_hashrate_drop"`
+	BaselineWindow  int     `json:"baseline_window"`
+	MaxFlaps        int     `json:"max_flaps"`
+	MaxZScore       float64 `json:"max_z_score"`
+}
+
+// WorkerHealth contains the health analysis of a single worker. Ratios are fractions between 0 and 1, and HashrateDrop is
+// the fraction the latest effective hashrate is below the rolling baseline.
+type WorkerHealth struct {
+	Name         string             `json:"name"`
+	Online       bool               `json:"online"`
+	Score        int                `json:"score"`
+	StaleRatio   float64            `json:"stale_ratio"`
+	InvalidRatio float64            `json:"invalid_ratio"`
+	Efficiency   float64            `json:"efficiency"`
+	HashrateDrop float64            `json:"hashrate_drop"`
+	Flaps        int                `json:"flaps"`
+	ZScores      map[string]float64 `json:"z_scores"`
+	Reasons      []string           `json:"reasons"`
+
+	penalty int
+
+	// hasEfficiency is set when Efficiency could be calculated, which needs a reported hashrate
+	hasEfficiency bool
+}
+
+// Metric names used as keys for WorkerHealth.ZScores.
+const (
+	MetricStaleRatio   = "stale_ratio"
+	MetricInvalidRatio = "invalid_ratio"
+	MetricEfficiency   = "efficiency"
+)
+
+// DefaultThresholds returns the Thresholds used when none are given.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		MaxStaleRatio:   0.05,
+		MaxInvalidRatio: 0.01,
+		MinEfficiency:   0.9,
+		MaxHashrateDrop: 0.3,
+		BaselineWindow:  6,
+		MaxFlaps:        3,
+		MaxZScore:       2,
+	}
+}
+
+// FetchReport takes a mining wallet address and a set of Thresholds, and fetches the miner's workers and each worker's
+// chart before building a report. Returns the report and nil on success, or nil and error on failure.
+func FetchReport(address string, thresholds Thresholds) ([]WorkerHealth, error) {
+	var (
+		workers []api.MinerWorker
+		err     error
+	)
+
+	if workers, err = api.MinerGetWorkers(address); err != nil {
+		return nil, err
+	}
+
+	charts := make(map[string][]api.WorkerChartData)
+
+	for _, worker := range workers {
+		if charts[worker.Name], err = api.WorkerGetChart(address, worker.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return Report(workers, charts, thresholds), nil
+}
+
+// Report takes a slice of api.MinerWorker instances, a map of worker name to that worker's chart data, and a set of
+// Thresholds, and analyses every worker. Workers without chart data skip the hashrate drop and flapping checks. Returns
+// the workers ranked from lowest to highest score, with ties broken by name.
+func Report(workers []api.MinerWorker, charts map[string][]api.WorkerChartData, thresholds Thresholds) []WorkerHealth {
+	report := make([]WorkerHealth, 0, len(workers))
+
+	for _, worker := range workers {
+		report = append(report, analyseWorker(worker, charts[worker.Name], thresholds))
+	}
+
+	flagOutliers(report, thresholds)
+
+	for i := range report {
+		report[i].Score = MaxScore - report[i].penalty
+		if report[i].Score < 0 {
+			report[i].Score = 0
+		}
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].Score != report[j].Score {
+			return report[i].Score < report[j].Score
+		}
+
+		return report[i].Name < report[j].Name
+	})
+
+	return report
+}
+
+// analyseWorker runs the threshold checks for a single worker.
+func analyseWorker(worker api.MinerWorker, chart []api.WorkerChartData, thresholds Thresholds) WorkerHealth {
+	health := WorkerHealth{
+		Name:    worker.Name,
+		Online:  worker.Online,
+		ZScores: make(map[string]float64),
+	}
+
+	if !worker.Online {
+		health.penalise(OfflinePenalty, "worker is offline")
+	}
+
+	totalShares := worker.ValidShares + worker.StaleShares + worker.InvalidShares
+	if totalShares > 0 {
+		health.StaleRatio = float64(worker.StaleShares) / float64(totalShares)
+		health.InvalidRatio = float64(worker.InvalidShares) / float64(totalShares)
+	}
+
+	if health.StaleRatio > thresholds.MaxStaleRatio {
+		health.penalise(StaleRatioPenalty, fmt.Sprintf("stale ratio %.2f%% is above %.2f%%",
+			health.StaleRatio*100, thresholds.MaxStaleRatio*100))
+	}
+
+	if health.InvalidRatio > thresholds.MaxInvalidRatio {
+		health.penalise(InvalidRatioPenalty, fmt.Sprintf("invalid ratio %.2f%% is above %.2f%%",
+			health.InvalidRatio*100, thresholds.MaxInvalidRatio*100))
+	}
+
+	if worker.ReportedHashrate > 0 {
+		health.Efficiency = float64(worker.EffectiveHashrate) / float64(worker.ReportedHashrate)
+		health.hasEfficiency = true
+
+		if health.Efficiency < thresholds.MinEfficiency {
+			health.penalise(EfficiencyPenalty, fmt.Sprintf("effective hashrate is %.1f%% of reported, below %.1f%%",
+				health.Efficiency*100, thresholds.MinEfficiency*100))
+		}
+	}
+
+	if len(chart) > 0 {
+		points := append([]api.WorkerChartData(nil), chart...)
+		sort.Slice(points, func(i, j int) bool {
+			return points[i].Timestamp < points[j].Timestamp
+		})
+
+		health.HashrateDrop = HashrateDrop(points, thresholds.BaselineWindow)
+		if health.HashrateDrop > thresholds.MaxHashrateDrop {
+			health.penalise(HashrateDropPenalty, fmt.Sprintf("hashrate dropped %.1f%% below its rolling baseline",
+				health.HashrateDrop*100))
+		}
+
+		health.Flaps = CountFlaps(points)
+		if health.Flaps > thresholds.MaxFlaps {
+			health.penalise(FlappingPenalty, fmt.Sprintf("went online/offline %d times", health.Flaps))
+		}
+	}
+
+	return health
+}
+
+// flagOutliers calculates the z-score of each worker's ratios and efficiency against the rest of the fleet, and penalises
+// workers that stand out in the bad direction. Efficiency is only compared between online workers with a reported
+// hashrate. The others have no meaningful efficiency, and are already penalised for being offline, so their zeros would
+// only drag the fleet's mean down and hide real outliers. They get a z-score of 0.
+func flagOutliers(report []WorkerHealth, thresholds Thresholds) {
+	metrics := []struct {
+		name        string
+		value       func(WorkerHealth) float64
+		include     func(WorkerHealth) bool
+		higherIsBad bool
+	}{
+		{MetricStaleRatio, func(w WorkerHealth) float64 { return w.StaleRatio }, nil, true},
+		{MetricInvalidRatio, func(w WorkerHealth) float64 { return w.InvalidRatio }, nil, true},
+		{MetricEfficiency, func(w WorkerHealth) float64 { return w.Efficiency },
+			func(w WorkerHealth) bool { return w.Online && w.hasEfficiency }, false},
+	}
+
+	for _, metric := range metrics {
+		var (
+			values   []float64
+			included []int
+		)
+
+		for i, worker := range report {
+			if metric.include == nil || metric.include(worker) {
+				values = append(values, metric.value(worker))
+				included = append(included, i)
+			}
+		}
+
+		for i := range report {
+			report[i].ZScores[metric.name] = 0
+		}
+
+		for j, score := range ZScores(values) {
+			i := included[j]
+			report[i].ZScores[metric.name] = score
+
+			if (metric.higherIsBad && score > thresholds.MaxZScore) || (!metric.higherIsBad && score < -thresholds.MaxZScore) {
+				report[i].penalise(OutlierPenalty, fmt.Sprintf("%s is an outlier across the fleet (z-score %.2f)",
+					metric.name, score))
+			}
+		}
+	}
+}
+
+// penalise adds a penalty and the reason for it to a worker.
+func (w *WorkerHealth) penalise(penalty int, reason string) {
+	w.penalty += penalty
+	w.Reasons = append(w.Reasons, reason)
+}
+
+// HashrateDrop takes chart data sorted by timestamp and a baseline window size, and returns the fraction the latest
+// effective hashrate is below the average of the window points before it. Returns 0 if there's no baseline or the
+// hashrate hasn't dropped.
+func HashrateDrop(points []api.WorkerChartData, window int) float64 {
+	if window <= 0 || len(points) < 2 {
+		return 0
+	}
+
+	latest := points[len(points)-1]
+	baselinePoints := points[:len(points)-1]
+
+	if len(baselinePoints) > window {
+		baselinePoints = baselinePoints[len(baselinePoints)-window:]
+	}
+
+	baseline := 0.0
+	for _, point := range baselinePoints {
+		baseline += float64(point.EffectiveHashrate)
+	}
+
+	baseline /= float64(len(baselinePoints))
+	if baseline == 0 {
+		return 0
+	}
+
+	return math.Max(0, 1-float64(latest.EffectiveHashrate)/baseline)
+}
+
+// CountFlaps takes chart data sorted by timestamp and counts how many times the worker switched between online and
+// offline. A worker is considered online for a chart point if it submitted any shares or had a reported hashrate.
+func CountFlaps(points []api.WorkerChartData) int {
+	flaps := 0
+
+	for i := 1; i < len(points); i++ {
+		if isOnline(points[i]) != isOnline(points[i-1]) {
+			flaps++
+		}
+	}
+
+	return flaps
+}
+
+// isOnline returns whether a chart point shows any activity from the worker.
+func isOnline(point api.WorkerChartData) bool {
+	return point.ValidShares+point.StaleShares+point.InvalidShares > 0 || point.ReportedHashrate > 0
+}
+
+// ZScores takes a slice of values and returns how many standard deviations each value is from the mean. Returns all zeros
+// if there are fewer than two values or they're all the same.
+func ZScores(values []float64) []float64 {
+	scores := make([]float64, len(values))
+
+	if len(values) < 2 {
+		return scores
+	}
+
+	mean := 0.0
+	for _, value := range values {
+		mean += value
+	}
+
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+
+	stdDev := math.Sqrt(variance / float64(len(values)))
+	if stdDev == 0 {
+		return scores
+	}
+
+	for i, value := range values {
+		scores[i] = (value - mean) / stdDev
+	}
+
+	return scores
+}
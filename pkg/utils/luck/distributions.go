@@ -0,0 +1,133 @@
+package luck
+
+import (
+	"math"
+)
+
+// Iteration limits and tolerances for the incomplete gamma function and quantile search.
+const (
+	gammaMaxIterations      = 500
+	gammaEpsilon            = 1e-15
+	quantileMaxIterations   = 200
+	quantileRelativeEpsilon = 1e-12
+)
+
+// ErlangCDF takes a value and an integer shape k, and returns the probability that an Erlang(k, 1) distributed variable
+// (the sum of k unit exponential variables) is less than or equal to x.
+func ErlangCDF(x float64, k int) float64 {
+	if x <= 0 || k < 1 {
+		return 0
+	}
+
+	return regularizedGammaP(float64(k), x)
+}
+
+// ErlangQuantile takes a probability and an integer shape k, and returns the value x such that ErlangCDF(x, k) = p. Returns
+// 0 for p <= 0 and +Inf for p >= 1.
+func ErlangQuantile(p float64, k int) float64 {
+	if p <= 0 || k < 1 {
+		return 0
+	}
+
+	if p >= 1 {
+		return math.Inf(1)
+	}
+
+	// Bracket the quantile, then bisect. The CDF is monotonic so this always converges.
+	lower, upper := 0.0, float64(k)
+	for ErlangCDF(upper, k) < p {
+		lower = upper
+		upper *= 2
+	}
+
+	for i := 0; i < quantileMaxIterations; i++ {
+		mid := (lower + upper) / 2
+
+		if ErlangCDF(mid, k) < p {
+			lower = mid
+		} else {
+			upper = mid
+		}
+
+		if upper-lower <= quantileRelativeEpsilon*upper {
+			break
+		}
+	}
+
+	return (lower + upper) / 2
+}
+
+// PoissonCDF takes an observed count and an expected mean, and returns the probability of a Poisson(mean) distributed
+// variable being less than or equal to count.
+func PoissonCDF(count int, mean float64) float64 {
+	if count < 0 {
+		return 0
+	}
+
+	if mean <= 0 {
+		return 1
+	}
+
+	// P(X <= k) for Poisson(mean) is the upper regularized gamma Q(k+1, mean)
+	return 1 - regularizedGammaP(float64(count+1), mean)
+}
+
+// regularizedGammaP computes the regularized lower incomplete gamma function P(a, x), using the series expansion for
+// x < a+1 and the continued fraction for the upper function otherwise.
+func regularizedGammaP(a float64, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+
+	lgammaA, _ := math.Lgamma(a)
+	prefix := a*math.Log(x) - x - lgammaA
+
+	if x < a+1 {
+		sum := 1.0 / a
+		term := sum
+
+		for n := 1; n < gammaMaxIterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+
+			if math.Abs(term) < math.Abs(sum)*gammaEpsilon {
+				break
+			}
+		}
+
+		return sum * math.Exp(prefix)
+	}
+
+	// Modified Lentz's method for the continued fraction of Q(a, x)
+	const tiny = 1e-300
+
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+
+	for n := 1; n < gammaMaxIterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+
+		d = 1 / d
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < gammaEpsilon {
+			break
+		}
+	}
+
+	return 1 - math.Exp(prefix)*h
+}
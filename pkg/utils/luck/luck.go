@@ -0,0 +1,321 @@
+// Package luck provides statistical analysis of pool and miner luck. Block discovery is modelled as a Poisson process,
+// which means the effort spent on each round (the Luck field of api.Block, where 1.0 is exactly the expected amount of
+// work) follows an exponential distribution with a mean of 1, and the total effort of N rounds follows an Erlang
+// distribution with shape N. All functions are pure and operate on slices of api.Block as returned by the API.
+package luck
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/utils"
+)
+
+// Errors returned for degenerate input.
+var (
+	ErrNoBlocks          = errors.New("no blocks given")
+	ErrInvalidWindow     = errors.New("window size must be between 1 and the number of blocks")
+	ErrInvalidConfidence = errors.New("confidence must be between 0 and 1 exclusive")
+	ErrInvalidHashrate   = errors.New("hashrate and difficulty must be non-zero")
+)
+
+// Bucket contains a single histogram bucket of an effort distribution, along with the number of blocks that would be
+// expected to land in it if the pool was perfectly fair.
+type Bucket struct {
+	Lower    float64 `json:"lower"`
+	Upper    float64 `json:"upper"`
+	Observed int     `json:"observed"`
+	Expected float64 `json:"expected"`
+}
+
+// Distribution contains summary statistics of the effort spent on a set of blocks.
+type Distribution struct {
+	Count   int      `json:"count"`
+	Mean    float64  `json:"mean"`
+	StdDev  float64  `json:"std_dev"`
+	Median  float64  `json:"median"`
+	P10     float64  `json:"p10"`
+	P90     float64  `json:"p90"`
+	Min     float64  `json:"min"`
+	Max     float64  `json:"max"`
+	Buckets []Bucket `json:"buckets"`
+}
+
+// Interval contains the lower and upper bound of a confidence interval.
+type Interval struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+// Streak contains a run of consecutive blocks whose combined effort was unusually high. Start and End are inclusive
+// indexes into the slice of blocks given, and Probability is the chance of a fair pool doing at least as badly over
+// that many blocks.
+type Streak struct {
+	Start       int     `json:"start"`
+	End         int     `json:"end"`
+	Blocks      int     `json:"blocks"`
+	TotalEffort float64 `json:"total_effort"`
+	Probability float64 `json:"probability"`
+}
+
+// effortBucketBounds are the upper bounds used for the effort histogram. The final bucket is open-ended.
+var effortBucketBounds = []float64{0.25, 0.5, 0.75, 1.0, 1.5, 2.0, 3.0}
+
+// Efforts takes a slice of api.Block instances and returns the effort of each block in the same order.
+func Efforts(blocks []api.Block) []float64 {
+	efforts := make([]float64, 0, len(blocks))
+
+	for _, block := range blocks {
+		efforts = append(efforts, block.Luck)
+	}
+
+	return efforts
+}
+
+// EffortDistribution takes a slice of api.Block instances and calculates the distribution of effort across them, with a
+// histogram comparing observed counts against those expected from an exponential distribution. Returns the Distribution
+// and nil on success, or an empty Distribution and error if no blocks were given.
+func EffortDistribution(blocks []api.Block) (Distribution, error) {
+	var dist Distribution
+
+	if len(blocks) == 0 {
+		return dist, ErrNoBlocks
+	}
+
+	efforts := Efforts(blocks)
+	sorted := append([]float64(nil), efforts...)
+	sort.Float64s(sorted)
+
+	total := 0.0
+	for _, effort := range efforts {
+		total += effort
+	}
+
+	dist.Count = len(efforts)
+	dist.Mean = total / float64(dist.Count)
+	dist.Min = sorted[0]
+	dist.Max = sorted[len(sorted)-1]
+	dist.Median = utils.Percentile(sorted, 50)
+	dist.P10 = utils.Percentile(sorted, 10)
+	dist.P90 = utils.Percentile(sorted, 90)
+
+	if dist.Count > 1 {
+		sumSquares := 0.0
+		for _, effort := range efforts {
+			sumSquares += (effort - dist.Mean) * (effort - dist.Mean)
+		}
+
+		dist.StdDev = math.Sqrt(sumSquares / float64(dist.Count-1))
+	}
+
+	// Build the histogram, with the expected count of each bucket coming from the exponential CDF 1 - e^-x
+	lower := 0.0
+	for i := 0; i <= len(effortBucketBounds); i++ {
+		upper := math.Inf(1)
+		if i < len(effortBucketBounds) {
+			upper = effortBucketBounds[i]
+		}
+
+		bucket := Bucket{
+			Lower:    lower,
+			Upper:    upper,
+			Expected: float64(dist.Count) * (math.Exp(-lower) - math.Exp(-upper)),
+		}
+
+		for _, effort := range efforts {
+			if effort >= lower && effort < upper {
+				bucket.Observed++
+			}
+		}
+
+		dist.Buckets = append(dist.Buckets, bucket)
+		lower = upper
+	}
+
+	return dist, nil
+}
+
+// RollingLuck takes a slice of api.Block instances and a window size, and calculates the average effort of every run of
+// window consecutive blocks in the order given. Returns a slice of len(blocks)-window+1 averages and nil on success, or
+// nil and error if the window is out of range.
+func RollingLuck(blocks []api.Block, window int) ([]float64, error) {
+	if window < 1 || window > len(blocks) {
+		return nil, ErrInvalidWindow
+	}
+
+	rolling := make([]float64, 0, len(blocks)-window+1)
+	sum := 0.0
+
+	for i, block := range blocks {
+		sum += block.Luck
+
+		if i >= window {
+			sum -= blocks[i-window].Luck
+		}
+
+		if i >= window-1 {
+			rolling = append(rolling, sum/float64(window))
+		}
+	}
+
+	return rolling, nil
+}
+
+// LuckInterval takes a slice of api.Block instances and a confidence level (ie. 0.95), and calculates the confidence
+// interval for the pool's true average effort based on the observed blocks. An interval that doesn't contain 1.0 suggests
+// luck that can't be explained by variance alone. Returns the Interval and nil on success, or an empty Interval and error
+// on invalid input.
+func LuckInterval(blocks []api.Block, confidence float64) (Interval, error) {
+	var interval Interval
+
+	if len(blocks) == 0 {
+		return interval, ErrNoBlocks
+	}
+
+	if confidence <= 0 || confidence >= 1 {
+		return interval, ErrInvalidConfidence
+	}
+
+	total := 0.0
+	for _, block := range blocks {
+		total += block.Luck
+	}
+
+	// The total effort is Erlang(n, theta) distributed, so the exact interval for theta comes from the Erlang quantiles
+	alpha := 1 - confidence
+	n := len(blocks)
+
+	interval.Lower = total / ErlangQuantile(1-alpha/2, n)
+	interval.Upper = total / ErlangQuantile(alpha/2, n)
+
+	return interval, nil
+}
+
+// ExpectedLuckRange takes a number of blocks and a confidence level, and calculates the range the average effort of that
+// many blocks would fall within for a perfectly fair pool. Returns the Interval and nil on success, or an empty Interval
+// and error on invalid input.
+func ExpectedLuckRange(blocks int, confidence float64) (Interval, error) {
+	var interval Interval
+
+	if blocks < 1 {
+		return interval, ErrNoBlocks
+	}
+
+	if confidence <= 0 || confidence >= 1 {
+		return interval, ErrInvalidConfidence
+	}
+
+	alpha := 1 - confidence
+
+	interval.Lower = ErlangQuantile(alpha/2, blocks) / float64(blocks)
+	interval.Upper = ErlangQuantile(1-alpha/2, blocks) / float64(blocks)
+
+	return interval, nil
+}
+
+// BlockCountInterval takes an observed number of blocks found over some period and a confidence level, and calculates
+// the exact (Garwood) confidence interval for the expected number of blocks over that period. Returns the Interval and
+// nil on success, or an empty Interval and error on invalid input.
+func BlockCountInterval(observed int, confidence float64) (Interval, error) {
+	var interval Interval
+
+	if observed < 0 {
+		return interval, ErrNoBlocks
+	}
+
+	if confidence <= 0 || confidence >= 1 {
+		return interval, ErrInvalidConfidence
+	}
+
+	alpha := 1 - confidence
+
+	if observed > 0 {
+		interval.Lower = ErlangQuantile(alpha/2, observed)
+	}
+
+	interval.Upper = ErlangQuantile(1-alpha/2, observed+1)
+
+	return interval, nil
+}
+
+// ExpectedRoundTime takes the network difficulty and the pool's hashrate in hashes per second, and calculates the
+// expected time to find a block in seconds. Returns the expected round time and nil on success, or 0 and error if either
+// value is zero.
+func ExpectedRoundTime(difficulty uint, poolHashrate uint) (float64, error) {
+	if difficulty == 0 || poolHashrate == 0 {
+		return 0, ErrInvalidHashrate
+	}
+
+	return float64(difficulty) / float64(poolHashrate), nil
+}
+
+// RoundProbability takes the length of the current round in seconds, the network difficulty and the pool's hashrate in
+// hashes per second, and calculates the probability of a round lasting at least that long. Returns the probability and
+// nil on success, or 0 and error if the difficulty or hashrate is zero.
+func RoundProbability(roundSeconds float64, difficulty uint, poolHashrate uint) (float64, error) {
+	expected, err := ExpectedRoundTime(difficulty, poolHashrate)
+	if err != nil {
+		return 0, err
+	}
+
+	if roundSeconds <= 0 {
+		return 1, nil
+	}
+
+	return math.Exp(-roundSeconds / expected), nil
+}
+
+// DetectBadStreaks takes a slice of api.Block instances and a significance level (ie. 0.01), and finds runs of
+// consecutive blocks whose combined effort a fair pool would exceed with a probability below the significance level.
+// The least likely run is taken first and the blocks either side of it are searched again, so the returned streaks
+// never overlap. Returns the streaks in the order of the blocks given.
+func DetectBadStreaks(blocks []api.Block, significance float64) []Streak {
+	streaks := findStreaks(blocks, 0, len(blocks)-1, significance)
+
+	sort.Slice(streaks, func(i, j int) bool {
+		return streaks[i].Start < streaks[j].Start
+	})
+
+	return streaks
+}
+
+// findStreaks recursively finds the least likely run of blocks between the inclusive lower and upper indexes.
+func findStreaks(blocks []api.Block, lower int, upper int, significance float64) []Streak {
+	if lower > upper {
+		return nil
+	}
+
+	best := Streak{Probability: 1}
+
+	for start := lower; start <= upper; start++ {
+		total := 0.0
+
+		for end := start; end <= upper; end++ {
+			total += blocks[end].Luck
+			blockCount := end - start + 1
+
+			probability := 1 - ErlangCDF(total, blockCount)
+			if probability < best.Probability {
+				best = Streak{
+					Start:       start,
+					End:         end,
+					Blocks:      blockCount,
+					TotalEffort: total,
+					Probability: probability,
+				}
+			}
+		}
+	}
+
+	if best.Probability >= significance {
+		return nil
+	}
+
+	streaks := []Streak{best}
+	streaks = append(streaks, findStreaks(blocks, lower, best.Start-1, significance)...)
+	streaks = append(streaks, findStreaks(blocks, best.End+1, upper, significance)...)
+
+	return streaks
+}
@@ -1,26 +1,63 @@
 package utils
 
 import (
+	"errors"
+
 	"github.com/cryptogenic/goflexpool/pkg/api"
 )
 
-// CalculateExpectedRoundTime takes a given network hashrate, pool hashrate, and average block time, and calculates what
-// the expected roundtime should be in seconds. The units for the network and pool hashrate do not matter as long as they're
-// consistent and the same unit. Megahashes/second is recommended for reasonable accuracy.
-func CalculateExpectedRoundTime(networkHashrate uint, poolHashrate uint, averageBlocktime int) uint {
-	return networkHashrate / poolHashrate * uint(averageBlocktime)
+// SecondsPerDay is the number of seconds in a day.
+const SecondsPerDay = 24 * 60 * 60
+
+// Errors returned for invalid input.
+var (
+	ErrNoBlocks         = errors.New("no blocks given")
+	ErrTooFewBlocks     = errors.New("at least 2 blocks are needed")
+	ErrZeroTimeSpan     = errors.New("blocks must span more than zero seconds")
+	ErrInvalidHashrate  = errors.New("hashrate must be greater than zero")
+	ErrInvalidBlockTime = errors.New("block time must be greater than zero")
+	ErrInvalidN         = errors.New("N and the share difficulty must be greater than zero")
+)
+
+// CalculateExpectedRoundTime takes a given network hashrate, pool hashrate, and average block time in seconds, and
+// calculates what the expected roundtime should be in seconds. The units for the network and pool hashrate do not
+// matter as long as they're consistent and the same unit. Returns the round time and nil on success, or 0 and error
+// if either hashrate or the block time isn't greater than zero.
+func CalculateExpectedRoundTime(networkHashrate float64, poolHashrate float64, averageBlocktime float64) (float64, error) {
+	if networkHashrate <= 0 || poolHashrate <= 0 {
+		return 0, ErrInvalidHashrate
+	}
+
+	if averageBlocktime <= 0 {
+		return 0, ErrInvalidBlockTime
+	}
+
+	return networkHashrate / poolHashrate * averageBlocktime, nil
 }
 
 // CalculatePPLNSShareWindow takes the N value, the pool's current share difficulty, and the pool's hashrate to calculate
 // how many seconds it will take for those shares to be exhausted / expire. The units for the share difficulty and pool
-// hashrate do not matter as long as they're consistent and the same unit. Megahashes/second is recommended for reasonable
-// accuracy.
-func CalculatePPLNSShareWindow(N int, shareDifficulty uint, poolHashrate uint) uint {
-	return (uint(N) * shareDifficulty) / poolHashrate
+// hashrate do not matter as long as they're consistent and the same unit. Returns the window in seconds and nil on
+// success, or 0 and error if any input isn't greater than zero.
+func CalculatePPLNSShareWindow(N int, shareDifficulty float64, poolHashrate float64) (float64, error) {
+	if N <= 0 || shareDifficulty <= 0 {
+		return 0, ErrInvalidN
+	}
+
+	if poolHashrate <= 0 {
+		return 0, ErrInvalidHashrate
+	}
+
+	return float64(N) * shareDifficulty / poolHashrate, nil
 }
 
-// CalculateUncleRate takes a slice of api.Block instances and returns the uncle rate as a percentage as a float64.
-func CalculateUncleRate(blocks []api.Block) float64 {
+// CalculateUncleRate takes a slice of api.Block instances and calculates the share of them that were uncles, as a
+// fraction between 0 and 1. Returns the uncle rate and nil on success, or 0 and ErrNoBlocks for an empty slice.
+func CalculateUncleRate(blocks []api.Block) (float64, error) {
+	if len(blocks) == 0 {
+		return 0, ErrNoBlocks
+	}
+
 	uncleBlocks := float64(0)
 
 	for _, block := range blocks {
@@ -29,31 +66,52 @@ func CalculateUncleRate(blocks []api.Block) float64 {
 		}
 	}
 
-	return uncleBlocks / float64(len(blocks))
+	return uncleBlocks / float64(len(blocks)), nil
 }
 
-// CalculateAverageBlockReward takes a slice of api.Block instances and calculates the average reward per block in gwei
-// and returns it as an int.
-func CalculateAverageBlockReward(blocks []api.Block) uint {
-	blockRewardTotal := uint(0)
+// CalculateAverageBlockReward takes a slice of api.Block instances and calculates the average reward per block in
+// gwei. Returns the average and nil on success, or 0 and ErrNoBlocks for an empty slice.
+func CalculateAverageBlockReward(blocks []api.Block) (float64, error) {
+	if len(blocks) == 0 {
+		return 0, ErrNoBlocks
+	}
+
+	blockRewardTotal := float64(0)
 
 	for _, block := range blocks {
-		blockRewardTotal += block.TotalRewards
+		blockRewardTotal += float64(block.TotalRewards)
 	}
 
-	return blockRewardTotal / uint(len(blocks))
+	return blockRewardTotal / float64(len(blocks)), nil
 }
 
-// CalculateAverageBlocksPerDay takes a slice of api.Block instances and calculates the number of blocks found per day
-// based on the roundtime of the blocks.
-func CalculateAverageBlocksPerDay(blocks []api.Block) int {
-	blockTimeTotal := 0
+// CalculateAverageBlocksPerDay takes a slice of api.Block instances in any order and calculates the number of blocks
+// found per day from their timestamps, as the number of gaps between blocks over the time from the first to the last.
+// Returns the blocks per day and nil on success, or 0 and error if there are fewer than 2 blocks or they all share a
+// timestamp.
+func CalculateAverageBlocksPerDay(blocks []api.Block) (float64, error) {
+	switch len(blocks) {
+	case 0:
+		return 0, ErrNoBlocks
+	case 1:
+		return 0, ErrTooFewBlocks
+	}
+
+	first, last := blocks[0].Timestamp, blocks[0].Timestamp
 
 	for _, block := range blocks {
-		blockTimeTotal += int(block.RoundTime)
+		if block.Timestamp < first {
+			first = block.Timestamp
+		}
+
+		if block.Timestamp > last {
+			last = block.Timestamp
+		}
+	}
+
+	if last == first {
+		return 0, ErrZeroTimeSpan
 	}
 
-	// Seconds / 60 = Minutes / 60 = Hours / 24 = 1 Day
-	blockTimeTotalDays := blockTimeTotal / 60 / 60 / 24
-	return len(blocks) / blockTimeTotalDays
+	return float64(len(blocks)-1) / float64(last-first) * SecondsPerDay, nil
 }
@@ -0,0 +1,160 @@
+// Package pplns models flexpool's PPLNS (pay per last N shares) reward scheme. Given the pool's hashrate, share
+// difficulty and block timings it estimates a miner's share of the next block reward from their hashrate history, how
+// long a new miner takes to reach a steady-state payout, and what a worker going offline costs. A Monte Carlo mode
+// simulates block and share arrivals to estimate the variance of the payout.
+package pplns
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+// Current flexpool PPLNS settings.
+const (
+	DefaultN               = 2_000_000     // 2 million share pool
+	DefaultShareDifficulty = 4_000_000_000 // 4GH/s difficulty
+)
+
+// Errors returned for invalid models or input.
+var (
+	ErrInvalidModel = errors.New("N, share difficulty, pool hashrate and round time must be non-zero")
+	ErrNoHistory    = errors.New("no hashrate history given")
+	ErrNoBlocks     = errors.New("no blocks given")
+)
+
+// Model contains the pool parameters used for PPLNS estimates. Hashrates are in hashes per second and rewards in gwei.
+type Model struct {
+	N               int     `json:"n"`
+	ShareDifficulty uint    `json:"share_difficulty"`
+	PoolHashrate    uint    `json:"pool_hashrate"`
+	BlockRewardGwei uint    `json:"block_reward_gwei"`
+	RoundTime       float64 `json:"round_time"`
+}
+
+// Estimate contains a miner's expected position in the PPLNS window.
+type Estimate struct {
+	WindowShares        float64 `json:"window_shares"`
+	Fraction            float64 `json:"fraction"`
+	NextBlockRewardGwei float64 `json:"next_block_reward_gwei"`
+}
+
+// NewModel takes the pool's hashrate and a slice of recent api.Block instances, and builds a Model using the default N
+// and share difficulty, with the block reward and round time averaged over the blocks. Returns the Model and nil on
+// success, or an empty Model and error if no blocks were given.
+func NewModel(poolHashrate uint, blocks []api.Block) (Model, error) {
+	if len(blocks) == 0 {
+		return Model{}, ErrNoBlocks
+	}
+
+	rewardTotal := 0.0
+	roundTimeTotal := 0.0
+
+	for _, block := range blocks {
+		rewardTotal += float64(block.TotalRewards)
+		roundTimeTotal += float64(block.RoundTime)
+	}
+
+	return Model{
+		N:               DefaultN,
+		ShareDifficulty: DefaultShareDifficulty,
+		PoolHashrate:    poolHashrate,
+		BlockRewardGwei: uint(rewardTotal / float64(len(blocks))),
+		RoundTime:       roundTimeTotal / float64(len(blocks)),
+	}, nil
+}
+
+// Validate checks that the model can be used for calculations. Returns nil if it's valid, or ErrInvalidModel otherwise.
+func (m Model) Validate() error {
+	if m.N <= 0 || m.ShareDifficulty == 0 || m.PoolHashrate == 0 || m.RoundTime <= 0 {
+		return ErrInvalidModel
+	}
+
+	return nil
+}
+
+// windowSeconds returns how many seconds the pool takes to submit N shares.
+func (m Model) windowSeconds() float64 {
+	return float64(m.N) * float64(m.ShareDifficulty) / float64(m.PoolHashrate)
+}
+
+// ShareWindow returns how long a share stays in the PPLNS window before it expires.
+func (m Model) ShareWindow() time.Duration {
+	return time.Duration(m.windowSeconds() * float64(time.Second))
+}
+
+// SteadyStateTime returns how long a new miner with a constant hashrate takes to reach their full payout per block. A
+// miner's share of the window grows linearly until their first shares start expiring, which takes one share window.
+func (m Model) SteadyStateTime() time.Duration {
+	return m.ShareWindow()
+}
+
+// ShareValueGwei returns the expected amount of gwei a single share earns over its lifetime in the window. Each share
+// receives 1/N of every block found while it's in the window, and on average window / round time blocks are found.
+func (m Model) ShareValueGwei() float64 {
+	return float64(m.BlockRewardGwei) * (m.windowSeconds() / m.RoundTime) / float64(m.N)
+}
+
+// ExpectedShare takes a miner's hashrate history from api.MinerGetChart and estimates how many of the miner's shares are in
+// the current window and their share of the next block reward. Each data point is treated as the average effective
+// hashrate for the period leading up to its timestamp, and the window is taken to end at the latest data point. Returns
+// the Estimate and nil on success, or an empty Estimate and error on invalid input.
+func (m Model) ExpectedShare(history []api.MinerChartData) (Estimate, error) {
+	var estimate Estimate
+
+	if err := m.Validate(); err != nil {
+		return estimate, err
+	}
+
+	if len(history) == 0 {
+		return estimate, ErrNoHistory
+	}
+
+	points := append([]api.MinerChartData(nil), history...)
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp < points[j].Timestamp
+	})
+
+	windowEnd := float64(points[len(points)-1].Timestamp)
+	windowStart := windowEnd - m.windowSeconds()
+
+	// Guess the sampling interval of the first point from the spacing of the rest
+	interval := 600.0
+	if len(points) > 1 {
+		interval = float64(points[1].Timestamp - points[0].Timestamp)
+	}
+
+	minerHashes := 0.0
+	periodStart := float64(points[0].Timestamp) - interval
+
+	for _, point := range points {
+		periodEnd := float64(point.Timestamp)
+		overlap := math.Min(periodEnd, windowEnd) - math.Max(periodStart, windowStart)
+
+		if overlap > 0 {
+			minerHashes += float64(point.EffectiveHashrate) * overlap
+		}
+
+		periodStart = periodEnd
+	}
+
+	estimate.WindowShares = minerHashes / float64(m.ShareDifficulty)
+	estimate.Fraction = estimate.WindowShares / float64(m.N)
+	estimate.NextBlockRewardGwei = estimate.Fraction * float64(m.BlockRewardGwei)
+
+	return estimate, nil
+}
+
+// OfflineLoss takes the effective hashrate of a worker and how long it was offline for, and estimates the revenue in gwei
+// lost from the shares it didn't submit. Returns the lost revenue and nil on success, or 0 and error on an invalid model.
+func (m Model) OfflineLoss(hashrate uint, offline time.Duration) (float64, error) {
+	if err := m.Validate(); err != nil {
+		return 0, err
+	}
+
+	lostShares := float64(hashrate) * offline.Seconds() / float64(m.ShareDifficulty)
+	return lostShares * m.ShareValueGwei(), nil
+}
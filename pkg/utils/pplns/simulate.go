@@ -0,0 +1,123 @@
+package pplns
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/utils"
+)
+
+// ErrInvalidSimulation is returned when a simulation is requested with no runs, duration or hashrate.
+var ErrInvalidSimulation = errors.New("runs, duration and miner hashrate must be non-zero")
+
+// SimulationConfig contains the settings for a Monte Carlo simulation. The same Seed always produces the same result.
+type SimulationConfig struct {
+	MinerHashrate uint          `json:"miner_hashrate"`
+	Duration      time.Duration `json:"duration"`
+	Runs          int           `json:"runs"`
+	Seed          int64         `json:"seed"`
+}
+
+// SimulationResult contains the distribution of the miner's revenue in gwei over the simulated duration.
+type SimulationResult struct {
+	Runs         int     `json:"runs"`
+	MeanGwei     float64 `json:"mean_gwei"`
+	StdDevGwei   float64 `json:"std_dev_gwei"`
+	MinGwei      float64 `json:"min_gwei"`
+	P5Gwei       float64 `json:"p5_gwei"`
+	MedianGwei   float64 `json:"median_gwei"`
+	P95Gwei      float64 `json:"p95_gwei"`
+	MaxGwei      float64 `json:"max_gwei"`
+	ExpectedGwei float64 `json:"expected_gwei"`
+	MeanBlocks   float64 `json:"mean_blocks"`
+}
+
+// Simulate takes a SimulationConfig and runs a Monte Carlo simulation of a miner with a constant hashrate who has already
+// reached steady state. Blocks and the miner's shares both arrive as Poisson processes, and each block pays the miner for
+// every one of their shares in the window at the time. Shares are counted as they're drawn rather than kept, so memory
+// grows with the blocks in a run, not the shares. Returns the SimulationResult and nil on success, or an empty
+// SimulationResult and error on invalid input.
+func (m Model) Simulate(config SimulationConfig) (SimulationResult, error) {
+	var result SimulationResult
+
+	if err := m.Validate(); err != nil {
+		return result, err
+	}
+
+	if config.Runs <= 0 || config.Duration <= 0 || config.MinerHashrate == 0 {
+		return result, ErrInvalidSimulation
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed))
+
+	window := m.windowSeconds()
+	duration := config.Duration.Seconds()
+	shareRate := float64(config.MinerHashrate) / float64(m.ShareDifficulty)
+	rewardPerShare := float64(m.BlockRewardGwei) / float64(m.N)
+
+	revenues := make([]float64, config.Runs)
+	totalBlocks := 0
+
+	var blocks []float64
+
+	for run := 0; run < config.Runs; run++ {
+		blocks = blocks[:0]
+		for t := rng.ExpFloat64() * m.RoundTime; t < duration; t += rng.ExpFloat64() * m.RoundTime {
+			blocks = append(blocks, t)
+		}
+
+		totalBlocks += len(blocks)
+
+		// Shares are streamed rather than stored, as there can be far more of them than blocks. A share at time s is paid
+		// by every block found in (s, s+window], which are the blocks from first up to last, and both only move forwards.
+		// Shares submitted up to one window before the start still count towards the first blocks.
+		shares, first, last := 0, 0, 0
+		for s := -window + rng.ExpFloat64()/shareRate; s < duration; s += rng.ExpFloat64() / shareRate {
+			for first < len(blocks) && blocks[first] <= s {
+				first++
+			}
+
+			for last < len(blocks) && blocks[last] <= s+window {
+				last++
+			}
+
+			shares += last - first
+		}
+
+		revenues[run] = float64(shares) * rewardPerShare
+	}
+
+	sorted := append([]float64(nil), revenues...)
+	sort.Float64s(sorted)
+
+	total := 0.0
+	for _, revenue := range revenues {
+		total += revenue
+	}
+
+	result.Runs = config.Runs
+	result.MeanGwei = total / float64(config.Runs)
+	result.MinGwei = sorted[0]
+	result.MaxGwei = sorted[len(sorted)-1]
+	result.P5Gwei = utils.Percentile(sorted, 5)
+	result.MedianGwei = utils.Percentile(sorted, 50)
+	result.P95Gwei = utils.Percentile(sorted, 95)
+	result.MeanBlocks = float64(totalBlocks) / float64(config.Runs)
+
+	// The analytical expectation is the miner's steady-state window share of every block found
+	result.ExpectedGwei = shareRate * window * rewardPerShare * duration / m.RoundTime
+
+	if config.Runs > 1 {
+		sumSquares := 0.0
+		for _, revenue := range revenues {
+			sumSquares += (revenue - result.MeanGwei) * (revenue - result.MeanGwei)
+		}
+
+		result.StdDevGwei = math.Sqrt(sumSquares / float64(config.Runs-1))
+	}
+
+	return result, nil
+}
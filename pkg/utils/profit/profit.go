@@ -0,0 +1,140 @@
+// Package profit provides profitability and payout estimates for a miner, combining the miner's balance, payout threshold,
+// estimated revenue and hashrate with user-supplied electricity costs.
+package profit
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/utils"
+)
+
+// HoursPerDay is used for converting power draw in watts into daily energy usage.
+const HoursPerDay = 24
+
+// Errors returned for invalid input.
+var (
+	ErrNegativePowerCost = errors.New("watts per worker, price per kWh and eth price must not be negative")
+)
+
+// Inputs contains everything needed to calculate profitability. Values marked as gwei or hashes per second are in the same
+// units as the api package. The power and price values are supplied by the user, and prices can be in any currency as
+// long as PricePerKWh and EthPrice use the same one.
+type Inputs struct {
+	BalanceGwei               uint    `json:"balance_gwei"`
+	MinPayoutThresholdGwei    uint    `json:"min_payout_threshold_gwei"`
+	EstimatedDailyRevenueGwei uint    `json:"estimated_daily_revenue_gwei"`
+	EffectiveHashrate         uint    `json:"effective_hashrate"`
+	AverageBlockRewardGwei    uint    `json:"average_block_reward_gwei"`
+	Workers                   int     `json:"workers"`
+	WattsPerWorker            float64 `json:"watts_per_worker"`
+	PricePerKWh               float64 `json:"price_per_kwh"`
+	EthPrice                  float64 `json:"eth_price"`
+}
+
+// Result contains the calculated profitability figures. The fiat values are only calculated when an EthPrice is given,
+// and the payout time is only valid when PayoutReachable is true.
+type Result struct {
+	RevenuePerDayGwei        uint          `json:"revenue_per_day_gwei"`
+	RevenuePerMHsPerDayGwei  float64       `json:"revenue_per_mhs_per_day_gwei"`
+	BlockRewardFractionDaily float64       `json:"block_reward_fraction_daily"`
+	RemainingToPayoutGwei    uint          `json:"remaining_to_payout_gwei"`
+	PayoutReachable          bool          `json:"payout_reachable"`
+	TimeToPayout             time.Duration `json:"time_to_payout"`
+	EnergyPerDayKWh          float64       `json:"energy_per_day_kwh"`
+	PowerCostPerDay          float64       `json:"power_cost_per_day"`
+	RevenuePerDay            float64       `json:"revenue_per_day"`
+	ProfitPerDay             float64       `json:"profit_per_day"`
+}
+
+// Fetch takes a mining wallet address and gathers the API-sourced fields of Inputs for it, using the number of online
+// workers as the worker count. The power and price fields are left for the caller to fill in. Returns the Inputs and nil
+// on success, or an empty Inputs and error on failure.
+func Fetch(address string) (Inputs, error) {
+	var (
+		inputs      Inputs
+		details     api.MinerDetails
+		current     api.WorkerCurrentStats
+		workerCount api.MinerWorkerCount
+		err         error
+	)
+
+	if inputs.BalanceGwei, err = api.MinerGetBalance(address); err != nil {
+		return Inputs{}, err
+	}
+
+	if details, err = api.MinerGetDetails(address); err != nil {
+		return Inputs{}, err
+	}
+
+	if inputs.EstimatedDailyRevenueGwei, err = api.MinerGetEstimatedDailyRevenue(address); err != nil {
+		return Inputs{}, err
+	}
+
+	if current, err = api.MinerGetCurrent(address); err != nil {
+		return Inputs{}, err
+	}
+
+	if workerCount, err = api.MinerGetWorkerCount(address); err != nil {
+		return Inputs{}, err
+	}
+
+	if inputs.AverageBlockRewardGwei, err = api.PoolGetAverageBlockReward(); err != nil {
+		return Inputs{}, err
+	}
+
+	inputs.MinPayoutThresholdGwei = details.MinPayoutThreshold
+	inputs.EffectiveHashrate = current.EffectiveHashrate
+	inputs.Workers = workerCount.Online
+
+	return inputs, nil
+}
+
+// Calculate takes a set of Inputs and calculates revenue per MH/s, the time until the payout threshold is reached, and
+// the daily profit after power costs. Returns the Result and nil on success, or an empty Result and error on invalid input.
+func Calculate(inputs Inputs) (Result, error) {
+	var result Result
+
+	if inputs.WattsPerWorker < 0 || inputs.PricePerKWh < 0 || inputs.EthPrice < 0 {
+		return result, ErrNegativePowerCost
+	}
+
+	result.RevenuePerDayGwei = inputs.EstimatedDailyRevenueGwei
+
+	// Revenue is normalised against effective hashrate, since that's what the pool pays on
+	effectiveMHs := float64(inputs.EffectiveHashrate) / math.Pow10(utils.MegaPow10Exponential)
+	if effectiveMHs > 0 {
+		result.RevenuePerMHsPerDayGwei = float64(inputs.EstimatedDailyRevenueGwei) / effectiveMHs
+	}
+
+	if inputs.AverageBlockRewardGwei > 0 {
+		result.BlockRewardFractionDaily = float64(inputs.EstimatedDailyRevenueGwei) / float64(inputs.AverageBlockRewardGwei)
+	}
+
+	// Work out how long until the unpaid balance crosses the payout threshold
+	if inputs.BalanceGwei >= inputs.MinPayoutThresholdGwei {
+		result.PayoutReachable = true
+	} else {
+		result.RemainingToPayoutGwei = inputs.MinPayoutThresholdGwei - inputs.BalanceGwei
+
+		if inputs.EstimatedDailyRevenueGwei > 0 {
+			days := float64(result.RemainingToPayoutGwei) / float64(inputs.EstimatedDailyRevenueGwei)
+
+			result.PayoutReachable = true
+			result.TimeToPayout = time.Duration(days * HoursPerDay * float64(time.Hour))
+		}
+	}
+
+	// Power costs are in the user's currency, so revenue needs converting with the eth price to get a profit figure
+	result.EnergyPerDayKWh = float64(inputs.Workers) * inputs.WattsPerWorker * HoursPerDay / 1000
+	result.PowerCostPerDay = result.EnergyPerDayKWh * inputs.PricePerKWh
+
+	if inputs.EthPrice > 0 {
+		result.RevenuePerDay = utils.ConvertGweiToEth(inputs.EstimatedDailyRevenueGwei) * inputs.EthPrice
+		result.ProfitPerDay = result.RevenuePerDay - result.PowerCostPerDay
+	}
+
+	return result, nil
+}
@@ -0,0 +1,20 @@
+package utils
+
+import "math"
+
+// Percentile takes a sorted slice of values and a percentile between 0 and 100, and returns the linearly interpolated
+// value at that percentile. Percentiles outside 0 to 100 are clamped to the minimum or maximum. Returns 0 if no values
+// were given.
+func Percentile(sorted []float64, percentile float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	percentile = math.Max(0, math.Min(100, percentile))
+
+	rank := percentile / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
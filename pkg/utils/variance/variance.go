@@ -0,0 +1,275 @@
+// Package variance simulates how much a miner's payouts swing from day to day. Block discovery is modelled as a Poisson
+// process with a rate derived from the pool's share of the network hashrate, and the miner earns their round share of
+// the average block reward for every block found. All simulations take a seed so results are reproducible.
+package variance
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/utils"
+)
+
+// Simulation defaults.
+const (
+	DefaultBlockTime        = 13.2 // Average ethereum block time in seconds
+	DefaultRuns             = 1000
+	DefaultHistogramBuckets = 10
+	DefaultMaxDays          = 365
+
+	secondsPerDay = 24 * 60 * 60
+)
+
+// Errors returned for invalid configurations.
+var (
+	ErrInvalidHashrate = errors.New("pool and network hashrate must be non-zero, and pool hashrate can't exceed network hashrate")
+	ErrInvalidConfig   = errors.New("days, block time, block reward and round share must be positive")
+)
+
+// Config contains the inputs for a simulation. Hashrates are in hashes per second, rewards in gwei, and RoundShare is a
+// percentage as returned by api.MinerGetRoundShare. Zero values for BlockTime, Runs, HistogramBuckets and MaxDays use the
+// package defaults.
+type Config struct {
+	PoolHashrate     uint    `json:"pool_hashrate"`
+	NetworkHashrate  uint    `json:"network_hashrate"`
+	BlockTime        float64 `json:"block_time"`
+	BlockRewardGwei  uint    `json:"block_reward_gwei"`
+	RoundShare       float64 `json:"round_share"`
+	BalanceGwei      uint    `json:"balance_gwei"`
+	ThresholdGwei    uint    `json:"threshold_gwei"`
+	Days             int     `json:"days"`
+	Runs             int     `json:"runs"`
+	MaxDays          int     `json:"max_days"`
+	HistogramBuckets int     `json:"histogram_buckets"`
+	Seed             int64   `json:"seed"`
+}
+
+// Bucket contains a single histogram bucket, covering values from Lower (inclusive) to Upper (exclusive).
+type Bucket struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int     `json:"count"`
+}
+
+// Distribution contains summary statistics, percentiles and a histogram of a set of simulated values.
+type Distribution struct {
+	Count     int      `json:"count"`
+	Mean      float64  `json:"mean"`
+	StdDev    float64  `json:"std_dev"`
+	Min       float64  `json:"min"`
+	P5        float64  `json:"p5"`
+	P25       float64  `json:"p25"`
+	Median    float64  `json:"median"`
+	P75       float64  `json:"p75"`
+	P95       float64  `json:"p95"`
+	Max       float64  `json:"max"`
+	Histogram []Bucket `json:"histogram"`
+}
+
+// Result contains the outcome of a simulation. DailyEarningsGwei covers each simulated day, and TimeToThresholdHours
+// covers each run that reached the payout threshold within MaxDays. ThresholdUnreached counts the runs that didn't.
+type Result struct {
+	ExpectedBlocksPerDay   float64      `json:"expected_blocks_per_day"`
+	ExpectedDailyGwei      float64      `json:"expected_daily_gwei"`
+	DailyEarningsGwei      Distribution `json:"daily_earnings_gwei"`
+	TimeToThresholdHours   Distribution `json:"time_to_threshold_hours"`
+	ThresholdUnreached     int          `json:"threshold_unreached"`
+	ThresholdAlreadyMet    bool         `json:"threshold_already_met"`
+	SimulatedDays          int          `json:"simulated_days"`
+	TimeToThresholdSamples int          `json:"time_to_threshold_samples"`
+}
+
+// FetchConfig takes a mining wallet address and a network hashrate in hashes per second, and fills in a Config from the
+// pool's hashrate and average block reward, and the miner's round share, balance and payout threshold. The network
+// hashrate isn't available from the flexpool API, so it has to be given. Returns the Config and nil on success, or an
+// empty Config and error on failure.
+func FetchConfig(address string, networkHashrate uint) (Config, error) {
+	var (
+		config       Config
+		poolHashrate api.PoolHashrate
+		details      api.MinerDetails
+		err          error
+	)
+
+	if poolHashrate, err = api.PoolGetHashrate(); err != nil {
+		return Config{}, err
+	}
+
+	if config.BlockRewardGwei, err = api.PoolGetAverageBlockReward(); err != nil {
+		return Config{}, err
+	}
+
+	if config.RoundShare, err = api.MinerGetRoundShare(address); err != nil {
+		return Config{}, err
+	}
+
+	if config.BalanceGwei, err = api.MinerGetBalance(address); err != nil {
+		return Config{}, err
+	}
+
+	if details, err = api.MinerGetDetails(address); err != nil {
+		return Config{}, err
+	}
+
+	config.PoolHashrate = poolHashrate.Total
+	config.NetworkHashrate = networkHashrate
+	config.ThresholdGwei = details.MinPayoutThreshold
+
+	return config, nil
+}
+
+// Simulate takes a Config and simulates the given number of days of block discovery to build a distribution of daily
+// earnings, then simulates Runs independent runs from the current balance to build a distribution of the time taken to
+// reach the payout threshold. Returns the Result and nil on success, or an empty Result and error on invalid input.
+func Simulate(config Config) (Result, error) {
+	var result Result
+
+	config = withDefaults(config)
+
+	if config.PoolHashrate == 0 || config.NetworkHashrate == 0 || config.PoolHashrate > config.NetworkHashrate {
+		return result, ErrInvalidHashrate
+	}
+
+	if config.Days <= 0 || config.BlockTime <= 0 || config.BlockRewardGwei == 0 || config.RoundShare <= 0 {
+		return result, ErrInvalidConfig
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed))
+
+	// Blocks per second found by the pool, and what the miner earns from each one
+	blockRate := float64(config.PoolHashrate) / float64(config.NetworkHashrate) / config.BlockTime
+	earningsPerBlock := float64(config.BlockRewardGwei) * config.RoundShare / 100
+
+	result.ExpectedBlocksPerDay = blockRate * secondsPerDay
+	result.ExpectedDailyGwei = result.ExpectedBlocksPerDay * earningsPerBlock
+	result.SimulatedDays = config.Days
+
+	// Daily earnings come from one continuous timeline of block arrivals, bucketed into days
+	daily := make([]float64, config.Days)
+	for t := rng.ExpFloat64() / blockRate; t < float64(config.Days)*secondsPerDay; t += rng.ExpFloat64() / blockRate {
+		daily[int(t/secondsPerDay)] += earningsPerBlock
+	}
+
+	result.DailyEarningsGwei = NewDistribution(daily, config.HistogramBuckets)
+
+	// Time to threshold is simulated independently for each run, starting from the current balance
+	if config.BalanceGwei >= config.ThresholdGwei {
+		result.ThresholdAlreadyMet = true
+		return result, nil
+	}
+
+	remaining := float64(config.ThresholdGwei - config.BalanceGwei)
+	maxSeconds := float64(config.MaxDays) * secondsPerDay
+
+	var hours []float64
+	for run := 0; run < config.Runs; run++ {
+		earned := 0.0
+		t := 0.0
+
+		for earned < remaining && t < maxSeconds {
+			t += rng.ExpFloat64() / blockRate
+			earned += earningsPerBlock
+		}
+
+		if earned >= remaining && t < maxSeconds {
+			hours = append(hours, t/3600)
+		} else {
+			result.ThresholdUnreached++
+		}
+	}
+
+	result.TimeToThresholdSamples = len(hours)
+	result.TimeToThresholdHours = NewDistribution(hours, config.HistogramBuckets)
+
+	return result, nil
+}
+
+// NewDistribution takes a slice of values and a number of histogram buckets, and calculates the summary statistics,
+// percentiles and an evenly spaced histogram of the values. Returns an empty Distribution if no values were given.
+func NewDistribution(values []float64, buckets int) Distribution {
+	var dist Distribution
+
+	if len(values) == 0 {
+		return dist
+	}
+
+	if buckets <= 0 {
+		buckets = DefaultHistogramBuckets
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	total := 0.0
+	for _, value := range sorted {
+		total += value
+	}
+
+	dist.Count = len(sorted)
+	dist.Mean = total / float64(dist.Count)
+	dist.Min = sorted[0]
+	dist.Max = sorted[len(sorted)-1]
+	dist.P5 = utils.Percentile(sorted, 5)
+	dist.P25 = utils.Percentile(sorted, 25)
+	dist.Median = utils.Percentile(sorted, 50)
+	dist.P75 = utils.Percentile(sorted, 75)
+	dist.P95 = utils.Percentile(sorted, 95)
+
+	if dist.Count > 1 {
+		sumSquares := 0.0
+		for _, value := range sorted {
+			sumSquares += (value - dist.Mean) * (value - dist.Mean)
+		}
+
+		dist.StdDev = math.Sqrt(sumSquares / float64(dist.Count-1))
+	}
+
+	// If every value is the same, everything goes into a single bucket
+	width := (dist.Max - dist.Min) / float64(buckets)
+	if width == 0 {
+		dist.Histogram = []Bucket{{Lower: dist.Min, Upper: dist.Max, Count: dist.Count}}
+		return dist
+	}
+
+	dist.Histogram = make([]Bucket, buckets)
+	for i := range dist.Histogram {
+		dist.Histogram[i].Lower = dist.Min + float64(i)*width
+		dist.Histogram[i].Upper = dist.Min + float64(i+1)*width
+	}
+
+	for _, value := range sorted {
+		// The maximum value belongs to the last bucket rather than one past it
+		index := int((value - dist.Min) / width)
+		if index >= buckets {
+			index = buckets - 1
+		}
+
+		dist.Histogram[index].Count++
+	}
+
+	return dist
+}
+
+// withDefaults fills in the zero-valued optional fields of a Config with the package defaults.
+func withDefaults(config Config) Config {
+	if config.BlockTime == 0 {
+		config.BlockTime = DefaultBlockTime
+	}
+
+	if config.Runs == 0 {
+		config.Runs = DefaultRuns
+	}
+
+	if config.HistogramBuckets == 0 {
+		config.HistogramBuckets = DefaultHistogramBuckets
+	}
+
+	if config.MaxDays == 0 {
+		config.MaxDays = DefaultMaxDays
+	}
+
+	return config
+}
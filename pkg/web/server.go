@@ -0,0 +1,375 @@
+package web
+
+import (
+	"crypto/subtle"
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/cache"
+)
+
+// static contains the single page dashboard UI.
+//
+//go:embed static
+var static embed.FS
+
+// Errors returned to API clients.
+var (
+	ErrUnknownAddress = errors.New("address is not in the dashboard's address list")
+	ErrInvalidPage    = errors.New("page must be a non-negative integer")
+	ErrNotFound       = errors.New("not found")
+)
+
+// Options contains the settings for a dashboard Server. Addresses maps display names to wallet addresses, and only those
+// addresses can be queried. Basic auth is required when Username is set.
+type Options struct {
+	Addresses map[string]string
+	Username  string
+	Password  string
+	CacheTTL  time.Duration
+}
+
+// Server serves the dashboard's JSON API under /api/ and the embedded UI everywhere else.
+type Server struct {
+	options Options
+	cache   *cache.Cache
+	mux     *http.ServeMux
+}
+
+// Address contains a named wallet address from the dashboard's address list.
+type Address struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// MinerOverview contains the summary of a miner shown at the top of the dashboard.
+type MinerOverview struct {
+	Name                      string                 `json:"name"`
+	Address                   string                 `json:"address"`
+	BalanceGwei               uint                   `json:"balance_gwei"`
+	Current                   api.WorkerCurrentStats `json:"current"`
+	Details                   api.MinerDetails       `json:"details"`
+	RoundShare                float64                `json:"round_share"`
+	EstimatedDailyRevenueGwei uint                   `json:"estimated_daily_revenue_gwei"`
+	TotalPaidGwei             uint                   `json:"total_paid_gwei"`
+	WorkerCount               api.MinerWorkerCount   `json:"worker_count"`
+}
+
+// PoolOverview contains the pool stats shown on the dashboard.
+type PoolOverview struct {
+	Hashrate               api.PoolHashrate         `json:"hashrate"`
+	MinersOnline           int                      `json:"miners_online"`
+	WorkersOnline          int                      `json:"workers_online"`
+	CurrentLuck            float64                  `json:"current_luck"`
+	AverageLuck            api.PoolAvgLuckRoundTime `json:"average_luck"`
+	AverageBlockRewardGwei uint                     `json:"average_block_reward_gwei"`
+}
+
+// errorResponse is the body returned with any non-200 status.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewServer takes a set of Options and creates a Server with an empty cache.
+func NewServer(options Options) *Server {
+	s := &Server{
+		options: options,
+		cache:   cache.New(options.CacheTTL),
+		mux:     http.NewServeMux(),
+	}
+
+	ui, _ := fs.Sub(static, "static")
+
+	s.mux.HandleFunc("/api/addresses", s.handleAddresses)
+	s.mux.HandleFunc("/api/miner/", s.handleMiner)
+	s.mux.HandleFunc("/api/pool", s.handlePool)
+	s.mux.HandleFunc("/api/pool/", s.handlePool)
+	s.mux.HandleFunc("/api/cache", s.handleCache)
+	s.mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, ErrNotFound)
+	})
+	s.mux.Handle("/", http.FileServer(http.FS(ui)))
+
+	return s
+}
+
+// ServeHTTP checks basic auth if it's enabled, and then serves the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.options.Username != "" {
+		username, password, ok := r.BasicAuth()
+
+		// Compare both values in constant time so neither leaks through timing
+		userMatch := subtle.ConstantTimeCompare([]byte(username), []byte(s.options.Username))
+		passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(s.options.Password))
+
+		if !ok || userMatch&passwordMatch != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="flexpool"`)
+			writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+
+			return
+		}
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// Purge removes expired values from the server's cache.
+func (s *Server) Purge() {
+	s.cache.Purge()
+}
+
+// handleAddresses lists the addresses that can be queried, sorted by name.
+func (s *Server) handleAddresses(w http.ResponseWriter, r *http.Request) {
+	addresses := make([]Address, 0, len(s.options.Addresses))
+	for name, address := range s.options.Addresses {
+		addresses = append(addresses, Address{Name: name, Address: address})
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Name < addresses[j].Name
+	})
+
+	writeJSON(w, addresses)
+}
+
+// handleMiner serves /api/miner/{address} and its workers, chart, payments and blocks sub-resources. The address can be
+// given by name or as the address itself.
+func (s *Server) handleMiner(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/miner/"), "/"), "/")
+	if len(parts) > 2 {
+		writeError(w, http.StatusNotFound, ErrNotFound)
+		return
+	}
+
+	name, address, ok := s.lookup(parts[0])
+	if !ok {
+		writeError(w, http.StatusNotFound, ErrUnknownAddress)
+		return
+	}
+
+	section := ""
+	if len(parts) == 2 {
+		section = parts[1]
+	}
+
+	page, err := pageParam(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var fetch func() (interface{}, error)
+
+	switch section {
+	case "":
+		fetch = func() (interface{}, error) {
+			return fetchMinerOverview(name, address)
+		}
+	case "workers":
+		fetch = func() (interface{}, error) {
+			return api.MinerGetWorkers(address)
+		}
+	case "chart":
+		fetch = func() (interface{}, error) {
+			return api.MinerGetChart(address)
+		}
+	case "payments":
+		fetch = func() (interface{}, error) {
+			return api.MinerGetPayments(address, page)
+		}
+	case "blocks":
+		fetch = func() (interface{}, error) {
+			return api.MinerGetBlocks(address, page)
+		}
+	default:
+		writeError(w, http.StatusNotFound, ErrNotFound)
+		return
+	}
+
+	s.serveCached(w, "miner/"+address+"/"+section+"?page="+strconv.Itoa(page), fetch)
+}
+
+// handlePool serves /api/pool and its chart and blocks sub-resources.
+func (s *Server) handlePool(w http.ResponseWriter, r *http.Request) {
+	section := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/pool"), "/")
+
+	page, err := pageParam(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var fetch func() (interface{}, error)
+
+	switch section {
+	case "":
+		fetch = func() (interface{}, error) {
+			return fetchPoolOverview()
+		}
+	case "chart":
+		fetch = func() (interface{}, error) {
+			return api.PoolGetHashrateChart()
+		}
+	case "blocks":
+		fetch = func() (interface{}, error) {
+			return api.PoolGetBlocks(page)
+		}
+	default:
+		writeError(w, http.StatusNotFound, ErrNotFound)
+		return
+	}
+
+	s.serveCached(w, "pool/"+section+"?page="+strconv.Itoa(page), fetch)
+}
+
+// handleCache reports the cache's hit and miss counts.
+func (s *Server) handleCache(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.cache.Stats())
+}
+
+// serveCached writes the cached value for key, fetching it if needed. Upstream failures are returned as 502s.
+func (s *Server) serveCached(w http.ResponseWriter, key string, fetch func() (interface{}, error)) {
+	value, status, err := s.cache.Fetch(key, fetch)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	if status == cache.Miss {
+		w.Header().Set("X-Cache", "MISS")
+	} else {
+		w.Header().Set("X-Cache", "HIT")
+	}
+
+	writeJSON(w, value)
+}
+
+// lookup resolves a name or address from the address list. Returns the name, the address and whether it was found.
+func (s *Server) lookup(nameOrAddress string) (string, string, bool) {
+	if address, ok := s.options.Addresses[nameOrAddress]; ok {
+		return nameOrAddress, address, true
+	}
+
+	for name, address := range s.options.Addresses {
+		if strings.EqualFold(address, nameOrAddress) {
+			return name, address, true
+		}
+	}
+
+	return "", "", false
+}
+
+// fetchMinerOverview takes a display name and wallet address, and gets the stats shown in the dashboard's summary.
+// Returns the MinerOverview and nil on success, or an empty MinerOverview and error on failure.
+func fetchMinerOverview(name string, address string) (MinerOverview, error) {
+	var err error
+
+	overview := MinerOverview{Name: name, Address: address}
+
+	if overview.BalanceGwei, err = api.MinerGetBalance(address); err != nil {
+		return MinerOverview{}, err
+	}
+
+	if overview.Current, err = api.MinerGetCurrent(address); err != nil {
+		return MinerOverview{}, err
+	}
+
+	if overview.Details, err = api.MinerGetDetails(address); err != nil {
+		return MinerOverview{}, err
+	}
+
+	if overview.RoundShare, err = api.MinerGetRoundShare(address); err != nil {
+		return MinerOverview{}, err
+	}
+
+	if overview.EstimatedDailyRevenueGwei, err = api.MinerGetEstimatedDailyRevenue(address); err != nil {
+		return MinerOverview{}, err
+	}
+
+	if overview.TotalPaidGwei, err = api.MinerGetTotalPaid(address); err != nil {
+		return MinerOverview{}, err
+	}
+
+	if overview.WorkerCount, err = api.MinerGetWorkerCount(address); err != nil {
+		return MinerOverview{}, err
+	}
+
+	return overview, nil
+}
+
+// fetchPoolOverview gets the pool stats shown on the dashboard. Returns the PoolOverview and nil on success, or an empty
+// PoolOverview and error on failure.
+func fetchPoolOverview() (PoolOverview, error) {
+	var (
+		err      error
+		overview PoolOverview
+	)
+
+	if overview.Hashrate, err = api.PoolGetHashrate(); err != nil {
+		return PoolOverview{}, err
+	}
+
+	if overview.MinersOnline, err = api.PoolGetMinersOnline(); err != nil {
+		return PoolOverview{}, err
+	}
+
+	if overview.WorkersOnline, err = api.PoolGetWorkersOnline(); err != nil {
+		return PoolOverview{}, err
+	}
+
+	if overview.CurrentLuck, err = api.PoolGetCurrentLuck(); err != nil {
+		return PoolOverview{}, err
+	}
+
+	if overview.AverageLuck, err = api.PoolGetAverageLuckRoundTime(); err != nil {
+		return PoolOverview{}, err
+	}
+
+	if overview.AverageBlockRewardGwei, err = api.PoolGetAverageBlockReward(); err != nil {
+		return PoolOverview{}, err
+	}
+
+	return overview, nil
+}
+
+// pageParam reads the optional page query parameter. Returns the page and nil on success, or 0 and ErrInvalidPage if
+// it isn't a non-negative integer.
+func pageParam(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("page")
+	if value == "" {
+		return 0, nil
+	}
+
+	page, err := strconv.Atoi(value)
+	if err != nil || page < 0 {
+		return 0, ErrInvalidPage
+	}
+
+	return page, nil
+}
+
+// writeJSON writes a value as a JSON response.
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes an error as a JSON response with the given status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,253 @@
+// Single page dashboard for the flexpool-web JSON API. Everything is rendered client side from /api/ responses, with
+// charts drawn as inline SVG so there are no external dependencies.
+(function () {
+  "use strict";
+
+  var REFRESH_MS = 60000;
+  var COLOURS = ["#0069ff", "#f57c00", "#2e7d32", "#8e24aa", "#c62828", "#00838f"];
+
+  // current is the name of the miner being shown, or null for the pool view
+  var current = null;
+
+  function $(id) {
+    return document.getElementById(id);
+  }
+
+  function getJSON(path) {
+    return fetch(path, { credentials: "same-origin" }).then(function (response) {
+      return response.json().then(function (body) {
+        if (!response.ok) {
+          throw new Error(body.error || response.statusText);
+        }
+        return body;
+      });
+    });
+  }
+
+  function showError(err) {
+    var element = $("error");
+    element.textContent = err ? "Unable to load data: " + err.message : "";
+    element.hidden = !err;
+  }
+
+  function eth(gwei) {
+    return (gwei / 1e9).toFixed(6) + " ETH";
+  }
+
+  function mhs(hashrate) {
+    return (hashrate / 1e6).toFixed(1) + " MH/s";
+  }
+
+  function date(timestamp) {
+    return new Date(timestamp * 1000).toLocaleString();
+  }
+
+  function cards(element, entries) {
+    element.innerHTML = "";
+    entries.forEach(function (entry) {
+      var card = document.createElement("div");
+      card.className = "card";
+      card.innerHTML = '<div class="label"></div><div class="value"></div>';
+      card.children[0].textContent = entry[0];
+      card.children[1].textContent = entry[1];
+      element.appendChild(card);
+    });
+  }
+
+  // table renders rows into a table, where columns is a list of [heading, function(row) -> text].
+  function table(element, columns, rows, rowClass) {
+    element.innerHTML = "";
+
+    var head = element.createTHead().insertRow();
+    columns.forEach(function (column) {
+      var th = document.createElement("th");
+      th.textContent = column[0];
+      head.appendChild(th);
+    });
+
+    var body = element.createTBody();
+    (rows || []).forEach(function (row) {
+      var tr = body.insertRow();
+      if (rowClass) {
+        tr.className = rowClass(row);
+      }
+      columns.forEach(function (column) {
+        tr.insertCell().textContent = column[1](row);
+      });
+    });
+
+    if (!rows || rows.length === 0) {
+      var cell = body.insertRow().insertCell();
+      cell.colSpan = columns.length;
+      cell.textContent = "Nothing to show.";
+    }
+  }
+
+  // lineChart draws each series as a line, scaled to a shared y axis. series is a list of {name, points: [[x, y]]}.
+  function lineChart(element, series, format) {
+    var width = 1000, height = 220, pad = 40;
+    var xs = [], ys = [0];
+
+    series.forEach(function (s) {
+      s.points.forEach(function (p) {
+        xs.push(p[0]);
+        ys.push(p[1]);
+      });
+    });
+
+    element.innerHTML = "";
+    if (xs.length === 0) {
+      element.textContent = "No chart data available.";
+      return;
+    }
+
+    var minX = Math.min.apply(null, xs), maxX = Math.max.apply(null, xs);
+    var maxY = Math.max.apply(null, ys) || 1;
+
+    function x(value) {
+      return pad + (maxX === minX ? 0 : (value - minX) / (maxX - minX)) * (width - 2 * pad);
+    }
+
+    function y(value) {
+      return height - pad / 2 - (value / maxY) * (height - pad);
+    }
+
+    var svg = '<svg viewBox="0 0 ' + width + " " + height + '" preserveAspectRatio="none">';
+    svg += '<text x="2" y="' + (y(maxY) + 4) + '" font-size="11">' + format(maxY) + "</text>";
+    svg += '<line x1="' + pad + '" x2="' + (width - pad) + '" y1="' + y(0) + '" y2="' + y(0) + '" stroke="#ccc"/>';
+
+    series.forEach(function (s, i) {
+      var path = s.points
+        .slice()
+        .sort(function (a, b) { return a[0] - b[0]; })
+        .map(function (p, j) { return (j === 0 ? "M" : "L") + x(p[0]).toFixed(1) + "," + y(p[1]).toFixed(1); })
+        .join(" ");
+      svg += '<path d="' + path + '" fill="none" stroke-width="2" stroke="' + COLOURS[i % COLOURS.length] + '"/>';
+    });
+
+    svg += "</svg>";
+
+    var legend = '<div class="legend">' + series.map(function (s, i) {
+      return '<span style="color:' + COLOURS[i % COLOURS.length] + '">&#9632; ' + s.name + "</span>";
+    }).join("") + "</div>";
+
+    element.innerHTML = svg + legend;
+  }
+
+  function loadMiner(name) {
+    var base = "/api/miner/" + encodeURIComponent(name);
+
+    return Promise.all([
+      getJSON(base),
+      getJSON(base + "/chart"),
+      getJSON(base + "/workers"),
+      getJSON(base + "/payments"),
+      getJSON(base + "/blocks")
+    ]).then(function (results) {
+      var overview = results[0];
+
+      cards($("miner-summary"), [
+        ["Balance", eth(overview.balance_gwei)],
+        ["Estimated daily", eth(overview.estimated_daily_revenue_gwei)],
+        ["Total paid", eth(overview.total_paid_gwei)],
+        ["Effective hashrate", mhs(overview.current.effective_hashrate)],
+        ["Reported hashrate", mhs(overview.current.reported_hashrate)],
+        ["Workers", overview.worker_count.online + " online, " + overview.worker_count.offline + " offline"],
+        ["Round share", overview.round_share.toFixed(4) + "%"],
+        ["Payout threshold", eth(overview.details.min_payout_threshold)]
+      ]);
+
+      var chart = results[1] || [];
+      lineChart($("miner-chart"), [
+        { name: "Effective", points: chart.map(function (p) { return [p.timestamp, p.effective_hashrate]; }) },
+        { name: "Reported", points: chart.map(function (p) { return [p.timestamp, p.reported_hashrate]; }) },
+        { name: "Average", points: chart.map(function (p) { return [p.timestamp, p.average_effective_hashrate]; }) }
+      ], mhs);
+
+      table($("workers"), [
+        ["Name", function (w) { return w.name; }],
+        ["Status", function (w) { return w.online ? "online" : "offline"; }],
+        ["Effective", function (w) { return mhs(w.effective_hashrate); }],
+        ["Reported", function (w) { return mhs(w.reported_hashrate); }],
+        ["Valid", function (w) { return w.valid_shares; }],
+        ["Stale", function (w) { return w.stale_shares; }],
+        ["Invalid", function (w) { return w.invalid_shares; }],
+        ["Last seen", function (w) { return date(w.last_seen); }]
+      ], results[2], function (w) { return w.online ? "" : "offline"; });
+
+      table($("payments"), [
+        ["Time", function (p) { return date(p.timestamp); }],
+        ["Amount", function (p) { return eth(p.amount); }],
+        ["Duration", function (p) { return (p.duration / 3600).toFixed(1) + " h"; }],
+        ["Transaction", function (p) { return p.txid; }]
+      ], results[3].data);
+
+      table($("blocks"), [
+        ["Number", function (b) { return b.number; }],
+        ["Time", function (b) { return date(b.timestamp); }],
+        ["Type", function (b) { return b.type; }],
+        ["Reward", function (b) { return eth(b.total_rewards); }],
+        ["Luck", function (b) { return (b.luck * 100).toFixed(0) + "%"; }],
+        ["Region", function (b) { return b.server_name; }]
+      ], results[4].data);
+    });
+  }
+
+  function loadPool() {
+    return Promise.all([getJSON("/api/pool"), getJSON("/api/pool/chart"), getJSON("/api/pool/blocks")]).then(function (results) {
+      var overview = results[0];
+
+      cards($("pool-summary"), [
+        ["Hashrate", mhs(overview.hashrate.total)],
+        ["Miners online", overview.miners_online],
+        ["Workers online", overview.workers_online],
+        ["Current luck", (overview.current_luck * 100).toFixed(0) + "%"],
+        ["Average luck", (overview.average_luck.luck * 100).toFixed(0) + "%"],
+        ["Average block reward", eth(overview.average_block_reward_gwei)]
+      ]);
+
+      var chart = results[1] || [];
+      lineChart($("pool-chart"), ["total", "eu", "us", "as", "au", "sa"].map(function (region) {
+        return { name: region, points: chart.map(function (p) { return [p.timestamp, p[region]]; }) };
+      }), mhs);
+
+      table($("pool-blocks"), [
+        ["Number", function (b) { return b.number; }],
+        ["Time", function (b) { return date(b.timestamp); }],
+        ["Type", function (b) { return b.type; }],
+        ["Miner", function (b) { return b.miner; }],
+        ["Luck", function (b) { return (b.luck * 100).toFixed(0) + "%"; }],
+        ["Region", function (b) { return b.server_name; }]
+      ], results[2].data);
+    });
+  }
+
+  function show(name) {
+    current = name;
+    $("miner").hidden = name === null;
+    $("pool").hidden = name !== null;
+    refresh();
+  }
+
+  function refresh() {
+    var loading = current === null ? loadPool() : loadMiner(current);
+    loading.then(function () { showError(null); }, showError);
+  }
+
+  getJSON("/api/addresses").then(function (addresses) {
+    var select = $("address");
+
+    addresses.forEach(function (entry) {
+      var option = document.createElement("option");
+      option.value = entry.name;
+      option.textContent = entry.name;
+      select.appendChild(option);
+    });
+
+    select.addEventListener("change", function () { show(select.value); });
+    $("pool-tab").addEventListener("click", function () { show(null); });
+
+    show(addresses.length > 0 ? addresses[0].name : null);
+    setInterval(refresh, REFRESH_MS);
+  }, showError);
+})();
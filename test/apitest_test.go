@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/apitest"
+)
+
+// recorder is a testing.TB that records failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// totalBalance is a consumer of the api package's services, standing in for the code under test.
+func totalBalance(miners api.MinerService, addresses []string) (uint, error) {
+	var total uint
+
+	for _, address := range addresses {
+		balance, err := miners.GetBalance(address)
+		if err != nil {
+			return 0, err
+		}
+
+		total += balance
+	}
+
+	return total, nil
+}
+
+func TestServices(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	// The real client's services should send requests to its own host, not DefaultClient's
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/miner/" + address + "/balance":
+			w.Write([]byte(`{"error": null, "result": 2000000000}`))
+		case "/worker/" + address + "/rig1/current":
+			w.Write([]byte(`{"error": null, "result": {"effective_hashrate": 90, "reported_hashrate": 100}}`))
+		case "/pool/minersOnline":
+			w.Write([]byte(`{"error": null, "result": 7}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer upstream.Close()
+
+	client := &api.Client{Host: upstream.URL}
+
+	if balance, err := client.Miner().GetBalance(address); err != nil || balance != 2 {
+		t.Errorf("Miner().GetBalance = %v, %v, want 2, nil", balance, err)
+	}
+
+	if current, err := client.Worker().GetCurrent(address, "rig1"); err != nil || current.EffectiveHashrate != 90 {
+		t.Errorf("Worker().GetCurrent = %+v, %v, want an effective hashrate of 90", current, err)
+	}
+
+	if online, err := client.Pool().GetMinersOnline(); err != nil || online != 7 {
+		t.Errorf("Pool().GetMinersOnline = %v, %v, want 7, nil", online, err)
+	}
+}
+
+func TestFake(t *testing.T) {
+	t.Run("Scripted", func(t *testing.T) {
+		fake := apitest.New()
+		fake.On("Miner.GetBalance").Return(1, nil)
+		fake.On("Miner.GetBalance", "0x2").Return(uint(5), nil)
+
+		total, err := totalBalance(fake.Miner(), []string{"0x1", "0x2", "0x3"})
+		if err != nil || total != 7 {
+			t.Errorf("totalBalance = %v, %v, want 7, nil", total, err)
+		}
+
+		fake.AssertCallCount(t, "Miner.GetBalance", 3)
+		fake.AssertCalled(t, "Miner.GetBalance", "0x2")
+		fake.AssertNotCalled(t, "Pool.GetBlocks")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		fake := apitest.New()
+		down := errors.New("down")
+		fake.On("Pool.GetBlocks").Return(api.PoolBlockData{TotalPages: 3}, nil)
+		fake.On("Pool.GetBlocks", 2).Return(nil, down)
+
+		if _, err := fake.Pool().GetBlocks(2); err != down {
+			t.Errorf("GetBlocks(2) returned %v, want the scripted error", err)
+		}
+
+		if blocks, err := fake.Pool().GetBlocks(1); err != nil || blocks.TotalPages != 3 {
+			t.Errorf("GetBlocks(1) = %+v, %v, want 3 total pages", blocks, err)
+		}
+
+		// Later responses take priority, so a new catch-all hides the error
+		fake.On("Pool.GetBlocks").Return(nil, nil)
+
+		if _, err := fake.Pool().GetBlocks(2); err != nil {
+			t.Errorf("GetBlocks(2) returned %v after a later catch-all, want nil", err)
+		}
+
+		if _, err := fake.Worker().GetStats("0x1", "rig1"); !errors.Is(err, apitest.ErrNotScripted) {
+			t.Errorf("GetStats returned %v unscripted, want ErrNotScripted", err)
+		}
+
+		fake.AssertCalled(t, "Worker.GetStats", "0x1", "rig1")
+	})
+
+	t.Run("Assertions", func(t *testing.T) {
+		fake := apitest.New()
+		fake.Pool().GetCurrentLuck()
+
+		r := &recorder{TB: t}
+		fake.AssertCalled(r, "Pool.GetCurrentLuck")
+		fake.AssertCalled(r, "Pool.GetHashrate")
+		fake.AssertNotCalled(r, "Pool.GetCurrentLuck")
+		fake.AssertCallCount(r, "Pool.GetCurrentLuck", 2)
+
+		if len(r.failures) != 3 {
+			t.Errorf("got %d assertion failures, want 3: %v", len(r.failures), r.failures)
+		}
+	})
+
+	t.Run("WrongType", func(t *testing.T) {
+		fake := apitest.New()
+		fake.On("Miner.GetDetails").Return("details", nil)
+
+		defer func() {
+			if recover() == nil {
+				t.Errorf("GetDetails didn't panic with a mistyped response")
+			}
+		}()
+
+		fake.Miner().GetDetails("0x1")
+	})
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/utils/blocks"
+)
+
+func TestBlocks(t *testing.T) {
+	// Newest first, as the API returns them
+	mined := []api.Block{
+		{Number: 106, Type: "block", ServerName: "eu1", Miner: "0xA", Timestamp: 6000, Confirmed: false, Luck: 0.5, RoundTime: 300, TotalRewards: 2100, BlockFees: 100},
+		{Number: 105, Type: "uncle", ServerName: "us1", Miner: "0xB", Timestamp: 5000, Confirmed: true, Luck: 2.0, RoundTime: 1200, TotalRewards: 1750},
+		{Number: 104, Type: "block", ServerName: "eu1", Miner: "0xb", Timestamp: 4000, Confirmed: true, Luck: 1.0, RoundTime: 600, TotalRewards: 2300, BlockFees: 300},
+		{Number: 103, Type: "block", ServerName: "us1", Miner: "0xA", Timestamp: 3000, Confirmed: true, Luck: 1.0, RoundTime: 900, TotalRewards: 2000},
+		{Number: 102, Type: "block", ServerName: "eu1", Miner: "0xC", Timestamp: 2000, Confirmed: true, Luck: 1.5, RoundTime: 480, TotalRewards: 2200, BlockFees: 200},
+	}
+
+	numbers := func(selected []api.Block) []uint {
+		var numbers []uint
+		for _, block := range selected {
+			numbers = append(numbers, block.Number)
+		}
+
+		return numbers
+	}
+
+	t.Run("Select", func(t *testing.T) {
+		confirmed := true
+
+		tests := []struct {
+			name   string
+			filter blocks.Filter
+			want   []uint
+		}{
+			{"All", blocks.Filter{}, []uint{106, 105, 104, 103, 102}},
+			{"Type", blocks.Filter{Type: blocks.TypeUncle}, []uint{105}},
+			{"Confirmed", blocks.Filter{Confirmed: &confirmed, Type: blocks.TypeBlock}, []uint{104, 103, 102}},
+			{"Server", blocks.Filter{ServerName: "eu1"}, []uint{106, 104, 102}},
+			{"Miner", blocks.Filter{Miner: "0xb"}, []uint{105, 104}},
+			{"Time", blocks.Filter{Since: time.Unix(3000, 0), Until: time.Unix(5000, 0)}, []uint{105, 104, 103}},
+			{"Number", blocks.Filter{MinNumber: 103, MaxNumber: 104}, []uint{104, 103}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				if got := numbers(blocks.Select(mined, test.filter)); !reflect.DeepEqual(got, test.want) {
+					t.Errorf("Select = %v, want %v", got, test.want)
+				}
+			})
+		}
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		tests := []struct {
+			key        string
+			descending bool
+			want       []uint
+		}{
+			{blocks.SortLuck, true, []uint{105, 102, 104, 103, 106}},
+			{blocks.SortReward, false, []uint{105, 103, 106, 102, 104}},
+			{blocks.SortRoundTime, true, []uint{105, 103, 104, 102, 106}},
+			{blocks.SortNumber, false, []uint{102, 103, 104, 105, 106}},
+		}
+
+		for _, test := range tests {
+			sorted := append([]api.Block(nil), mined...)
+
+			if err := blocks.Sort(sorted, test.key, test.descending); err != nil {
+				t.Fatalf("Sort(%s) failed with: %v", test.key, err)
+			}
+
+			if got := numbers(sorted); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Sort(%s, %v) = %v, want %v", test.key, test.descending, got, test.want)
+			}
+		}
+
+		if err := blocks.Sort(mined, "hash", false); err != blocks.ErrInvalidSortKey {
+			t.Errorf("Sort(hash) returned %v, want ErrInvalidSortKey", err)
+		}
+	})
+
+	t.Run("Aggregate", func(t *testing.T) {
+		stats := blocks.Aggregate(mined)
+
+		if len(stats) != 3 {
+			t.Fatalf("got %d rows, want eu1, us1 and all: %+v", len(stats), stats)
+		}
+
+		eu1 := blocks.Stats{ServerName: "eu1", Blocks: 3, Confirmed: 2, Unconfirmed: 1, TotalRewards: 6600, BlockFees: 600,
+			FeeShare: 600.0 / 6600, AverageLuck: 1, AverageRoundTime: 460}
+		if stats[0] != eu1 {
+			t.Errorf("eu1 = %+v, want %+v", stats[0], eu1)
+		}
+
+		us1 := blocks.Stats{ServerName: "us1", Blocks: 2, Uncles: 1, Confirmed: 2, TotalRewards: 3750, UncleRate: 0.5,
+			AverageLuck: 1.5, AverageRoundTime: 1050}
+		if stats[1] != us1 {
+			t.Errorf("us1 = %+v, want %+v", stats[1], us1)
+		}
+
+		if all := stats[2]; all.ServerName != blocks.AllServers || all.Blocks != 5 || all.UncleRate != 0.2 || all.TotalRewards != 10350 {
+			t.Errorf("all = %+v, want 5 blocks, a 0.2 uncle rate and 10350 gwei", all)
+		}
+	})
+
+	t.Run("Region", func(t *testing.T) {
+		for server, want := range map[string]string{"eu1": "eu", "US2": "us", "sa": "sa", "1": ""} {
+			if got := blocks.Region(server); got != want {
+				t.Errorf("Region(%q) = %q, want %q", server, got, want)
+			}
+		}
+	})
+
+	t.Run("AggregateRegions", func(t *testing.T) {
+		// The first point is before the oldest block, so it's left out of the averages
+		chart := []api.PoolHashrateChartData{
+			{Timestamp: 1000, Eu: 1000, Us: 1000, Total: 2000},
+			{Timestamp: 3000, Eu: 40, Us: 60, Total: 100},
+			{Timestamp: 5000, Eu: 60, Us: 40, Total: 100},
+		}
+
+		regions := blocks.AggregateRegions(mined, chart)
+
+		if len(regions) != 3 {
+			t.Fatalf("got %d rows, want eu, us and all: %+v", len(regions), regions)
+		}
+
+		eu := regions[0]
+		if eu.Region != blocks.RegionEurope || eu.Servers != "eu1" || eu.Blocks != 3 || eu.AverageRoundTime != 460 {
+			t.Errorf("eu = %+v, want the 3 blocks mined through eu1", eu)
+		}
+
+		if eu.AverageHashrate != 50 || eu.HashrateShare != 0.5 || eu.BlockShare != 0.6 || eu.Efficiency != 1.2 {
+			t.Errorf("eu = %+v, want 50 H/s, half the hashrate, 0.6 of the blocks and 1.2 efficiency", eu)
+		}
+
+		if us := regions[1]; us.Region != blocks.RegionUS || us.HashrateShare != 0.5 || us.Efficiency != 0.8 {
+			t.Errorf("us = %+v, want half the hashrate and 0.8 efficiency", us)
+		}
+
+		if all := regions[2]; all.Region != blocks.AllRegions || all.Servers != "eu1,us1" || all.Efficiency != 1 {
+			t.Errorf("all = %+v, want every server and an efficiency of 1", all)
+		}
+
+		// Without points in the blocks' period, the whole chart is used
+		if eu = blocks.AggregateRegions(mined, chart[:1])[0]; eu.AverageHashrate != 1000 || eu.HashrateShare != 0.5 {
+			t.Errorf("eu = %+v, want the whole chart's hashrate", eu)
+		}
+
+		if eu = blocks.AggregateRegions(mined, nil)[0]; eu.HashrateShare != 0 || eu.Efficiency != 0 || eu.BlockShare != 0.6 {
+			t.Errorf("eu = %+v, want no hashrate share or efficiency without a chart", eu)
+		}
+	})
+
+	t.Run("Fetch", func(t *testing.T) {
+		// Pages of two blocks each
+		var fetched []int
+		pages := func(page int) ([]api.Block, int, error) {
+			fetched = append(fetched, page)
+
+			end := page*2 + 2
+			if end > len(mined) {
+				end = len(mined)
+			}
+
+			return mined[page*2 : end], 3, nil
+		}
+
+		selected, err := blocks.Fetch(pages, 0, blocks.Filter{Type: blocks.TypeBlock})
+		if err != nil || !reflect.DeepEqual(numbers(selected), []uint{106, 104, 103, 102}) || len(fetched) != 3 {
+			t.Errorf("Fetch = %v, %v after pages %v, want every block from all 3 pages", numbers(selected), err, fetched)
+		}
+
+		fetched = nil
+
+		if selected, _ = blocks.Fetch(pages, 0, blocks.Filter{Since: time.Unix(4500, 0)}); len(fetched) != 2 {
+			t.Errorf("Fetch fetched pages %v, want to stop after page 1 reaches past Since", fetched)
+		}
+
+		if !reflect.DeepEqual(numbers(selected), []uint{106, 105}) {
+			t.Errorf("Fetch = %v, want 106 and 105", numbers(selected))
+		}
+
+		fetched = nil
+
+		if blocks.Fetch(pages, 1, blocks.Filter{}); len(fetched) != 1 {
+			t.Errorf("Fetch fetched pages %v, want only the first", fetched)
+		}
+
+		// No matches are an empty list, so they're written as [] rather than null
+		if selected, err = blocks.Fetch(pages, 0, blocks.Filter{MinNumber: 200}); err != nil || selected == nil || len(selected) != 0 {
+			t.Errorf("Fetch = %#v, %v, want an empty list", selected, err)
+		}
+
+		if _, err = blocks.Fetch(pages, 0, blocks.Filter{Type: "orphan"}); err != blocks.ErrInvalidType {
+			t.Errorf("Fetch returned %v for an invalid type, want ErrInvalidType", err)
+		}
+
+		failing := func(page int) ([]api.Block, int, error) {
+			return nil, 0, errors.New("down")
+		}
+
+		if _, err = blocks.Fetch(failing, 0, blocks.Filter{}); err == nil {
+			t.Errorf("Fetch succeeded when fetching a page failed")
+		}
+	})
+}
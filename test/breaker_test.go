@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+func TestBreaker(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	// Stand in for the flexpool API, dropping connections while down is set
+	var (
+		down     int32
+		requests int32
+	)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if atomic.LoadInt32(&down) == 1 {
+			panic(http.ErrAbortHandler)
+		}
+
+		w.Write([]byte(`{"error": null, "result": 2000000000}`))
+	}))
+	defer upstream.Close()
+
+	previous := *api.DefaultClient
+	defer func() { *api.DefaultClient = previous }()
+
+	// Each test starts with the API up and a fresh breaker
+	reset := func(options api.BreakerOptions) *api.CircuitBreaker {
+		atomic.StoreInt32(&down, 0)
+		atomic.StoreInt32(&requests, 0)
+
+		breaker := api.NewBreaker(options)
+
+		api.DefaultClient.Host = upstream.URL
+		api.DefaultClient.Middleware = []api.Middleware{breaker.Middleware}
+
+		return breaker
+	}
+
+	t.Run("Open", func(t *testing.T) {
+		var changes []string
+		breaker := reset(api.BreakerOptions{
+			Threshold: 3,
+			Cooldown:  time.Hour,
+			OnStateChange: func(from api.BreakerState, to api.BreakerState) {
+				changes = append(changes, from.String()+">"+to.String())
+			},
+		})
+
+		atomic.StoreInt32(&down, 1)
+
+		for i := 0; i < 3; i++ {
+			api.MinerGetBalance(address)
+		}
+
+		if state := breaker.State(); state != api.Open {
+			t.Fatalf("State() = %v after 3 failures, want open", state)
+		}
+
+		if _, err := api.MinerGetBalance(address); err != api.ErrCircuitOpen {
+			t.Errorf("MinerGetBalance returned %v while open, want ErrCircuitOpen", err)
+		}
+
+		if got := atomic.LoadInt32(&requests); got != 3 {
+			t.Errorf("upstream got %d requests, want 3", got)
+		}
+
+		if len(changes) != 1 || changes[0] != "closed>open" {
+			t.Errorf("state changes = %v, want [closed>open]", changes)
+		}
+	})
+
+	t.Run("Successes", func(t *testing.T) {
+		breaker := reset(api.BreakerOptions{Threshold: 2, Cooldown: time.Hour})
+
+		// A success between failures resets the count
+		for _, isDown := range []int32{1, 0, 1, 0} {
+			atomic.StoreInt32(&down, isDown)
+			api.MinerGetBalance(address)
+		}
+
+		if state := breaker.State(); state != api.Closed {
+			t.Errorf("State() = %v, want closed", state)
+		}
+	})
+
+	t.Run("HalfOpen", func(t *testing.T) {
+		var changes []string
+		breaker := reset(api.BreakerOptions{
+			Threshold: 1,
+			Cooldown:  20 * time.Millisecond,
+			OnStateChange: func(from api.BreakerState, to api.BreakerState) {
+				changes = append(changes, from.String()+">"+to.String())
+			},
+		})
+
+		// A failed trial opens the circuit again
+		atomic.StoreInt32(&down, 1)
+		api.MinerGetBalance(address)
+		time.Sleep(30 * time.Millisecond)
+
+		if state := breaker.State(); state != api.HalfOpen {
+			t.Errorf("State() = %v after the cool-down, want half-open", state)
+		}
+
+		api.MinerGetBalance(address)
+
+		if _, err := api.MinerGetBalance(address); err != api.ErrCircuitOpen {
+			t.Errorf("MinerGetBalance returned %v after a failed trial, want ErrCircuitOpen", err)
+		}
+
+		// A successful trial closes it
+		atomic.StoreInt32(&down, 0)
+		time.Sleep(30 * time.Millisecond)
+
+		if balance, err := api.MinerGetBalance(address); err != nil || balance != 2 {
+			t.Errorf("MinerGetBalance = %v, %v after the cool-down, want 2, nil", balance, err)
+		}
+
+		if state := breaker.State(); state != api.Closed {
+			t.Errorf("State() = %v after a successful trial, want closed", state)
+		}
+
+		want := []string{"closed>open", "open>half-open", "half-open>open", "open>half-open", "half-open>closed"}
+		if len(changes) != len(want) {
+			t.Fatalf("state changes = %v, want %v", changes, want)
+		}
+
+		for i := range want {
+			if changes[i] != want[i] {
+				t.Errorf("state changes = %v, want %v", changes, want)
+				break
+			}
+		}
+	})
+
+	t.Run("ServeStale", func(t *testing.T) {
+		reset(api.BreakerOptions{Threshold: 1, Cooldown: time.Hour, ServeStale: true})
+
+		if _, err := api.MinerGetBalance(address); err != nil {
+			t.Fatalf("MinerGetBalance failed with: %v", err)
+		}
+
+		atomic.StoreInt32(&down, 1)
+		api.MinerGetBalance(address)
+
+		if balance, err := api.MinerGetBalance(address); err != nil || balance != 2 {
+			t.Errorf("MinerGetBalance = %v, %v while open, want the cached 2, nil", balance, err)
+		}
+
+		// Nothing is cached for other URLs
+		if _, err := api.MinerGetRoundShare(address); err != api.ErrCircuitOpen {
+			t.Errorf("MinerGetRoundShare returned %v while open, want ErrCircuitOpen", err)
+		}
+	})
+
+	t.Run("StaleEntries", func(t *testing.T) {
+		reset(api.BreakerOptions{Threshold: 1, Cooldown: time.Hour, ServeStale: true, StaleEntries: 1})
+
+		api.MinerGetBalance(address)
+		api.MinerGetRoundShare(address)
+
+		atomic.StoreInt32(&down, 1)
+		api.MinerGetRoundShare(address)
+
+		// Only the most recently used result is kept
+		if _, err := api.MinerGetBalance(address); err != api.ErrCircuitOpen {
+			t.Errorf("MinerGetBalance returned %v while open, want ErrCircuitOpen for the dropped result", err)
+		}
+
+		if _, err := api.MinerGetRoundShare(address); err != nil {
+			t.Errorf("MinerGetRoundShare failed with %v while open, want the cached result", err)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		breaker := reset(api.BreakerOptions{Threshold: 1, Cooldown: 20 * time.Millisecond})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		client := api.DefaultClient.WithContext(ctx)
+
+		// Cancelled calls don't count as failures
+		for i := 0; i < 3; i++ {
+			if _, err := client.Miner().GetBalance(address); !errors.Is(err, context.Canceled) {
+				t.Errorf("GetBalance returned %v, want context.Canceled", err)
+			}
+		}
+
+		if state := breaker.State(); state != api.Closed {
+			t.Fatalf("State() = %v after cancelled calls, want closed", state)
+		}
+
+		// Nor does a cancelled trial, which leaves the next call free to be the trial
+		atomic.StoreInt32(&down, 1)
+		api.MinerGetBalance(address)
+		time.Sleep(30 * time.Millisecond)
+
+		client.Miner().GetBalance(address)
+		atomic.StoreInt32(&down, 0)
+
+		if _, err := api.MinerGetBalance(address); err != nil {
+			t.Errorf("MinerGetBalance failed with %v after a cancelled trial, want a new trial", err)
+		}
+
+		if state := breaker.State(); state != api.Closed {
+			t.Errorf("State() = %v after a successful trial, want closed", state)
+		}
+	})
+}
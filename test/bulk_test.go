@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/apitest"
+	"github.com/cryptogenic/goflexpool/pkg/ratelimit"
+)
+
+// slowWorkers is a WorkerService that takes a while to answer, recording the most calls it had in flight at once.
+type slowWorkers struct {
+	api.WorkerService
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (s *slowWorkers) GetStats(address string, worker string) (api.WorkerStats, error) {
+	s.mu.Lock()
+	s.inFlight++
+	if s.inFlight > s.maxInFlight {
+		s.maxInFlight = s.inFlight
+	}
+	s.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+
+	return s.WorkerService.GetStats(address, worker)
+}
+
+func TestWorkerDetails(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	// Scripts a fake with count workers, every other one offline
+	newFake := func(count int) *apitest.Fake {
+		fake := apitest.New()
+
+		var workers []api.MinerWorker
+		for i := 0; i < count; i++ {
+			workers = append(workers, api.MinerWorker{Name: fmt.Sprintf("rig%d", i), Online: i%2 == 0})
+		}
+
+		fake.On("Miner.GetWorkers", address).Return(workers, nil)
+		fake.On("Worker.GetStats").Return(api.WorkerStats{Daily: api.WorkerDailyStats{ValidShares: 100}}, nil)
+		fake.On("Worker.GetChart").Return([]api.WorkerChartData{{ValidShares: 10}}, nil)
+
+		return fake
+	}
+
+	t.Run("All", func(t *testing.T) {
+		fake := newFake(4)
+		fake.On("Worker.GetChart", address, "rig3").Return(nil, errors.New("down"))
+
+		details, err := api.GetWorkerDetails(context.Background(), fake.Miner(), fake.Worker(), address, api.WorkerDetailsOptions{})
+		if err != nil {
+			t.Fatalf("GetWorkerDetails failed with: %v", err)
+		}
+
+		if len(details) != 4 {
+			t.Fatalf("got %d workers, want 4", len(details))
+		}
+
+		if rig0 := details["rig0"]; rig0.Err != nil || rig0.Stats.Daily.ValidShares != 100 || len(rig0.Chart) != 1 || !rig0.Worker.Online {
+			t.Errorf("rig0 = %+v, want its stats and chart", rig0)
+		}
+
+		if rig3 := details["rig3"]; rig3.Err == nil || rig3.Err.Error() != "chart: down" || rig3.Stats.Daily.ValidShares != 100 {
+			t.Errorf("rig3 = %+v, want its stats and a chart error", rig3)
+		}
+
+		fake.AssertCallCount(t, "Worker.GetStats", 4)
+		fake.AssertCalled(t, "Worker.GetChart", address, "rig2")
+	})
+
+	t.Run("SkipOffline", func(t *testing.T) {
+		fake := newFake(4)
+
+		details, err := api.GetWorkerDetails(context.Background(), fake.Miner(), fake.Worker(), address,
+			api.WorkerDetailsOptions{SkipOffline: true})
+		if err != nil {
+			t.Fatalf("GetWorkerDetails failed with: %v", err)
+		}
+
+		if _, ok := details["rig1"]; ok || len(details) != 2 {
+			t.Errorf("got workers %v, want only rig0 and rig2", details)
+		}
+
+		fake.AssertCallCount(t, "Worker.GetStats", 2)
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		fake := newFake(12)
+		workers := &slowWorkers{WorkerService: fake.Worker()}
+
+		if _, err := api.GetWorkerDetails(context.Background(), fake.Miner(), workers, address,
+			api.WorkerDetailsOptions{Concurrency: 3}); err != nil {
+			t.Fatalf("GetWorkerDetails failed with: %v", err)
+		}
+
+		if workers.maxInFlight != 3 {
+			t.Errorf("%d requests were in flight at once, want 3", workers.maxInFlight)
+		}
+	})
+
+	t.Run("Limiter", func(t *testing.T) {
+		fake := newFake(2)
+
+		// A single token is used by the worker list, so every worker's requests wait until the context is done
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		details, err := api.GetWorkerDetails(ctx, fake.Miner(), fake.Worker(), address,
+			api.WorkerDetailsOptions{Limiter: ratelimit.New(0.001, 1)})
+		if err != nil {
+			t.Fatalf("GetWorkerDetails failed with: %v", err)
+		}
+
+		for name, worker := range details {
+			if worker.Err == nil {
+				t.Errorf("%s fetched without waiting for the limiter", name)
+			}
+		}
+
+		fake.AssertNotCalled(t, "Worker.GetStats")
+	})
+
+	t.Run("WorkerListError", func(t *testing.T) {
+		fake := apitest.New()
+		fake.On("Miner.GetWorkers").Return(nil, errors.New("down"))
+
+		if _, err := api.GetWorkerDetails(context.Background(), fake.Miner(), fake.Worker(), address,
+			api.WorkerDetailsOptions{}); err == nil {
+			t.Errorf("GetWorkerDetails succeeded without a worker list")
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/cache"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("Expiry", func(t *testing.T) {
+		c := cache.New(20 * time.Millisecond)
+		fetches := 0
+		fetch := func() (interface{}, error) {
+			fetches++
+			return fetches, nil
+		}
+
+		for _, want := range []cache.Status{cache.Miss, cache.Hit} {
+			if value, status, _ := c.Fetch("key", fetch); value != 1 || status != want {
+				t.Errorf("Fetch = %v, %v, want 1, %v", value, status, want)
+			}
+		}
+
+		time.Sleep(30 * time.Millisecond)
+
+		if value, status, _ := c.Fetch("key", fetch); value != 2 || status != cache.Miss {
+			t.Errorf("Fetch after expiry = %v, %v, want 2, Miss", value, status)
+		}
+	})
+
+	t.Run("Coalescing", func(t *testing.T) {
+		c := cache.New(time.Minute)
+		release := make(chan struct{})
+
+		var fetches int64
+		fetch := func() (interface{}, error) {
+			atomic.AddInt64(&fetches, 1)
+			<-release
+			return "value", nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if value, err := c.Get("key", fetch); value != "value" || err != nil {
+					t.Errorf("Get = %v, %v", value, err)
+				}
+			}()
+		}
+
+		// Give every goroutine time to join the in-flight fetch before it completes
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if fetches := atomic.LoadInt64(&fetches); fetches != 1 {
+			t.Errorf("fetch called %d times, want 1", fetches)
+		}
+
+		if stats := c.Stats(); stats.Misses != 1 || stats.Coalesced != 9 || stats.Entries != 1 {
+			t.Errorf("Stats = %+v", stats)
+		}
+	})
+
+	t.Run("ErrorsNotCached", func(t *testing.T) {
+		c := cache.New(time.Minute)
+		failure := errors.New("upstream down")
+
+		if _, err := c.Get("key", func() (interface{}, error) { return nil, failure }); err != failure {
+			t.Errorf("Get error = %v, want %v", err, failure)
+		}
+
+		if value, _ := c.Get("key", func() (interface{}, error) { return "ok", nil }); value != "ok" {
+			t.Errorf("Get after error = %v, want ok", value)
+		}
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		c := cache.NewWithStale(10*time.Millisecond, time.Minute)
+		failure := errors.New("upstream down")
+
+		c.Get("key", func() (interface{}, error) { return "old", nil })
+		time.Sleep(20 * time.Millisecond)
+
+		value, status, err := c.Fetch("key", func() (interface{}, error) { return nil, failure })
+		if value != "old" || status != cache.Stale || err != nil {
+			t.Errorf("Fetch = %v, %v, %v, want old, Stale, nil", value, status, err)
+		}
+
+		if value, status, _ := c.Fetch("key", func() (interface{}, error) { return "new", nil }); value != "new" || status != cache.Miss {
+			t.Errorf("Fetch after recovery = %v, %v, want new, Miss", value, status)
+		}
+	})
+}
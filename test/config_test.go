@@ -0,0 +1,239 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/config"
+)
+
+func TestConfig(t *testing.T) {
+	const home = "0x0000000000000000000000000000000000000001"
+
+	t.Run("Parse", func(t *testing.T) {
+		data := []byte(`{
+  "addresses": {"home": "` + home + `"},
+  "default_address": "home",
+  "groups": [{"name": "rack-A", "patterns": ["rackA-*"]}],
+  "thresholds": {"max_stale_ratio": 0.1},
+  "electricity": {"watts_per_worker": 250}
+}`)
+
+		cfg, err := config.Parse("config.json", data)
+		if err != nil {
+			t.Fatalf("Parse failed with: %v", err)
+		}
+
+		if cfg.Address("") != home || cfg.Address("home") != home || cfg.Address("0xabc") != "0xabc" {
+			t.Errorf("addresses resolved incorrectly: %q, %q, %q", cfg.Address(""), cfg.Address("home"), cfg.Address("0xabc"))
+		}
+
+		// Values missing from the file keep their defaults
+		if cfg.Thresholds.MaxStaleRatio != 0.1 || cfg.Thresholds.MaxInvalidRatio != config.Default().Thresholds.MaxInvalidRatio {
+			t.Errorf("thresholds = %+v", cfg.Thresholds)
+		}
+	})
+
+	t.Run("ParseErrors", func(t *testing.T) {
+		data := []byte(`{
+  "addresses": {"home": "0x12"},
+  "groups": [
+    {"name": "rack-A", "patterns": ["[bad"]}
+  ],
+  "api": {"timeout": "soon", "retries": 3}
+}`)
+
+		_, err := config.Parse("config.json", data)
+
+		problems, ok := err.(config.Errors)
+		if !ok {
+			t.Fatalf("Parse error = %v, want config.Errors", err)
+		}
+
+		want := []string{
+			`config.json:2:17: addresses.home: "0x12" is not a valid address`,
+			`config.json:4:37: groups[0].patterns[0]: invalid pattern "[bad"`,
+			`config.json:6:11: api.timeout: "soon" is not a valid duration`,
+			`config.json:6:30: api.retries: unknown field`,
+		}
+
+		if len(problems) != len(want) {
+			t.Fatalf("Parse found %d problems, want %d:\n%v", len(problems), len(want), err)
+		}
+
+		for i := range want {
+			if problems[i].Error() != want[i] {
+				t.Errorf("problem %d = %q, want %q", i, problems[i].Error(), want[i])
+			}
+		}
+	})
+
+	t.Run("OutputFormat", func(t *testing.T) {
+		for _, value := range []string{"", "text", "table", "json", "csv", "yaml"} {
+			if _, err := config.Parse("config.json", []byte(`{"output": {"format": "`+value+`"}}`)); err != nil {
+				t.Errorf("Parse rejected output format %q: %v", value, err)
+			}
+		}
+
+		if _, err := config.Parse("config.json", []byte(`{"output": {"format": "xml"}}`)); err == nil {
+			t.Errorf("Parse accepted output format xml")
+		}
+	})
+
+	t.Run("ParseSyntaxError", func(t *testing.T) {
+		_, err := config.Parse("config.json", []byte("{\n  \"api\": {\n    \"host\": 5\n  }\n}"))
+
+		problems, ok := err.(config.Errors)
+		if !ok || len(problems) != 1 || problems[0].Line != 3 || problems[0].Path != "api.host" {
+			t.Errorf("Parse error = %v, want a type error on line 3", err)
+		}
+	})
+
+	t.Run("ParseYAML", func(t *testing.T) {
+		data := []byte(`addresses:
+  home: "` + home + `"
+default_address: home
+groups:
+  - name: rack-A
+    patterns: ["rackA-*"]
+thresholds:
+  max_stale_ratio: 0.1
+`)
+
+		cfg, err := config.ParseYAML("config.yaml", data)
+		if err != nil {
+			t.Fatalf("ParseYAML failed with: %v", err)
+		}
+
+		if cfg.Address("") != home || len(cfg.Groups) != 1 || cfg.Groups[0].Patterns[0] != "rackA-*" {
+			t.Errorf("config = %+v, want the YAML values", cfg)
+		}
+
+		if cfg.Thresholds.MaxStaleRatio != 0.1 || cfg.Thresholds.MaxInvalidRatio != config.Default().Thresholds.MaxInvalidRatio {
+			t.Errorf("thresholds = %+v", cfg.Thresholds)
+		}
+
+		if cfg, err = config.ParseYAML("config.yaml", nil); err != nil || cfg.API.Host != config.Default().API.Host {
+			t.Errorf("ParseYAML of an empty file = %+v, %v, want the defaults", cfg, err)
+		}
+	})
+
+	t.Run("ParseYAMLErrors", func(t *testing.T) {
+		data := []byte(`addresses:
+  home: "0x12"
+groups:
+  - name: rack-A
+    patterns: ["[bad"]
+api:
+  timeout: soon
+  retries: 3
+`)
+
+		_, err := config.ParseYAML("config.yaml", data)
+
+		problems, ok := err.(config.Errors)
+		if !ok {
+			t.Fatalf("ParseYAML error = %v, want config.Errors", err)
+		}
+
+		want := []string{
+			`config.yaml:2:3: addresses.home: "0x12" is not a valid address`,
+			`config.yaml:5:16: groups[0].patterns[0]: invalid pattern "[bad"`,
+			`config.yaml:7:3: api.timeout: "soon" is not a valid duration`,
+			`config.yaml:8:3: api.retries: unknown field`,
+		}
+
+		if len(problems) != len(want) {
+			t.Fatalf("ParseYAML found %d problems, want %d:\n%v", len(problems), len(want), err)
+		}
+
+		for i := range want {
+			if problems[i].Error() != want[i] {
+				t.Errorf("problem %d = %q, want %q", i, problems[i].Error(), want[i])
+			}
+		}
+
+		_, err = config.ParseYAML("config.yaml", []byte("api:\n  host: [1]\n"))
+		if problems, ok = err.(config.Errors); !ok || len(problems) != 1 || problems[0].Line != 2 || problems[0].Path != "api.host" {
+			t.Errorf("ParseYAML error = %v, want a type error on line 2", err)
+		}
+
+		if _, err = config.ParseYAML("config.yaml", []byte("api: [\n")); err == nil {
+			t.Error("ParseYAML accepted invalid YAML")
+		}
+	})
+
+	t.Run("LoadYAML", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "config.yml")
+		if err := ioutil.WriteFile(name, []byte("default_address: \""+home+"\"\n"), 0600); err != nil {
+			t.Fatalf("WriteFile failed with: %v", err)
+		}
+
+		if cfg, err := config.Load(name); err != nil || cfg.DefaultAddress != home {
+			t.Errorf("Load(%q) = %+v, %v, want the YAML config", name, cfg, err)
+		}
+	})
+
+	t.Run("ApplyAPI", func(t *testing.T) {
+		previous := api.DefaultClient.HTTPClient
+		defer func() { api.DefaultClient.HTTPClient = previous }()
+
+		transport := &http.Transport{}
+		httpClient := &http.Client{Transport: transport}
+		api.DefaultClient.HTTPClient = httpClient
+
+		// Without a timeout the client is left alone
+		cfg := config.Default()
+		cfg.ApplyAPI()
+
+		if api.DefaultClient.HTTPClient != httpClient {
+			t.Errorf("HTTPClient = %+v, want it unchanged", api.DefaultClient.HTTPClient)
+		}
+
+		cfg.API.Timeout = "5s"
+		cfg.ApplyAPI()
+
+		if got := api.DefaultClient.HTTPClient; got.Timeout != 5*time.Second || got.Transport != transport {
+			t.Errorf("HTTPClient = %+v, want a 5s timeout and the same transport", got)
+		}
+
+		if httpClient.Timeout != 0 {
+			t.Errorf("original HTTPClient timeout = %v, want it unchanged", httpClient.Timeout)
+		}
+	})
+
+	t.Run("ApplyEnv", func(t *testing.T) {
+		env := map[string]string{
+			"FLEXPOOL_ADDRESS":       home,
+			"FLEXPOOL_PRICE_PER_KWH": "0.25",
+		}
+
+		cfg := config.Default()
+		if err := cfg.ApplyEnv(func(name string) string { return env[name] }); err != nil {
+			t.Fatalf("ApplyEnv failed with: %v", err)
+		}
+
+		if cfg.DefaultAddress != home || cfg.Electricity.PricePerKWh != 0.25 {
+			t.Errorf("overrides not applied: %+v", cfg)
+		}
+
+		env["FLEXPOOL_API_TIMEOUT"] = "soon"
+		env["FLEXPOOL_ETH_PRICE"] = "lots"
+
+		if err := cfg.ApplyEnv(func(name string) string { return env[name] }); err == nil {
+			t.Errorf("ApplyEnv accepted invalid values")
+		}
+	})
+
+	t.Run("Path", func(t *testing.T) {
+		for _, args := range [][]string{{"-config", "a.json"}, {"--config=a.json"}, {"miner", "balance", "--config", "a.json"}} {
+			if name, explicit := config.Path(args); name != "a.json" || !explicit {
+				t.Errorf("Path(%q) = %q, %v", args, name, explicit)
+			}
+		}
+	})
+}
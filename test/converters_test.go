@@ -0,0 +1,90 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+
+	"github.com/cryptogenic/goflexpool/pkg/utils"
+)
+
+// exponents maps each HashrateUnit to its power of 10 in hashes per second.
+var exponents = map[utils.HashrateUnit]int{
+	utils.HashesPerSecond:     utils.BasePow10Exponential,
+	utils.KiloHashesPerSecond: utils.KiloPow10Exponential,
+	utils.MegaHashesPerSecond: utils.MegaPow10Exponential,
+	utils.GigaHashesPerSecond: utils.GigaPow10Exponential,
+	utils.TeraHashesPerSecond: utils.TeraPow10Exponential,
+	utils.PetaHashesPerSecond: utils.PetaPow10Exponential,
+}
+
+func TestConverters(t *testing.T) {
+	t.Run("HashrateRoundTrip", func(t *testing.T) {
+		// Converting to another unit and back returns the input exactly when the other unit is finer, and truncates it
+		// to a multiple of the coarser unit otherwise.
+		property := func(input uint32, from uint8, to uint8) bool {
+			fromUnit := utils.HashrateUnit(from % 6)
+			toUnit := utils.HashrateUnit(to % 6)
+
+			// Keep the value in hashes per second from overflowing, as 9999 PH/s is just under 2^64 H/s
+			hashrate := uint(input) % 10000
+
+			back := utils.ConvertHashrate(utils.ConvertHashrate(hashrate, fromUnit, toUnit), toUnit, fromUnit)
+
+			if exponents[toUnit] <= exponents[fromUnit] {
+				return back == hashrate
+			}
+
+			step := uint(math.Pow10(exponents[toUnit] - exponents[fromUnit]))
+			return back == hashrate/step*step
+		}
+
+		if err := quick.Check(property, nil); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("GweiRoundTrip", func(t *testing.T) {
+		// Gwei survives a round trip through eth to within 1 gwei, or a relative 1e-15 for amounts above 1e15 gwei
+		property := func(gwei uint64) bool {
+			input := uint(gwei % 1e18)
+			back := utils.ConvertEthToGwei(utils.ConvertGweiToEth(input))
+
+			tolerance := math.Max(1, float64(input)*1e-15)
+			return math.Abs(float64(back)-float64(input)) <= tolerance
+		}
+
+		if err := quick.Check(property, nil); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("EthRoundTrip", func(t *testing.T) {
+		// Eth survives a round trip through gwei to within the 1 gwei truncation, plus float rounding
+		property := func(eth float64) bool {
+			eth = math.Mod(math.Abs(eth), 1e6)
+			back := utils.ConvertGweiToEth(utils.ConvertEthToGwei(eth))
+
+			return back <= eth+eth*1e-15 && eth-back <= 1e-9+eth*1e-15
+		}
+
+		if err := quick.Check(property, nil); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Monotonic", func(t *testing.T) {
+		// A larger amount of gwei is never worth less eth
+		property := func(a uint32, b uint32) bool {
+			if a > b {
+				a, b = b, a
+			}
+
+			return utils.ConvertGweiToEth(uint(a)) <= utils.ConvertGweiToEth(uint(b))
+		}
+
+		if err := quick.Check(property, nil); err != nil {
+			t.Error(err)
+		}
+	})
+}
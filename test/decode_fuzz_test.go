@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+// Result shapes returned by each endpoint, used to seed the fuzz targets.
+const (
+	seedBlock = `{"hash": "0xabc", "number": 12000000, "type": "block", "miner": "0x0", "difficulty": 4000000000000000,
+		"timestamp": 1614556800, "confirmed": true, "round_time": 720, "luck": 1.2, "server_name": "eu1",
+		"block_reward": 2000000000000000000, "block_fees": 100000000000000000, "uncle_inclusion_rewards": 0,
+		"total_rewards": 2100000000000000000}`
+	seedChartPoint = `{"timestamp": 1614556800, "effective_hashrate": 90000000, "average_effective_hashrate": 95000000.5,
+		"reported_hashrate": 100000000, "valid_shares": 100, "stale_shares": 2, "invalid_shares": 1}`
+	seedCurrent = `{"effective_hashrate": 90000000, "reported_hashrate": 100000000}`
+	seedDaily   = `{"effective_hashrate": 90000000, "reported_hashrate": 100000000, "valid_shares": 100, "stale_shares": 2,
+		"invalid_shares": 1}`
+	seedHashrate = `{"as": 1, "au": 2, "eu": 3, "sa": 4, "us": 5, "total": 15}`
+)
+
+var (
+	minerSeeds = []string{
+		`2000000000`,
+		seedCurrent,
+		seedDaily,
+		`{"current": ` + seedCurrent + `, "daily": ` + seedDaily + `}`,
+		`{"online": 3, "offline": 1}`,
+		`[{"name": "rig1", "online": true, "duplicate_workers_merged": 0, "reported_hashrate": 100000000,
+			"effective_hashrate": 90000000, "valid_shares": 100, "stale_shares": 2, "invalid_shares": 1,
+			"last_seen": 1614556800}]`,
+		`null`,
+		`[` + seedChartPoint + `]`,
+		`{"data": [{"txid": "0xdef", "amount": 100000000000000000, "timestamp": 1614556800, "duration": 86400}],
+			"items_per_page": 10, "total_items": 1, "total_pages": 1}`,
+		`{"data": null, "items_per_page": 10, "total_items": 0, "total_pages": 0}`,
+		`[{"amount": 100000000000000000, "timestamp": 1614556800}]`,
+		`{"data": [` + seedBlock + `], "items_per_page": 10, "total_items": 1, "total_pages": 1}`,
+		`{"min_payout_threshold": 100000000000000000, "pool_donation": 0.01, "max_fee_price": 100,
+			"censored_email": "a***@example.com", "censored_ip": "1.2.*.*", "first_joined": 1614556800}`,
+		`0.05`,
+	}
+
+	workerSeeds = []string{
+		seedCurrent,
+		seedDaily,
+		`{"current": ` + seedCurrent + `, "daily": ` + seedDaily + `}`,
+		`[` + seedChartPoint + `]`,
+	}
+
+	poolSeeds = []string{
+		seedHashrate,
+		`[{"as": 1, "au": 2, "eu": 3, "sa": 4, "us": 5, "total": 15, "timestamp": 1614556800}]`,
+		`250`,
+		`{"data": [` + seedBlock + `], "items_per_page": 10, "total_items": 1, "total_pages": 1}`,
+		`{"confirmed": 10, "unconfirmed": 2}`,
+		`[{"address": "0x0", "hashrate": 100000000, "total_workers": 3, "balance": 2000000000, "pool_donation": 0.01,
+			"first_joined": 1614556800}]`,
+		`[{"address": "0x0", "pool_donation": 0.01, "total_donated": 2000000000, "first_joined": 1614556800}]`,
+		`{"luck": 1.1, "round_time": 700.5}`,
+		`1.05`,
+	}
+
+	// Malformed shapes that used to panic the decoders
+	malformedSeeds = []string{
+		`{"error": null}`,
+		`{"error": null, "result": "text"}`,
+		`{"error": null, "result": {"data": [1, 2]}}`,
+		`{"error": null, "result": [null]}`,
+		`{"error": null, "result": {"current": []}}`,
+		`{"error": {"code": 400, "message": "invalid address"}, "result": null}`,
+		`[]`,
+		`not json`,
+	}
+)
+
+// bodyTransport is an http.RoundTripper answering every request with the same body.
+type bodyTransport []byte
+
+func (b bodyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		Request:    r,
+	}, nil
+}
+
+// fuzzClient returns a client answering every request with body.
+func fuzzClient(body []byte) *api.Client {
+	return &api.Client{Host: "http://flexpool.test/api/v1", HTTPClient: &http.Client{Transport: bodyTransport(body)}}
+}
+
+// addSeeds adds each result shape to f wrapped in a response, along with the malformed seeds.
+func addSeeds(f *testing.F, results []string) {
+	for _, result := range results {
+		f.Add([]byte(`{"error": null, "result": ` + result + `}`))
+	}
+
+	for _, body := range malformedSeeds {
+		f.Add([]byte(body))
+	}
+}
+
+// Every endpoint's decoder must either decode the body or return an error, and never panic.
+
+func FuzzMinerResponses(f *testing.F) {
+	addSeeds(f, minerSeeds)
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		miner := fuzzClient(body).Miner()
+
+		miner.GetBalance("0x0")
+		miner.GetCurrent("0x0")
+		miner.GetDaily("0x0")
+		miner.GetStats("0x0")
+		miner.GetWorkerCount("0x0")
+		miner.GetWorkers("0x0")
+		miner.GetChart("0x0")
+		miner.GetPayments("0x0", 0)
+		miner.GetPaymentCount("0x0")
+		miner.GetPaymentChart("0x0")
+		miner.GetBlocks("0x0", 0)
+		miner.GetBlockCount("0x0")
+		miner.GetDetails("0x0")
+		miner.GetEstimatedDailyRevenue("0x0")
+		miner.GetRoundShare("0x0")
+		miner.GetTotalPaid("0x0")
+		miner.GetTotalDonated("0x0")
+	})
+}
+
+func FuzzWorkerResponses(f *testing.F) {
+	addSeeds(f, workerSeeds)
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		worker := fuzzClient(body).Worker()
+
+		worker.GetCurrent("0x0", "rig1")
+		worker.GetDaily("0x0", "rig1")
+		worker.GetStats("0x0", "rig1")
+		worker.GetChart("0x0", "rig1")
+	})
+}
+
+func FuzzPoolResponses(f *testing.F) {
+	addSeeds(f, poolSeeds)
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		pool := fuzzClient(body).Pool()
+
+		pool.GetHashrate()
+		pool.GetHashrateChart()
+		pool.GetMinersOnline()
+		pool.GetWorkersOnline()
+		pool.GetBlocks(0)
+		pool.GetBlockCount()
+		pool.GetTopMiners()
+		pool.GetTopDonators()
+		pool.GetAverageLuckRoundTime()
+		pool.GetCurrentLuck()
+		pool.GetAverageBlockReward()
+	})
+}
+
+func TestDecoders(t *testing.T) {
+	t.Run("Seeds", func(t *testing.T) {
+		// The real shapes should decode without errors
+		miner := fuzzClient([]byte(`{"error": null, "result": {"data": [` + seedBlock + `], "items_per_page": 10,
+			"total_items": 1, "total_pages": 1}}`)).Miner()
+
+		blocks, err := miner.GetBlocks("0x0", 0)
+		if err != nil {
+			t.Fatalf("GetBlocks failed with: %v", err)
+		}
+
+		if len(blocks.Data) != 1 || blocks.Data[0].BlockReward != 2000000000 || blocks.Data[0].ServerName != "eu1" {
+			t.Errorf("GetBlocks = %+v, want one block with a 2000000000 gwei reward from eu1", blocks)
+		}
+
+		worker := fuzzClient([]byte(`{"error": null, "result": {"current": ` + seedCurrent + `, "daily": ` + seedDaily + `}}`)).Worker()
+
+		stats, err := worker.GetStats("0x0", "rig1")
+		if err != nil || stats.Daily.ValidShares != 100 {
+			t.Errorf("GetStats = %+v, %v, want 100 valid shares", stats, err)
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		minersOnline := func(client *api.Client) error {
+			_, err := client.Pool().GetMinersOnline()
+			return err
+		}
+
+		blocks := func(client *api.Client) error {
+			_, err := client.Pool().GetBlocks(0)
+			return err
+		}
+
+		workers := func(client *api.Client) error {
+			_, err := client.Miner().GetWorkers("0x0")
+			return err
+		}
+
+		// An API error is reported as one, rather than as an unexpected or empty result
+		apiError := malformedSeeds[5]
+
+		tests := []struct {
+			name string
+			body string
+			call func(client *api.Client) error
+			want string
+		}{
+			{"MissingResult", `{"error": null}`, minersOnline, "api: unexpected response: result is missing, want number"},
+			{"WrongType", `{"error": null, "result": "text"}`, minersOnline, "api: unexpected response: result is string, want number"},
+			{"NestedField", `{"error": null, "result": {"data": [{"hash": 1}]}}`, blocks,
+				"api: unexpected response: result.data[0].hash is float64, want string"},
+			{"MissingList", `{"error": null}`, workers, "api: unexpected response: result is missing, want array"},
+			{"APIError", apiError, workers, "api: invalid address (code 400, status 200)"},
+			{"APIErrorScalar", apiError, minersOnline, "api: invalid address (code 400, status 200)"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				err := test.call(fuzzClient([]byte(test.body)))
+
+				if err == nil || err.Error() != test.want {
+					t.Errorf("got error %v, want %s", err, test.want)
+				}
+			})
+		}
+
+		// A null list is empty, like a null nested array
+		if list, err := fuzzClient([]byte(`{"error": null, "result": null}`)).Miner().GetWorkers("0x0"); err != nil || len(list) != 0 {
+			t.Errorf("null list = %v, %v, want an empty list", list, err)
+		}
+
+		var apiErr *api.APIError
+		if err := workers(fuzzClient([]byte(apiError))); !errors.As(err, &apiErr) || apiErr.Code != 400 {
+			t.Errorf("got error %#v, want an APIError with code 400", err)
+		}
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		// An upstream outage, which used to come back as empty lists
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"error": {"code": 502, "message": "bad gateway"}, "result": null}`))
+		}))
+		defer upstream.Close()
+
+		client := &api.Client{Host: upstream.URL}
+
+		calls := map[string]func() error{
+			"GetWorkers":   func() error { _, err := client.Miner().GetWorkers("0x0"); return err },
+			"GetChart":     func() error { _, err := client.Worker().GetChart("0x0", "rig1"); return err },
+			"GetTopMiners": func() error { _, err := client.Pool().GetTopMiners(); return err },
+		}
+
+		for name, call := range calls {
+			var apiErr *api.APIError
+			if err := call(); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway || apiErr.Code != 502 {
+				t.Errorf("%s returned %v, want an APIError with status 502", name, err)
+			}
+		}
+
+		// A non-JSON error page still reports the status
+		upstream.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "<html>down</html>", http.StatusServiceUnavailable)
+		})
+
+		if err := calls["GetWorkers"](); err == nil || err.Error() != "api: request failed with status 503" {
+			t.Errorf("GetWorkers returned %v, want the 503 status", err)
+		}
+	})
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/feed"
+)
+
+func TestFeed(t *testing.T) {
+	const (
+		address = "0x0000000000000000000000000000000000000001"
+		failing = "0x0000000000000000000000000000000000000002"
+	)
+
+	// Stand in for the flexpool API. A second worker comes online after the first poll, and the pool hashrate goes up
+	// on every poll. The failing address has two workers, then fails, then has none.
+	var workerPolls, hashratePolls, failingPolls int64
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		worker := `{"name": "%s", "online": true, "duplicate_workers_merged": 0, "reported_hashrate": 100,
+			"effective_hashrate": 90, "valid_shares": 10, "stale_shares": 1, "invalid_shares": 0, "last_seen": 1600000000}`
+
+		result := "null"
+
+		switch r.URL.Path {
+		case "/miner/" + address + "/workers":
+			result = "[" + fmt.Sprintf(worker, "rig01") + "]"
+			if atomic.AddInt64(&workerPolls, 1) > 1 {
+				result = "[" + fmt.Sprintf(worker, "rig01") + ", " + fmt.Sprintf(worker, "rig02") + "]"
+			}
+		case "/pool/hashrate":
+			result = fmt.Sprintf(`{"as": 0, "au": 0, "eu": 0, "sa": 0, "us": 0, "total": %d}`, atomic.AddInt64(&hashratePolls, 1))
+		case "/miner/" + failing + "/workers":
+			switch atomic.AddInt64(&failingPolls, 1) {
+			case 1:
+				result = "[" + fmt.Sprintf(worker, "rig01") + ", " + fmt.Sprintf(worker, "rig02") + "]"
+			case 2:
+				w.WriteHeader(http.StatusBadGateway)
+				w.Write([]byte(`{"error": {"code": 502, "message": "bad gateway"}, "result": null}`))
+
+				return
+			default:
+				result = "[]"
+			}
+		}
+
+		w.Write([]byte(`{"error": null, "result": ` + result + `}`))
+	}))
+	defer upstream.Close()
+
+	previousHost := api.DefaultClient.Host
+	api.DefaultClient.Host = upstream.URL
+	defer func() { api.DefaultClient.Host = previousHost }()
+
+	next := func(t *testing.T, sub *feed.Subscription) feed.Event {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				t.Fatalf("subscription closed with: %v", sub.Err())
+			}
+
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for an event")
+		}
+
+		return feed.Event{}
+	}
+
+	t.Run("InvalidTopics", func(t *testing.T) {
+		hub := feed.NewHub(feed.Options{})
+		defer hub.Close()
+
+		if _, err := hub.Subscribe(); err != feed.ErrNoTopics {
+			t.Errorf("Subscribe() error = %v, want %v", err, feed.ErrNoTopics)
+		}
+
+		for _, topic := range []string{"miner/0x1/workers", "miner/" + address + "/balance", "pool/luck"} {
+			if _, err := hub.Subscribe(topic); err != feed.ErrUnknownTopic {
+				t.Errorf("Subscribe(%q) error = %v, want %v", topic, err, feed.ErrUnknownTopic)
+			}
+		}
+	})
+
+	t.Run("SnapshotAndUpdate", func(t *testing.T) {
+		hub := feed.NewHub(feed.Options{Interval: 20 * time.Millisecond})
+		defer hub.Close()
+
+		topic := "miner/" + address + "/workers"
+
+		sub, err := hub.Subscribe(topic)
+		if err != nil {
+			t.Fatalf("Subscribe failed with: %v", err)
+		}
+
+		if event := next(t, sub); event.Type != feed.Snapshot || len(event.Data.([]api.MinerWorker)) != 1 {
+			t.Fatalf("first event = %+v, want a snapshot of 1 worker", event)
+		}
+
+		event := next(t, sub)
+		if changes, ok := event.Data.(feed.WorkerChanges); event.Type != feed.Update || !ok || len(changes.Added) != 1 ||
+			changes.Added[0].Name != "rig02" {
+			t.Fatalf("second event = %+v, want rig02 added", event)
+		}
+
+		// A late subscriber is replayed the latest snapshot straight away
+		late, _ := hub.Subscribe(topic)
+		if event := next(t, late); event.Type != feed.Snapshot || len(event.Data.([]api.MinerWorker)) != 2 {
+			t.Errorf("replayed event = %+v, want a snapshot of 2 workers", event)
+		}
+
+		sub.Close()
+		late.Close()
+
+		if topics := hub.Topics(); len(topics) != 0 {
+			t.Errorf("Topics after unsubscribing = %v, want none", topics)
+		}
+	})
+
+	t.Run("FailedPolls", func(t *testing.T) {
+		var (
+			mu   sync.Mutex
+			errs []error
+		)
+
+		hub := feed.NewHub(feed.Options{Interval: 20 * time.Millisecond, OnError: func(topic string, err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}})
+		defer hub.Close()
+
+		sub, _ := hub.Subscribe("miner/" + failing + "/workers")
+
+		if event := next(t, sub); event.Type != feed.Snapshot || len(event.Data.([]api.MinerWorker)) != 2 {
+			t.Fatalf("first event = %+v, want a snapshot of 2 workers", event)
+		}
+
+		// Neither the error nor the first empty poll removes anything, only the second empty poll in a row does
+		event := next(t, sub)
+		if changes, ok := event.Data.(feed.WorkerChanges); event.Type != feed.Update || !ok || len(changes.Removed) != 2 {
+			t.Fatalf("second event = %+v, want both workers removed", event)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		var apiErr *api.APIError
+		if len(errs) != 2 || !errors.As(errs[0], &apiErr) || apiErr.StatusCode != http.StatusBadGateway || errs[1] != feed.ErrEmptyPoll {
+			t.Errorf("OnError got %v, want the 502 then ErrEmptyPoll", errs)
+		}
+
+		if polls := atomic.LoadInt64(&failingPolls); polls < 4 {
+			t.Errorf("update sent after %d polls, want at least 4", polls)
+		}
+	})
+
+	t.Run("SlowConsumer", func(t *testing.T) {
+		hub := feed.NewHub(feed.Options{Interval: 5 * time.Millisecond, Buffer: 1})
+		defer hub.Close()
+
+		slow, _ := hub.Subscribe("pool/hashrate")
+
+		// Never read, so the buffer fills up and the subscriber is dropped
+		deadline := time.After(time.Second)
+		for slow.Err() == nil {
+			select {
+			case <-deadline:
+				t.Fatal("slow subscriber was never dropped")
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+
+		if err := slow.Err(); err != feed.ErrSlowConsumer {
+			t.Errorf("Err = %v, want %v", err, feed.ErrSlowConsumer)
+		}
+	})
+
+	t.Run("ServerSentEvents", func(t *testing.T) {
+		hub := feed.NewHub(feed.Options{Interval: time.Minute})
+		defer hub.Close()
+
+		server := httptest.NewServer(hub)
+		defer server.Close()
+
+		if resp, _ := http.Get(server.URL + "?topic=pool/unknown"); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("unknown topic status = %d, want 400", resp.StatusCode)
+		}
+
+		resp, err := http.Get(server.URL + "?topic=pool/hashrate")
+		if err != nil {
+			t.Fatalf("GET failed with: %v", err)
+		}
+
+		defer resp.Body.Close()
+
+		if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+			t.Errorf("Content-Type = %q", resp.Header.Get("Content-Type"))
+		}
+
+		reader := bufio.NewReader(resp.Body)
+		if line, _ := reader.ReadString('\n'); line != "event: snapshot\n" {
+			t.Fatalf("first line = %q, want the snapshot event name", line)
+		}
+
+		line, _ := reader.ReadString('\n')
+
+		var event feed.Event
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil || event.Topic != "pool/hashrate" {
+			t.Errorf("data = %q, %v", line, err)
+		}
+	})
+
+	t.Run("WebSocket", func(t *testing.T) {
+		hub := feed.NewHub(feed.Options{Interval: time.Minute})
+		defer hub.Close()
+
+		server := httptest.NewServer(hub)
+		defer server.Close()
+
+		conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+		if err != nil {
+			t.Fatalf("Dial failed with: %v", err)
+		}
+
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(time.Second))
+
+		// The example key and accept value from RFC 6455
+		fmt.Fprintf(conn, "GET /?topic=pool/hashrate HTTP/1.1\r\nHost: localhost\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+			"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+
+		reader := bufio.NewReader(conn)
+		resp, err := http.ReadResponse(reader, nil)
+		if err != nil || resp.StatusCode != http.StatusSwitchingProtocols ||
+			resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+			t.Fatalf("handshake = %+v, %v", resp, err)
+		}
+
+		header := make([]byte, 2)
+		io.ReadFull(reader, header)
+
+		length := int(header[1])
+		if length == 126 {
+			extended := make([]byte, 2)
+			io.ReadFull(reader, extended)
+			length = int(binary.BigEndian.Uint16(extended))
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil || header[0] != 0x81 {
+			t.Fatalf("frame header = %x, %v", header, err)
+		}
+
+		var event feed.Event
+		if err := json.Unmarshal(payload, &event); err != nil || event.Type != feed.Snapshot || event.Topic != "pool/hashrate" {
+			t.Errorf("message = %q, %v", payload, err)
+		}
+	})
+
+	t.Run("WebSocketOrigin", func(t *testing.T) {
+		hub := feed.NewHub(feed.Options{Interval: time.Minute, AllowedOrigins: []string{"https://dashboard.example"}})
+		defer hub.Close()
+
+		server := httptest.NewServer(hub)
+		defer server.Close()
+
+		tests := []struct {
+			name   string
+			origin string
+			want   int
+		}{
+			{"NoOrigin", "", http.StatusSwitchingProtocols},
+			{"SameOrigin", server.URL, http.StatusSwitchingProtocols},
+			{"Allowed", "https://dashboard.example", http.StatusSwitchingProtocols},
+			{"AllowedCase", "https://Dashboard.example", http.StatusSwitchingProtocols},
+			{"OtherOrigin", "https://evil.example", http.StatusForbidden},
+			{"OtherPort", "http://" + strings.Split(strings.TrimPrefix(server.URL, "http://"), ":")[0] + ":1", http.StatusForbidden},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+				if err != nil {
+					t.Fatalf("Dial failed with: %v", err)
+				}
+
+				defer conn.Close()
+				conn.SetDeadline(time.Now().Add(time.Second))
+
+				request := "GET /?topic=pool/hashrate HTTP/1.1\r\nHost: " + strings.TrimPrefix(server.URL, "http://") +
+					"\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+					"Sec-WebSocket-Version: 13\r\n"
+				if test.origin != "" {
+					request += "Origin: " + test.origin + "\r\n"
+				}
+
+				fmt.Fprint(conn, request+"\r\n")
+
+				if resp, err := http.ReadResponse(bufio.NewReader(conn), nil); err != nil || resp.StatusCode != test.want {
+					t.Errorf("handshake = %+v, %v, want status %d", resp, err, test.want)
+				}
+			})
+		}
+	})
+}
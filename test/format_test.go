@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/format"
+)
+
+func TestFormat(t *testing.T) {
+	report := format.MinerReport{
+		Address:      "0x0",
+		PoolDonation: 0.01,
+		Workers: []api.MinerWorker{
+			{Name: "rig01", Online: true, ValidShares: 100, StaleShares: 2, InvalidShares: 1},
+		},
+	}
+
+	t.Run("WriteMinerText", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := format.WriteMinerReport(&buf, format.Text, report); err != nil {
+			t.Fatalf("WriteMinerReport failed with: %v", err)
+		}
+
+		output := buf.String()
+
+		if !strings.Contains(output, "(valid: 100, stale: 2, invalid: 1)") {
+			t.Errorf("worker shares printed in the wrong columns:\n%s", output)
+		}
+
+		if !strings.Contains(output, "Donation Percent: 0.0100% \t") || strings.Contains(output, "%!") {
+			t.Errorf("percentages not formatted correctly:\n%s", output)
+		}
+	})
+
+	t.Run("WriteMinerJSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := format.WriteMinerReport(&buf, format.JSON, report); err != nil {
+			t.Fatalf("WriteMinerReport failed with: %v", err)
+		}
+
+		var decoded format.MinerReport
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("output was not valid JSON: %v", err)
+		}
+
+		if decoded.Address != report.Address || len(decoded.Workers) != 1 {
+			t.Errorf("decoded report = %+v", decoded)
+		}
+	})
+
+	t.Run("WriteCSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := format.Write(&buf, format.CSV, report); err != nil {
+			t.Fatalf("Write failed with: %v", err)
+		}
+
+		sections := strings.Split(strings.TrimSpace(buf.String()), "\n\n")
+		if len(sections) != 4 {
+			t.Fatalf("CSV has %d sections, want 4 (summary, workers, payments, blocks):\n%s", len(sections), buf.String())
+		}
+
+		if !strings.HasPrefix(sections[0], "address,balance_gwei,") {
+			t.Errorf("unexpected summary header: %s", sections[0])
+		}
+
+		if !strings.HasPrefix(sections[1], "name,online,") || !strings.Contains(sections[1], "rig01,true,") {
+			t.Errorf("unexpected workers section: %s", sections[1])
+		}
+	})
+
+	t.Run("WriteYAML", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := format.Write(&buf, format.YAML, api.MinerStats{}); err != nil {
+			t.Fatalf("Write failed with: %v", err)
+		}
+
+		want := "current:\n  effective_hashrate: 0\n  reported_hashrate: 0\ndaily:\n"
+		if !strings.HasPrefix(buf.String(), want) {
+			t.Errorf("YAML output =\n%s\nwant prefix\n%s", buf.String(), want)
+		}
+	})
+
+	t.Run("HiddenFields", func(t *testing.T) {
+		type row struct {
+			Name   string `json:"name"`
+			Secret string `json:"-"`
+			Tags   []row  `json:"-"`
+		}
+
+		rows := []row{{Name: "rig01", Secret: "hunter2"}}
+
+		for _, output := range []string{format.Table, format.CSV, format.YAML, format.JSON} {
+			var buf bytes.Buffer
+			if err := format.Write(&buf, output, rows); err != nil {
+				t.Fatalf("Write(%s) failed with: %v", output, err)
+			}
+
+			if strings.Contains(buf.String(), "hunter2") || strings.Contains(strings.ToLower(buf.String()), "secret") {
+				t.Errorf("%s output shows a field tagged json:\"-\":\n%s", output, buf.String())
+			}
+		}
+	})
+
+	t.Run("Supported", func(t *testing.T) {
+		formats := []string{format.Text, format.JSON, format.CSV}
+
+		for _, output := range formats {
+			if !format.Supported(output, formats...) {
+				t.Errorf("Supported(%q) = false, want true", output)
+			}
+		}
+
+		for _, output := range []string{"", format.YAML, "xml"} {
+			if format.Supported(output, formats...) {
+				t.Errorf("Supported(%q) = true, want false", output)
+			}
+		}
+
+		if format.Supported("xml", "xml") {
+			t.Error("Supported accepted a format Write can't produce")
+		}
+	})
+
+	t.Run("WriteUnknown", func(t *testing.T) {
+		if err := format.Write(&bytes.Buffer{}, "xml", report); err != format.ErrUnknownFormat {
+			t.Errorf("Write error = %v, want ErrUnknownFormat", err)
+		}
+	})
+}
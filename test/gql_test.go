@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/gql"
+)
+
+func TestGraphQL(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	// Stand in for the flexpool API, with 25 payments over pages of 10, counting requests by path
+	var (
+		mu       sync.Mutex
+		requests = make(map[string]int)
+		inFlight int
+		peak     int
+	)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path+"?"+r.URL.RawQuery]++
+		if inFlight++; inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		// Long enough for concurrent requests to overlap
+		time.Sleep(5 * time.Millisecond)
+
+		result := "null"
+
+		switch r.URL.Path {
+		case "/miner/" + address + "/balance":
+			result = "5000000000"
+		case "/miner/" + address + "/workers":
+			result = `[{"name": "rig01", "online": true, "duplicate_workers_merged": 0, "reported_hashrate": 100,
+				"effective_hashrate": 90, "valid_shares": 10, "stale_shares": 1, "invalid_shares": 0, "last_seen": 1600000000},
+				{"name": "rig02", "online": false, "duplicate_workers_merged": 0, "reported_hashrate": 0,
+				"effective_hashrate": 0, "valid_shares": 0, "stale_shares": 0, "invalid_shares": 0, "last_seen": 1500000000}]`
+		case "/miner/" + address + "/payments":
+			var page int
+			fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+
+			var payments []string
+			for i := page * 10; i < page*10+10 && i < 25; i++ {
+				payments = append(payments, fmt.Sprintf(`{"txid": "tx%d", "amount": 1, "timestamp": 1, "duration": 1}`, i))
+			}
+
+			result = `{"data": [` + strings.Join(payments, ",") + `], "items_per_page": 10, "total_items": 25, "total_pages": 3}`
+		case "/pool/avgLuckRoundtime":
+			result = `{"luck": 0.9, "round_time": 600}`
+		}
+
+		w.Write([]byte(`{"error": null, "result": ` + result + `}`))
+	}))
+	defer upstream.Close()
+
+	previousHost := api.DefaultClient.Host
+	api.DefaultClient.Host = upstream.URL
+	defer func() { api.DefaultClient.Host = previousHost }()
+
+	handler := gql.NewHandler(gql.Options{})
+
+	t.Run("Composed", func(t *testing.T) {
+		response := handler.Exec(context.Background(), `query($address: String!) {
+			miner(address: $address) {
+				balance
+				online: workers(online: true) { name }
+				all: workers { name }
+				payments(first: 10) { totalCount edges { node { txid } } }
+			}
+			pool { averageLuck averageRoundTime }
+		}`, "", map[string]interface{}{"address": address})
+
+		if len(response.Errors) > 0 {
+			t.Fatalf("Exec errors = %v", response.Errors)
+		}
+
+		var data struct {
+			Miner struct {
+				Balance  float64
+				Online   []struct{ Name string }
+				All      []struct{ Name string }
+				Payments struct {
+					TotalCount int
+					Edges      []struct{ Node struct{ Txid string } }
+				}
+			}
+			Pool struct {
+				AverageLuck      float64
+				AverageRoundTime float64
+			}
+		}
+
+		json.Unmarshal(response.Data, &data)
+
+		if data.Miner.Balance != 5 || len(data.Miner.Online) != 1 || len(data.Miner.All) != 2 ||
+			data.Miner.Payments.TotalCount != 25 || len(data.Miner.Payments.Edges) != 10 || data.Pool.AverageRoundTime != 600 {
+			t.Errorf("data = %+v", data)
+		}
+
+		// Fields sharing an API call only make it once
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, path := range []string{"/miner/" + address + "/workers?", "/pool/avgLuckRoundtime?"} {
+			if requests[path] != 1 {
+				t.Errorf("%s requested %d times, want 1", path, requests[path])
+			}
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		// Pages of 8 don't line up with the API's pages of 10, so some of them span two API pages
+		var txids []string
+		var after interface{} = nil
+
+		for {
+			response := handler.Exec(context.Background(), `query($address: String!, $after: String) {
+				miner(address: $address) { payments(first: 8, after: $after) { edges { node { txid } } pageInfo { hasNextPage endCursor } } }
+			}`, "", map[string]interface{}{"address": address, "after": after})
+
+			if len(response.Errors) > 0 {
+				t.Fatalf("Exec errors = %v", response.Errors)
+			}
+
+			var data struct {
+				Miner struct {
+					Payments struct {
+						Edges    []struct{ Node struct{ Txid string } }
+						PageInfo struct {
+							HasNextPage bool
+							EndCursor   string
+						}
+					}
+				}
+			}
+
+			json.Unmarshal(response.Data, &data)
+
+			for _, e := range data.Miner.Payments.Edges {
+				txids = append(txids, e.Node.Txid)
+			}
+
+			if !data.Miner.Payments.PageInfo.HasNextPage {
+				break
+			}
+
+			after = data.Miner.Payments.PageInfo.EndCursor
+		}
+
+		if len(txids) != 25 || txids[0] != "tx0" || txids[8] != "tx8" || txids[24] != "tx24" {
+			t.Errorf("paginated txids = %v", txids)
+		}
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		for _, query := range []string{
+			`{ miner(address: "0x1") { balance } }`,
+			`{ miner(address: "` + address + `") { payments(first: 1000) { totalCount } } }`,
+			`{ miner(address: "` + address + `") { payments(after: "bogus") { totalCount } } }`,
+		} {
+			if response := handler.Exec(context.Background(), query, "", nil); len(response.Errors) == 0 {
+				t.Errorf("%s returned no errors", query)
+			}
+		}
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		other := "0x00000000000000000000000000000000000000aa"
+		otherUpper := "0x00000000000000000000000000000000000000AA"
+		third := "0x00000000000000000000000000000000000000bb"
+
+		limited := gql.NewHandler(gql.Options{MaxDepth: 3, MaxParallelism: 1, MaxAddresses: 2})
+
+		tests := []struct {
+			name  string
+			query string
+			err   string
+		}{
+			{"Shallow", `{ miner(address: "` + address + `") { balance } }`, ""},
+			{"TooDeep", `{ miner(address: "` + address + `") { payments { edges { node { txid } } } } }`, "exceeds max depth 3"},
+			{"TwoAddresses", `{ a: miner(address: "` + address + `") { address } b: miner(address: "` + other + `") { address }
+				c: miner(address: "` + otherUpper + `") { address } }`, ""},
+			{"TooManyAddresses", `{ a: miner(address: "` + address + `") { address } b: miner(address: "` + other + `") { address }
+				c: miner(address: "` + third + `") { address } }`, gql.ErrTooManyAddresses.Error()},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				response := limited.Exec(context.Background(), test.query, "", nil)
+
+				if test.err == "" && len(response.Errors) > 0 {
+					t.Errorf("Exec errors = %v, want none", response.Errors)
+				}
+
+				if test.err != "" && (len(response.Errors) == 0 || !strings.Contains(response.Errors[0].Message, test.err)) {
+					t.Errorf("Exec errors = %v, want %q", response.Errors, test.err)
+				}
+			})
+		}
+
+		mu.Lock()
+		peak = 0
+		mu.Unlock()
+
+		// With one resolver at a time, the API calls behind these fields are made one at a time too
+		response := limited.Exec(context.Background(), `{ miner(address: "`+address+`") { balance workers { name } } pool { averageLuck } }`, "", nil)
+		if len(response.Errors) > 0 {
+			t.Fatalf("Exec errors = %v", response.Errors)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if peak != 1 {
+			t.Errorf("peak concurrent API calls = %d, want 1", peak)
+		}
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		body, _ := json.Marshal(map[string]interface{}{"query": `{ miner(address: "` + address + `") { balance } }`})
+
+		resp, err := http.Post(server.URL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST failed with: %v", err)
+		}
+
+		var result struct {
+			Data struct{ Miner struct{ Balance float64 } }
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || result.Data.Miner.Balance != 5 {
+			t.Errorf("POST result = %+v, %v", result, err)
+		}
+	})
+}
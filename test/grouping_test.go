@@ -0,0 +1,93 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/config"
+	"github.com/cryptogenic/goflexpool/pkg/utils/grouping"
+)
+
+func TestGrouping(t *testing.T) {
+	workers := []api.MinerWorker{
+		{Name: "site1-rig01-gpu", Online: true, EffectiveHashrate: 100, ReportedHashrate: 110, ValidShares: 10, StaleShares: 1},
+		{Name: "site1-rig02-gpu", Online: false, EffectiveHashrate: 50, ReportedHashrate: 60, ValidShares: 5, InvalidShares: 1},
+		{Name: "site2-rig01-gpu", Online: true, EffectiveHashrate: 200, ReportedHashrate: 210, ValidShares: 20},
+		{Name: "laptop", Online: true, EffectiveHashrate: 5, ReportedHashrate: 5, ValidShares: 1},
+	}
+
+	grouper, err := grouping.New([]grouping.Rule{
+		{Group: "site1", Glob: "site1-*"},
+		{Group: "rig01", Regex: `-rig01-`},
+	}, map[string][]string{"site2-rig01-gpu": {"backup"}})
+	if err != nil {
+		t.Fatalf("New failed with: %v", err)
+	}
+
+	t.Run("Groups", func(t *testing.T) {
+		if groups := grouper.Groups("site2-rig01-gpu"); !reflect.DeepEqual(groups, []string{"rig01", "backup"}) {
+			t.Errorf("Groups(site2-rig01-gpu) = %v", groups)
+		}
+
+		if groups := grouper.Groups("laptop"); !reflect.DeepEqual(groups, []string{grouping.Ungrouped}) {
+			t.Errorf("Groups(laptop) = %v", groups)
+		}
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		charts := map[string][]api.WorkerChartData{
+			"site1-rig01-gpu": {{Timestamp: 1200, EffectiveHashrate: 100}, {Timestamp: 600, EffectiveHashrate: 90}},
+			"site1-rig02-gpu": {{Timestamp: 600, EffectiveHashrate: 40, ValidShares: 2}},
+		}
+
+		report := grouper.Report(workers, charts)
+
+		var names []string
+		for _, stats := range report.Groups {
+			names = append(names, stats.Name)
+		}
+
+		if !reflect.DeepEqual(names, []string{"site1", "rig01", "backup", grouping.Ungrouped}) {
+			t.Fatalf("group order = %v", names)
+		}
+
+		want := grouping.Stats{Name: "site1", Workers: 2, Online: 1, Offline: 1, EffectiveHashrate: 150, ReportedHashrate: 170,
+			ValidShares: 15, StaleShares: 1, InvalidShares: 1}
+		if report.Groups[0] != want {
+			t.Errorf("site1 stats = %+v, want %+v", report.Groups[0], want)
+		}
+
+		chart := report.Charts["site1"]
+		if len(chart) != 2 || chart[0].Timestamp != 600 || chart[0].EffectiveHashrate != 130 || chart[0].ValidShares != 2 {
+			t.Errorf("site1 chart = %+v", chart)
+		}
+	})
+
+	t.Run("InvalidRule", func(t *testing.T) {
+		if _, err := grouping.New([]grouping.Rule{{Group: "a", Glob: "*", Regex: ".*"}}, nil); err != grouping.ErrNoMatcher {
+			t.Errorf("New error = %v, want ErrNoMatcher", err)
+		}
+
+		if _, err := grouping.New([]grouping.Rule{{Group: "a", Regex: "("}}, nil); err == nil {
+			t.Errorf("New accepted an invalid regex")
+		}
+	})
+
+	t.Run("FromConfig", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.Groups = []config.Group{
+			{Name: "mine", Patterns: []string{"site1-*"}},
+			{Name: "other", Address: "0x0000000000000000000000000000000000000002", Patterns: []string{"*"}},
+		}
+
+		grouper, err := grouping.FromConfig(cfg, "0x0000000000000000000000000000000000000001")
+		if err != nil {
+			t.Fatalf("FromConfig failed with: %v", err)
+		}
+
+		if names := grouper.Names(); !reflect.DeepEqual(names, []string{"mine"}) {
+			t.Errorf("Names = %v, want [mine]", names)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/utils/health"
+)
+
+func TestWorkerHealth(t *testing.T) {
+	healthyWorker := func(name string) api.MinerWorker {
+		return api.MinerWorker{
+			Name:              name,
+			Online:            true,
+			ReportedHashrate:  100_000_000,
+			EffectiveHashrate: 100_000_000,
+			ValidShares:       1000,
+			StaleShares:       10,
+		}
+	}
+
+	steadyChart := func(hashrates ...uint) []api.WorkerChartData {
+		var chart []api.WorkerChartData
+		for i, hashrate := range hashrates {
+			chart = append(chart, api.WorkerChartData{Timestamp: uint(i * 600), EffectiveHashrate: hashrate, ValidShares: 10})
+		}
+
+		return chart
+	}
+
+	t.Run("Report", func(t *testing.T) {
+		workers := []api.MinerWorker{healthyWorker("rig01"), healthyWorker("rig02"), healthyWorker("rig03"), healthyWorker("rig04")}
+
+		workers[1].StaleShares = 200
+		workers[2].Online = false
+
+		charts := map[string][]api.WorkerChartData{
+			"rig01": steadyChart(100, 100, 100, 100),
+			"rig04": steadyChart(100, 100, 100, 40),
+		}
+
+		report := health.Report(workers, charts, health.DefaultThresholds())
+		if len(report) != 4 {
+			t.Fatalf("Report returned %d workers, want 4", len(report))
+		}
+
+		order := []string{"rig03", "rig02", "rig04", "rig01"}
+		for i, name := range order {
+			if report[i].Name != name {
+				t.Fatalf("report ranked %s at %d, want %s: %+v", report[i].Name, i, name, report)
+			}
+		}
+
+		if report[3].Score != health.MaxScore || len(report[3].Reasons) != 0 {
+			t.Errorf("healthy worker scored %d with reasons %v", report[3].Score, report[3].Reasons)
+		}
+
+		if math.Abs(report[2].HashrateDrop-0.6) > 1e-9 {
+			t.Errorf("HashrateDrop = %v, want 0.6", report[2].HashrateDrop)
+		}
+
+		if report[0].Score > health.MaxScore-health.OfflinePenalty {
+			t.Errorf("offline worker scored %d", report[0].Score)
+		}
+	})
+
+	t.Run("EfficiencyOutliers", func(t *testing.T) {
+		var workers []api.MinerWorker
+		for _, name := range []string{"rig01", "rig02", "rig03", "rig04", "rig05", "rig06", "slow"} {
+			workers = append(workers, healthyWorker(name))
+		}
+
+		workers[6].EffectiveHashrate = 50_000_000
+
+		// Offline workers and workers without a reported hashrate have no efficiency to compare
+		offline, unreported := healthyWorker("offline"), healthyWorker("unreported")
+		offline.Online = false
+		unreported.ReportedHashrate = 0
+		workers = append(workers, offline, offline, unreported)
+
+		report := health.Report(workers, nil, health.DefaultThresholds())
+
+		for _, worker := range report {
+			score := worker.ZScores[health.MetricEfficiency]
+
+			switch worker.Name {
+			case "slow":
+				if score > -2 {
+					t.Errorf("slow worker's efficiency z-score = %.2f, want it flagged as an outlier", score)
+				}
+			case "offline", "unreported":
+				if score != 0 {
+					t.Errorf("%s efficiency z-score = %.2f, want 0", worker.Name, score)
+				}
+
+				for _, reason := range worker.Reasons {
+					if strings.HasPrefix(reason, health.MetricEfficiency) {
+						t.Errorf("%s was penalised as an efficiency outlier: %v", worker.Name, worker.Reasons)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("CountFlaps", func(t *testing.T) {
+		chart := steadyChart(100, 0, 100, 0, 100)
+		chart[1].ValidShares = 0
+		chart[3].ValidShares = 0
+
+		if flaps := health.CountFlaps(chart); flaps != 4 {
+			t.Errorf("CountFlaps = %d, want 4", flaps)
+		}
+	})
+
+	t.Run("ZScores", func(t *testing.T) {
+		scores := health.ZScores([]float64{1, 1, 1, 5})
+		if math.Abs(scores[3]-math.Sqrt(3)) > 1e-9 || math.Abs(scores[0]+1/math.Sqrt(3)) > 1e-9 {
+			t.Errorf("ZScores = %v", scores)
+		}
+
+		if scores := health.ZScores([]float64{2, 2}); scores[0] != 0 || scores[1] != 0 {
+			t.Errorf("ZScores of equal values = %v, want zeros", scores)
+		}
+	})
+}
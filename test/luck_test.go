@@ -0,0 +1,175 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/utils/luck"
+)
+
+const luckTolerance = 1e-6
+
+func blocksWithEfforts(efforts ...float64) []api.Block {
+	var blocks []api.Block
+
+	for _, effort := range efforts {
+		blocks = append(blocks, api.Block{Luck: effort})
+	}
+
+	return blocks
+}
+
+func TestLuckDistributions(t *testing.T) {
+	// Golden values computed from the closed forms of the exponential and Erlang distributions
+	t.Run("ErlangCDF", func(t *testing.T) {
+		tests := []struct {
+			x    float64
+			k    int
+			want float64
+		}{
+			{1, 1, 1 - math.Exp(-1)},
+			{2, 2, 1 - 3*math.Exp(-2)},
+			{10, 10, 0.5420702855281478},
+			{50, 60, 0.0922650519589331},
+			{0, 5, 0},
+		}
+
+		for _, test := range tests {
+			if got := luck.ErlangCDF(test.x, test.k); math.Abs(got-test.want) > luckTolerance {
+				t.Errorf("ErlangCDF(%v, %d) = %v, want %v", test.x, test.k, got, test.want)
+			}
+		}
+	})
+
+	t.Run("ErlangQuantile", func(t *testing.T) {
+		if got := luck.ErlangQuantile(0.5, 1); math.Abs(got-math.Ln2) > luckTolerance {
+			t.Errorf("ErlangQuantile(0.5, 1) = %v, want %v", got, math.Ln2)
+		}
+
+		for _, k := range []int{1, 3, 25, 200} {
+			for _, p := range []float64{0.025, 0.5, 0.975} {
+				if got := luck.ErlangCDF(luck.ErlangQuantile(p, k), k); math.Abs(got-p) > luckTolerance {
+					t.Errorf("ErlangCDF(ErlangQuantile(%v, %d)) = %v", p, k, got)
+				}
+			}
+		}
+	})
+
+	t.Run("PoissonCDF", func(t *testing.T) {
+		// P(X <= 2) for Poisson(1) = e^-1 * (1 + 1 + 1/2)
+		if got, want := luck.PoissonCDF(2, 1), 2.5*math.Exp(-1); math.Abs(got-want) > luckTolerance {
+			t.Errorf("PoissonCDF(2, 1) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("BlockCountInterval", func(t *testing.T) {
+		// Published exact 95% Poisson confidence limits
+		tests := []struct {
+			observed     int
+			lower, upper float64
+		}{
+			{0, 0, 3.688879},
+			{1, 0.025318, 5.571643},
+			{10, 4.795389, 18.390356},
+		}
+
+		for _, test := range tests {
+			interval, err := luck.BlockCountInterval(test.observed, 0.95)
+			if err != nil {
+				t.Fatalf("BlockCountInterval failed with: %v", err)
+			}
+
+			if math.Abs(interval.Lower-test.lower) > luckTolerance || math.Abs(interval.Upper-test.upper) > luckTolerance {
+				t.Errorf("BlockCountInterval(%d) = %+v, want [%v, %v]", test.observed, interval, test.lower, test.upper)
+			}
+		}
+	})
+}
+
+func TestLuckAnalysis(t *testing.T) {
+	t.Run("EffortDistribution", func(t *testing.T) {
+		dist, err := luck.EffortDistribution(blocksWithEfforts(0.5, 1.5, 1.0, 3.5))
+		if err != nil {
+			t.Fatalf("EffortDistribution failed with: %v", err)
+		}
+
+		if dist.Mean != 1.625 || dist.Median != 1.25 || dist.Min != 0.5 || dist.Max != 3.5 {
+			t.Errorf("unexpected distribution: %+v", dist)
+		}
+
+		observed, expected := 0, 0.0
+		for _, bucket := range dist.Buckets {
+			observed += bucket.Observed
+			expected += bucket.Expected
+		}
+
+		if observed != 4 || math.Abs(expected-4) > luckTolerance {
+			t.Errorf("histogram totals = %d observed, %v expected, want 4", observed, expected)
+		}
+
+		if _, err := luck.EffortDistribution(nil); err != luck.ErrNoBlocks {
+			t.Errorf("EffortDistribution(nil) error = %v, want ErrNoBlocks", err)
+		}
+	})
+
+	t.Run("RollingLuck", func(t *testing.T) {
+		rolling, err := luck.RollingLuck(blocksWithEfforts(1, 2, 3, 4), 2)
+		if err != nil {
+			t.Fatalf("RollingLuck failed with: %v", err)
+		}
+
+		want := []float64{1.5, 2.5, 3.5}
+		for i := range want {
+			if rolling[i] != want[i] {
+				t.Errorf("RollingLuck = %v, want %v", rolling, want)
+				break
+			}
+		}
+
+		if _, err := luck.RollingLuck(blocksWithEfforts(1), 2); err != luck.ErrInvalidWindow {
+			t.Errorf("RollingLuck with oversized window error = %v, want ErrInvalidWindow", err)
+		}
+	})
+
+	t.Run("LuckInterval", func(t *testing.T) {
+		// With a single block of effort 1, the interval is [1/Q(0.975), 1/Q(0.025)] of the unit exponential
+		interval, err := luck.LuckInterval(blocksWithEfforts(1), 0.95)
+		if err != nil {
+			t.Fatalf("LuckInterval failed with: %v", err)
+		}
+
+		lower, upper := 1/-math.Log(0.025), 1/-math.Log(0.975)
+		if math.Abs(interval.Lower-lower) > luckTolerance || math.Abs(interval.Upper-upper) > luckTolerance {
+			t.Errorf("LuckInterval = %+v, want [%v, %v]", interval, lower, upper)
+		}
+	})
+
+	t.Run("RoundProbability", func(t *testing.T) {
+		// A round lasting exactly the expected time has probability e^-1 of being reached
+		probability, err := luck.RoundProbability(100, 1000, 10)
+		if err != nil {
+			t.Fatalf("RoundProbability failed with: %v", err)
+		}
+
+		if math.Abs(probability-math.Exp(-1)) > luckTolerance {
+			t.Errorf("RoundProbability = %v, want %v", probability, math.Exp(-1))
+		}
+
+		if _, err := luck.RoundProbability(100, 1000, 0); err != luck.ErrInvalidHashrate {
+			t.Errorf("RoundProbability with zero hashrate error = %v, want ErrInvalidHashrate", err)
+		}
+	})
+
+	t.Run("DetectBadStreaks", func(t *testing.T) {
+		streaks := luck.DetectBadStreaks(blocksWithEfforts(0.9, 1.1, 4, 5, 3, 0.8, 1.0), 0.01)
+
+		if len(streaks) != 1 {
+			t.Fatalf("DetectBadStreaks found %d streaks, want 1: %+v", len(streaks), streaks)
+		}
+
+		if streaks[0].Start != 2 || streaks[0].End != 4 || streaks[0].TotalEffort != 12 {
+			t.Errorf("unexpected streak: %+v", streaks[0])
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+)
+
+func TestMiddleware(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	// Stand in for the flexpool API, keeping the last request's headers
+	var lastHeaders http.Header
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastHeaders = r.Header.Clone()
+
+		if strings.HasPrefix(r.URL.Path, "/worker/") {
+			w.Write([]byte(`{"error": null, "result": {"effective_hashrate": 90, "reported_hashrate": 100}}`))
+		} else {
+			w.Write([]byte(`{"error": null, "result": 2000000000}`))
+		}
+	}))
+	defer upstream.Close()
+
+	previous := *api.DefaultClient
+	defer func() { *api.DefaultClient = previous }()
+
+	// Each test starts with an empty chain pointed at the fake API
+	reset := func() {
+		api.DefaultClient.Host = upstream.URL
+		api.DefaultClient.Middleware = nil
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		reset()
+
+		var order []string
+		record := func(name string) api.Middleware {
+			return func(next api.Handler) api.Handler {
+				return func(call *api.Call) error {
+					order = append(order, name+" before")
+					err := next(call)
+					order = append(order, name+" after")
+
+					return err
+				}
+			}
+		}
+
+		api.DefaultClient.Use(record("first"), record("second"))
+		api.DefaultClient.Use(record("third"))
+
+		if _, err := api.MinerGetBalance(address); err != nil {
+			t.Fatalf("MinerGetBalance failed with: %v", err)
+		}
+
+		want := "first before,second before,third before,third after,second after,first after"
+		if got := strings.Join(order, ","); got != want {
+			t.Errorf("order = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("Call", func(t *testing.T) {
+		reset()
+
+		var seen api.Call
+		api.DefaultClient.Use(func(next api.Handler) api.Handler {
+			return func(call *api.Call) error {
+				err := next(call)
+				seen = *call
+
+				return err
+			}
+		})
+
+		api.WorkerGetCurrent(address, "rig01")
+
+		if seen.Endpoint != api.Worker || seen.Query != address || seen.Method != "rig01" || len(seen.Params) != 1 ||
+			seen.Params[0] != "current" {
+			t.Errorf("call = %+v", seen)
+		}
+
+		result, _ := seen.Result.Result.(map[string]interface{})
+		if seen.Response == nil || seen.Response.StatusCode != http.StatusOK || result["effective_hashrate"] != 90.0 ||
+			!strings.HasSuffix(seen.Request.URL.Path, "/worker/"+address+"/rig01/current") {
+			t.Errorf("call response = %+v, result = %+v", seen.Response, seen.Result)
+		}
+	})
+
+	t.Run("ShortCircuit", func(t *testing.T) {
+		reset()
+
+		lastHeaders = nil
+		api.DefaultClient.Use(func(next api.Handler) api.Handler {
+			return func(call *api.Call) error {
+				call.Result = api.Response{Result: 5000000000.0}
+				return nil
+			}
+		})
+
+		if balance, err := api.MinerGetBalance(address); err != nil || balance != 5 || lastHeaders != nil {
+			t.Errorf("MinerGetBalance = %v, %v, upstream called = %v", balance, err, lastHeaders != nil)
+		}
+	})
+
+	t.Run("Builtin", func(t *testing.T) {
+		reset()
+
+		var logged bytes.Buffer
+		api.DefaultClient.Use(
+			api.Logging(log.New(&logged, "", 0)),
+			api.Headers(http.Header{"Proxy-Authorization": {"Bearer token"}}),
+			api.RequestID(""),
+		)
+
+		if _, err := api.MinerGetBalance(address); err != nil {
+			t.Fatalf("MinerGetBalance failed with: %v", err)
+		}
+
+		if lastHeaders.Get("Proxy-Authorization") != "Bearer token" {
+			t.Errorf("Proxy-Authorization = %q", lastHeaders.Get("Proxy-Authorization"))
+		}
+
+		if len(lastHeaders.Get(api.RequestIDHeader)) != 16 {
+			t.Errorf("%s = %q, want 16 hex digits", api.RequestIDHeader, lastHeaders.Get(api.RequestIDHeader))
+		}
+
+		if !strings.Contains(logged.String(), "/miner/"+address+"/balance 200") {
+			t.Errorf("log = %q", logged.String())
+		}
+	})
+}
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"testing"
 
-	"../pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/api"
 )
 
 func TestMinerEndpoints(t *testing.T) {
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/utils/pplns"
+)
+
+func TestPPLNSModel(t *testing.T) {
+	model := pplns.Model{
+		N:               pplns.DefaultN,
+		ShareDifficulty: pplns.DefaultShareDifficulty,
+		PoolHashrate:    1_000_000_000_000, // 1TH/s
+		BlockRewardGwei: 2_000_000_000,
+		RoundTime:       800,
+	}
+
+	t.Run("ShareWindow", func(t *testing.T) {
+		if window := model.ShareWindow(); window != 8000*time.Second {
+			t.Errorf("ShareWindow = %v, want 8000s", window)
+		}
+	})
+
+	t.Run("NewModel", func(t *testing.T) {
+		blocks := []api.Block{{TotalRewards: 1_000_000_000, RoundTime: 600}, {TotalRewards: 3_000_000_000, RoundTime: 1000}}
+
+		newModel, err := pplns.NewModel(model.PoolHashrate, blocks)
+		if err != nil {
+			t.Fatalf("NewModel failed with: %v", err)
+		}
+
+		if newModel != model {
+			t.Errorf("NewModel = %+v, want %+v", newModel, model)
+		}
+	})
+
+	t.Run("ExpectedShare", func(t *testing.T) {
+		// A constant 100MH/s over a longer period than the window fills 200 shares of the window
+		var history []api.MinerChartData
+		for i := 0; i < 20; i++ {
+			history = append(history, api.MinerChartData{Timestamp: 1_600_000_000 + i*600, EffectiveHashrate: 100_000_000})
+		}
+
+		estimate, err := model.ExpectedShare(history)
+		if err != nil {
+			t.Fatalf("ExpectedShare failed with: %v", err)
+		}
+
+		if math.Abs(estimate.WindowShares-200) > 1e-9 || math.Abs(estimate.NextBlockRewardGwei-200_000) > 1e-6 {
+			t.Errorf("unexpected estimate: %+v", estimate)
+		}
+
+		if _, err := model.ExpectedShare(nil); err != pplns.ErrNoHistory {
+			t.Errorf("ExpectedShare(nil) error = %v, want ErrNoHistory", err)
+		}
+	})
+
+	t.Run("OfflineLoss", func(t *testing.T) {
+		// 90 lost shares, each worth 1/N of the 10 blocks expected over their lifetime
+		loss, err := model.OfflineLoss(100_000_000, time.Hour)
+		if err != nil {
+			t.Fatalf("OfflineLoss failed with: %v", err)
+		}
+
+		if math.Abs(loss-900_000) > 1e-6 {
+			t.Errorf("OfflineLoss = %v, want 900000", loss)
+		}
+
+		if _, err := (pplns.Model{}).OfflineLoss(1, time.Hour); err != pplns.ErrInvalidModel {
+			t.Errorf("OfflineLoss on empty model error = %v, want ErrInvalidModel", err)
+		}
+	})
+
+	t.Run("Simulate", func(t *testing.T) {
+		config := pplns.SimulationConfig{
+			MinerHashrate: 500_000_000,
+			Duration:      24 * time.Hour,
+			Runs:          500,
+			Seed:          42,
+		}
+
+		result, err := model.Simulate(config)
+		if err != nil {
+			t.Fatalf("Simulate failed with: %v", err)
+		}
+
+		if math.Abs(result.MeanGwei-result.ExpectedGwei)/result.ExpectedGwei > 0.05 {
+			t.Errorf("simulated mean %v too far from expected %v", result.MeanGwei, result.ExpectedGwei)
+		}
+
+		if !(result.P5Gwei <= result.MedianGwei && result.MedianGwei <= result.P95Gwei) || result.StdDevGwei <= 0 {
+			t.Errorf("unexpected distribution: %+v", result)
+		}
+
+		again, _ := model.Simulate(config)
+		if again != result {
+			t.Errorf("simulation with the same seed was not reproducible")
+		}
+
+		// Percentiles are interpolated, so the median of two runs is their mean
+		config.Runs = 2
+
+		if pair, _ := model.Simulate(config); math.Abs(pair.MedianGwei-pair.MeanGwei) > 1e-6 {
+			t.Errorf("median of two runs = %v, want their mean %v", pair.MedianGwei, pair.MeanGwei)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/utils/profit"
+)
+
+func TestProfitCalculate(t *testing.T) {
+	inputs := profit.Inputs{
+		BalanceGwei:               40_000_000,
+		MinPayoutThresholdGwei:    50_000_000,
+		EstimatedDailyRevenueGwei: 10_000_000,
+		EffectiveHashrate:         50_000_000,
+		AverageBlockRewardGwei:    4_000_000_000,
+		Workers:                   2,
+		WattsPerWorker:            125,
+		PricePerKWh:               0.1,
+		EthPrice:                  2000,
+	}
+
+	t.Run("Calculate", func(t *testing.T) {
+		result, err := profit.Calculate(inputs)
+		if err != nil {
+			t.Fatalf("Calculate failed with: %v", err)
+		}
+
+		if result.RevenuePerMHsPerDayGwei != 200_000 {
+			t.Errorf("RevenuePerMHsPerDayGwei = %v, want 200000", result.RevenuePerMHsPerDayGwei)
+		}
+
+		if !result.PayoutReachable || result.TimeToPayout != 24*time.Hour {
+			t.Errorf("TimeToPayout = %v (reachable: %v), want 24h", result.TimeToPayout, result.PayoutReachable)
+		}
+
+		if result.EnergyPerDayKWh != 6 || math.Abs(result.PowerCostPerDay-0.6) > 1e-9 {
+			t.Errorf("power = %v kWh costing %v, want 6 kWh costing 0.6", result.EnergyPerDayKWh, result.PowerCostPerDay)
+		}
+
+		if math.Abs(result.ProfitPerDay-19.4) > 1e-9 {
+			t.Errorf("ProfitPerDay = %v, want 19.4", result.ProfitPerDay)
+		}
+	})
+
+	t.Run("CalculateNoRevenue", func(t *testing.T) {
+		noRevenue := inputs
+		noRevenue.EstimatedDailyRevenueGwei = 0
+
+		result, err := profit.Calculate(noRevenue)
+		if err != nil {
+			t.Fatalf("Calculate failed with: %v", err)
+		}
+
+		if result.PayoutReachable {
+			t.Errorf("payout should not be reachable without revenue: %+v", result)
+		}
+	})
+
+	t.Run("CalculateNegativeCost", func(t *testing.T) {
+		negative := inputs
+		negative.PricePerKWh = -1
+
+		if _, err := profit.Calculate(negative); err != profit.ErrNegativePowerCost {
+			t.Errorf("Calculate error = %v, want ErrNegativePowerCost", err)
+		}
+	})
+}
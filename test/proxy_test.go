@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/proxy"
+)
+
+func TestProxy(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	// Stand in for the flexpool API, failing on request while down is set
+	var (
+		upstreamRequests int64
+		down             int32
+	)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&upstreamRequests, 1)
+
+		if atomic.LoadInt32(&down) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error": "unavailable", "result": null}`))
+
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"error": null, "result": 2000000000}`))
+	}))
+	defer upstream.Close()
+
+	newProxy := func(ttl time.Duration) *httptest.Server {
+		p, err := proxy.New(proxy.Options{Upstream: upstream.URL, TTL: ttl, StaleTTL: time.Minute})
+		if err != nil {
+			t.Fatalf("New failed with: %v", err)
+		}
+
+		return httptest.NewServer(p)
+	}
+
+	get := func(url string) (*http.Response, string) {
+		resp, err := http.Get(url)
+		if err != nil {
+			t.Fatalf("GET %s failed with: %v", url, err)
+		}
+
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		return resp, string(body)
+	}
+
+	t.Run("InvalidUpstream", func(t *testing.T) {
+		if _, err := proxy.New(proxy.Options{Upstream: "flexpool.io"}); err != proxy.ErrInvalidUpstream {
+			t.Errorf("New error = %v, want %v", err, proxy.ErrInvalidUpstream)
+		}
+	})
+
+	t.Run("Caching", func(t *testing.T) {
+		server := newProxy(time.Minute)
+		defer server.Close()
+
+		atomic.StoreInt64(&upstreamRequests, 0)
+
+		// The /api/v1 prefix is optional, and query parameter order doesn't matter
+		paths := []string{"/miner/" + address + "/payments?page=1&x=2", "/api/v1/miner/" + address + "/payments?x=2&page=1"}
+		for i, path := range paths {
+			resp, body := get(server.URL + path)
+			if resp.StatusCode != http.StatusOK || body != `{"error": null, "result": 2000000000}` {
+				t.Fatalf("GET %s = %d, %q", path, resp.StatusCode, body)
+			}
+
+			wantCache := []string{"MISS", "HIT"}[i]
+			if resp.Header.Get("X-Cache") != wantCache {
+				t.Errorf("GET %s X-Cache = %q, want %q", path, resp.Header.Get("X-Cache"), wantCache)
+			}
+		}
+
+		if requests := atomic.LoadInt64(&upstreamRequests); requests != 1 {
+			t.Errorf("upstream received %d requests, want 1", requests)
+		}
+	})
+
+	t.Run("Coalescing", func(t *testing.T) {
+		server := newProxy(time.Minute)
+		defer server.Close()
+
+		atomic.StoreInt64(&upstreamRequests, 0)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				get(server.URL + "/pool/hashrate")
+			}()
+		}
+
+		wg.Wait()
+
+		if requests := atomic.LoadInt64(&upstreamRequests); requests != 1 {
+			t.Errorf("upstream received %d requests, want 1", requests)
+		}
+	})
+
+	t.Run("Disconnect", func(t *testing.T) {
+		// One token, refilled every 50ms, so the second fetch has to wait for the limiter
+		p, _ := proxy.New(proxy.Options{Upstream: upstream.URL, TTL: time.Minute, Rate: 20, Burst: 1})
+		defer p.Close()
+
+		p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/pool/hashrate", nil))
+
+		// The first client to ask has already gone away, which mustn't fail the fetch the second is sharing
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		gone := httptest.NewRecorder()
+		waiting := httptest.NewRecorder()
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			p.ServeHTTP(gone, httptest.NewRequest("GET", "/pool/workersOnline", nil).WithContext(ctx))
+		}()
+
+		go func() {
+			defer wg.Done()
+			time.Sleep(10 * time.Millisecond)
+			p.ServeHTTP(waiting, httptest.NewRequest("GET", "/pool/workersOnline", nil))
+		}()
+
+		wg.Wait()
+
+		if waiting.Code != http.StatusOK || gone.Code != http.StatusOK {
+			t.Errorf("statuses = %d and %d, want the shared fetch to succeed for both", gone.Code, waiting.Code)
+		}
+
+		if stats := p.Stats(); stats.Cache.Coalesced != 1 || stats.RateLimited != 1 {
+			t.Errorf("stats = %+v, want one rate limited fetch shared by both clients", stats)
+		}
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		server := newProxy(10 * time.Millisecond)
+		defer server.Close()
+		defer atomic.StoreInt32(&down, 0)
+
+		get(server.URL + "/pool/minersOnline")
+		time.Sleep(20 * time.Millisecond)
+		atomic.StoreInt32(&down, 1)
+
+		resp, body := get(server.URL + "/pool/minersOnline")
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("X-Cache") != "STALE" || body != `{"error": null, "result": 2000000000}` {
+			t.Errorf("stale GET = %d, %q, %q", resp.StatusCode, resp.Header.Get("X-Cache"), body)
+		}
+
+		// With nothing cached, the upstream's error is passed through
+		if resp, _ := get(server.URL + "/pool/workersOnline"); resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("uncached GET status = %d, want 503", resp.StatusCode)
+		}
+	})
+
+	t.Run("UnknownRoute", func(t *testing.T) {
+		server := newProxy(time.Minute)
+		defer server.Close()
+
+		for _, path := range []string{"/miner/" + address + "/unknown", "/pool/hashrate/extra", "/miner/../pool/hashrate", "/"} {
+			if resp, _ := get(server.URL + path); resp.StatusCode != http.StatusNotFound {
+				t.Errorf("GET %s status = %d, want 404", path, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("Client", func(t *testing.T) {
+		server := newProxy(time.Minute)
+		defer server.Close()
+
+		previousHost := api.DefaultClient.Host
+		api.DefaultClient.Host = server.URL + "/api/v1"
+		defer func() { api.DefaultClient.Host = previousHost }()
+
+		if balance, err := api.MinerGetBalance(address); err != nil || balance != 2 {
+			t.Errorf("MinerGetBalance = %v, %v, want 2, nil", balance, err)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Run("Burst", func(t *testing.T) {
+		l := ratelimit.New(1, 3)
+
+		for i := 0; i < 3; i++ {
+			if !l.Allow() {
+				t.Fatalf("Allow %d = false within burst", i)
+			}
+		}
+
+		if l.Allow() {
+			t.Error("Allow = true after burst was used up")
+		}
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		l := ratelimit.New(0, 1)
+
+		for i := 0; i < 100; i++ {
+			if !l.Allow() {
+				t.Fatalf("Allow %d = false with no rate limit", i)
+			}
+		}
+	})
+
+	t.Run("Wait", func(t *testing.T) {
+		l := ratelimit.New(50, 1)
+		l.Allow()
+
+		start := time.Now()
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait failed with: %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+			t.Errorf("Wait returned after %v, want about 20ms", elapsed)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		l.Allow()
+		if err := l.Wait(ctx); err != context.Canceled {
+			t.Errorf("Wait with cancelled context = %v, want %v", err, context.Canceled)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRPC(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	failing := func(digit int) string {
+		return fmt.Sprintf("0x%040d", digit)
+	}
+
+	// Stand in for the flexpool API. The pool finds a new block after the first poll.
+	var workerRequests, blockPolls int64
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		block := `{"hash": "%s", "number": %d, "type": "block", "miner": "` + address + `", "difficulty": 1, "timestamp": 1,
+			"confirmed": false, "round_time": 1, "luck": 1, "server_name": "eu", "block_reward": 2, "block_fees": 0,
+			"uncle_inclusion_rewards": 0, "total_rewards": 2}`
+
+		result := "null"
+
+		switch r.URL.Path {
+		case "/miner/" + address + "/workers":
+			atomic.AddInt64(&workerRequests, 1)
+			result = `[{"name": "rig01", "online": true, "duplicate_workers_merged": 0, "reported_hashrate": 100,
+				"effective_hashrate": 90, "valid_shares": 10, "stale_shares": 1, "invalid_shares": 0, "last_seen": 1600000000}]`
+		case "/pool/blocks":
+			blocks := fmt.Sprintf(block, "0x01", 1)
+			if atomic.AddInt64(&blockPolls, 1) > 1 {
+				blocks = fmt.Sprintf(block, "0x02", 2) + ", " + blocks
+			}
+
+			result = `{"data": [` + blocks + `], "items_per_page": 10, "total_items": 2, "total_pages": 1}`
+		}
+
+		// Balances for failing(n) fail with an error in the n00 range
+		switch r.URL.Path {
+		case "/miner/" + failing(4) + "/balance":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"code": 404, "message": "not found"}, "result": null}`))
+
+			return
+		case "/miner/" + failing(2) + "/balance":
+			w.Write([]byte(`{"error": {"code": 400, "message": "invalid address"}, "result": null}`))
+			return
+		case "/miner/" + failing(5) + "/balance":
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.Write([]byte(`{"error": null, "result": ` + result + `}`))
+	}))
+	defer upstream.Close()
+
+	previousHost := api.DefaultClient.Host
+	api.DefaultClient.Host = upstream.URL
+	defer func() { api.DefaultClient.Host = previousHost }()
+
+	// Serve over an in-memory listener
+	listener := bufconn.Listen(1 << 20)
+
+	server := rpc.NewServer(rpc.Options{CacheTTL: time.Minute, WatchInterval: 20 * time.Millisecond})
+	defer server.Close()
+
+	grpcServer := grpc.NewServer()
+	server.Register(grpcServer)
+
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	client, err := rpc.Dial("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial failed with: %v", err)
+	}
+
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("GetWorkers", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			list, err := client.Miner.GetWorkers(ctx, &rpc.MinerRequest{Address: address})
+			if err != nil || len(list.GetWorkers()) != 1 || list.GetWorkers()[0].GetName() != "rig01" ||
+				list.GetWorkers()[0].GetEffectiveHashrate() != 90 {
+				t.Fatalf("GetWorkers = %v, %v", list, err)
+			}
+		}
+
+		if requests := atomic.LoadInt64(&workerRequests); requests != 1 {
+			t.Errorf("upstream received %d requests, want 1 with caching", requests)
+		}
+	})
+
+	t.Run("InvalidArgument", func(t *testing.T) {
+		_, err := client.Miner.GetBalance(ctx, &rpc.MinerRequest{Address: "0x1"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetBalance with a bad address error = %v, want InvalidArgument", err)
+		}
+
+		_, err = client.Pool.GetBlocks(ctx, &rpc.PagedPoolRequest{Page: -1})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetBlocks with a negative page error = %v, want InvalidArgument", err)
+		}
+	})
+
+	t.Run("APIErrors", func(t *testing.T) {
+		tests := []struct {
+			address string
+			code    codes.Code
+		}{
+			{failing(2), codes.InvalidArgument},
+			{failing(4), codes.NotFound},
+			{failing(5), codes.Unavailable},
+		}
+
+		for _, test := range tests {
+			if _, err := client.Miner.GetBalance(ctx, &rpc.MinerRequest{Address: test.address}); status.Code(err) != test.code {
+				t.Errorf("GetBalance(%s) error = %v, want %v", test.address, err, test.code)
+			}
+		}
+	})
+
+	t.Run("WatchBlocks", func(t *testing.T) {
+		stream, err := client.Pool.WatchBlocks(ctx, &rpc.PoolRequest{})
+		if err != nil {
+			t.Fatalf("WatchBlocks failed with: %v", err)
+		}
+
+		// Only the block found after the stream started is sent
+		block, err := stream.Recv()
+		if err != nil || block.GetHash() != "0x02" || block.GetNumber() != 2 {
+			t.Errorf("Recv = %v, %v, want block 0x02", block, err)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/utils"
+)
+
+func TestPoolStats(t *testing.T) {
+	// Three blocks 12 hours apart, newest first
+	blocks := []api.Block{
+		{Type: "block", Timestamp: 86400, TotalRewards: 2000},
+		{Type: "uncle", Timestamp: 43200, TotalRewards: 1750},
+		{Type: "block", Timestamp: 0, TotalRewards: 2250},
+	}
+
+	single := blocks[:1]
+	sameTime := []api.Block{{Timestamp: 100}, {Timestamp: 100}}
+
+	near := func(got float64, want float64) bool {
+		return math.Abs(got-want) < 1e-9
+	}
+
+	t.Run("CalculateExpectedRoundTime", func(t *testing.T) {
+		tests := []struct {
+			name                   string
+			network, pool, seconds float64
+			want                   float64
+			err                    error
+		}{
+			{"Exact", 1000, 100, 13, 130, nil},
+			{"Fractional", 1000, 300, 13, 1000.0 / 300 * 13, nil},
+			{"PoolLarger", 100, 200, 13, 6.5, nil},
+			{"ZeroPool", 1000, 0, 13, 0, utils.ErrInvalidHashrate},
+			{"NegativeNetwork", -1, 100, 13, 0, utils.ErrInvalidHashrate},
+			{"ZeroBlockTime", 1000, 100, 0, 0, utils.ErrInvalidBlockTime},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got, err := utils.CalculateExpectedRoundTime(test.network, test.pool, test.seconds)
+				if err != test.err || !near(got, test.want) {
+					t.Errorf("CalculateExpectedRoundTime = %v, %v, want %v, %v", got, err, test.want, test.err)
+				}
+			})
+		}
+	})
+
+	t.Run("CalculatePPLNSShareWindow", func(t *testing.T) {
+		tests := []struct {
+			name             string
+			n                int
+			difficulty, pool float64
+			want             float64
+			err              error
+		}{
+			{"Exact", 100, 4000, 200, 2000, nil},
+			{"Fractional", 3, 1, 2, 1.5, nil},
+			{"ZeroHashrate", 100, 4000, 0, 0, utils.ErrInvalidHashrate},
+			{"ZeroN", 0, 4000, 200, 0, utils.ErrInvalidN},
+			{"ZeroDifficulty", 100, 0, 200, 0, utils.ErrInvalidN},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got, err := utils.CalculatePPLNSShareWindow(test.n, test.difficulty, test.pool)
+				if err != test.err || !near(got, test.want) {
+					t.Errorf("CalculatePPLNSShareWindow = %v, %v, want %v, %v", got, err, test.want, test.err)
+				}
+			})
+		}
+	})
+
+	t.Run("Percentile", func(t *testing.T) {
+		sorted := []float64{10, 20, 30, 40, 50}
+
+		tests := []struct {
+			name       string
+			sorted     []float64
+			percentile float64
+			want       float64
+		}{
+			{"Median", sorted, 50, 30},
+			{"Interpolated", sorted, 10, 14},
+			{"Quartile", sorted[:4], 25, 17.5},
+			{"Minimum", sorted, 0, 10},
+			{"Maximum", sorted, 100, 50},
+			{"Clamped", sorted, 150, 50},
+			{"Single", sorted[:1], 90, 10},
+			{"Empty", nil, 50, 0},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				if got := utils.Percentile(test.sorted, test.percentile); !near(got, test.want) {
+					t.Errorf("Percentile(%v, %v) = %v, want %v", test.sorted, test.percentile, got, test.want)
+				}
+			})
+		}
+	})
+
+	t.Run("BlockStats", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			calculate func(blocks []api.Block) (float64, error)
+			blocks    []api.Block
+			want      float64
+			err       error
+		}{
+			{"UncleRate", utils.CalculateUncleRate, blocks, 1.0 / 3, nil},
+			{"UncleRateSingle", utils.CalculateUncleRate, single, 0, nil},
+			{"UncleRateEmpty", utils.CalculateUncleRate, nil, 0, utils.ErrNoBlocks},
+			{"AverageBlockReward", utils.CalculateAverageBlockReward, blocks, 2000, nil},
+			{"AverageBlockRewardFractional", utils.CalculateAverageBlockReward, blocks[:2], 1875, nil},
+			{"AverageBlockRewardEmpty", utils.CalculateAverageBlockReward, nil, 0, utils.ErrNoBlocks},
+			{"AverageBlocksPerDay", utils.CalculateAverageBlocksPerDay, blocks, 2, nil},
+			{"AverageBlocksPerDayUnderADay", utils.CalculateAverageBlocksPerDay, blocks[:2], 2, nil},
+			{"AverageBlocksPerDayUnordered", utils.CalculateAverageBlocksPerDay, []api.Block{blocks[1], blocks[2], blocks[0]}, 2, nil},
+			{"AverageBlocksPerDayEmpty", utils.CalculateAverageBlocksPerDay, nil, 0, utils.ErrNoBlocks},
+			{"AverageBlocksPerDaySingle", utils.CalculateAverageBlocksPerDay, single, 0, utils.ErrTooFewBlocks},
+			{"AverageBlocksPerDaySameTime", utils.CalculateAverageBlocksPerDay, sameTime, 0, utils.ErrZeroTimeSpan},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got, err := test.calculate(test.blocks)
+				if err != test.err || !near(got, test.want) {
+					t.Errorf("got %v, %v, want %v, %v", got, err, test.want, test.err)
+				}
+			})
+		}
+	})
+}
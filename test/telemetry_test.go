@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetry(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	// Stand in for the flexpool API, answering the balance endpoint with an API error
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/balance") {
+			w.Write([]byte(`{"error": {"code": 400, "message": "invalid address"}, "result": 0}`))
+			return
+		}
+
+		w.Write([]byte(`{"error": null, "result": {"data": [], "items_per_page": 10, "total_items": 0, "total_pages": 0}}`))
+	}))
+	defer upstream.Close()
+
+	previous := *api.DefaultClient
+	defer func() { *api.DefaultClient = previous }()
+
+	api.DefaultClient.Host = upstream.URL
+	api.DefaultClient.Middleware = nil
+
+	t.Run("NoProvider", func(t *testing.T) {
+		middleware, err := telemetry.Middleware(telemetry.Options{})
+		if err != nil {
+			t.Fatalf("Middleware failed with: %v", err)
+		}
+
+		api.DefaultClient.Middleware = []api.Middleware{middleware}
+		defer func() { api.DefaultClient.Middleware = nil }()
+
+		if _, err := api.MinerGetPayments(address, 0); err != nil {
+			t.Errorf("MinerGetPayments with no provider failed with: %v", err)
+		}
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+		middleware, err := telemetry.Middleware(telemetry.Options{TracerProvider: tracerProvider})
+		if err != nil {
+			t.Fatalf("Middleware failed with: %v", err)
+		}
+
+		client := &api.Client{Host: upstream.URL, Middleware: []api.Middleware{middleware}}
+
+		ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "handler")
+		client.WithContext(ctx).Miner().GetPayments(address, 0)
+		parent.End()
+
+		spans := exporter.GetSpans()
+		if len(spans) != 2 {
+			t.Fatalf("recorded %d spans, want the call and its parent", len(spans))
+		}
+
+		call, handler := spans[0], spans[1]
+		if call.Parent.SpanID() != handler.SpanContext.SpanID() || call.SpanContext.TraceID() != handler.SpanContext.TraceID() {
+			t.Errorf("call span %s has parent %s, want it nested under %s", call.Name, call.Parent.SpanID(), handler.SpanContext.SpanID())
+		}
+
+		// Without a context, calls are still root spans
+		exporter.Reset()
+		client.Miner().GetPayments(address, 0)
+
+		if spans := exporter.GetSpans(); len(spans) != 1 || spans[0].Parent.IsValid() {
+			t.Errorf("spans = %+v, want a single root span", spans)
+		}
+	})
+
+	t.Run("Recorded", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		reader := sdkmetric.NewManualReader()
+		meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+		middleware, err := telemetry.Middleware(telemetry.Options{TracerProvider: tracerProvider, MeterProvider: meterProvider})
+		if err != nil {
+			t.Fatalf("Middleware failed with: %v", err)
+		}
+
+		api.DefaultClient.Middleware = []api.Middleware{middleware}
+		defer func() { api.DefaultClient.Middleware = nil }()
+
+		api.MinerGetPayments(address, 2)
+		api.MinerGetBalance(address)
+
+		spans := exporter.GetSpans()
+		if len(spans) != 2 {
+			t.Fatalf("recorded %d spans, want 2", len(spans))
+		}
+
+		if spans[0].Name != "flexpool.miner.payments" {
+			t.Errorf("span name = %q", spans[0].Name)
+		}
+
+		want := []attribute.KeyValue{
+			telemetry.EndpointKey.String("miner"),
+			telemetry.MethodKey.String("payments"),
+			telemetry.AddressHashKey.String(telemetry.HashAddress(address)),
+			telemetry.PageKey.Int(2),
+			telemetry.StatusCodeKey.Int(200),
+		}
+
+		attributes := attribute.NewSet(spans[0].Attributes...)
+		for _, kv := range want {
+			if value, ok := attributes.Value(kv.Key); !ok || value != kv.Value {
+				t.Errorf("span attribute %s = %v, want %v", kv.Key, value.Emit(), kv.Value.Emit())
+			}
+		}
+
+		if strings.Contains(attributes.Encoded(attribute.DefaultEncoder()), address) {
+			t.Error("span attributes contain the raw address")
+		}
+
+		balanceAttributes := attribute.NewSet(spans[1].Attributes...)
+		if code, _ := balanceAttributes.Value(telemetry.ErrorCodeKey); code.AsInt64() != 400 {
+			t.Errorf("balance span error code = %v, want 400", code.Emit())
+		}
+
+		var metrics metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &metrics); err != nil {
+			t.Fatalf("Collect failed with: %v", err)
+		}
+
+		found := make(map[string]bool)
+		for _, scope := range metrics.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				found[m.Name] = true
+
+				if m.Name != "flexpool.client.errors" {
+					continue
+				}
+
+				sum := m.Data.(metricdata.Sum[int64])
+				if len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 1 {
+					t.Errorf("error counter = %+v, want one error", sum.DataPoints)
+				} else if code, _ := sum.DataPoints[0].Attributes.Value(telemetry.ErrorCodeKey); code.AsInt64() != 400 {
+					t.Errorf("error counter code = %v, want 400", code.Emit())
+				}
+			}
+		}
+
+		if !found["flexpool.client.duration"] || !found["flexpool.client.errors"] {
+			t.Errorf("recorded metrics = %v", found)
+		}
+	})
+}
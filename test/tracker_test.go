@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/utils/blocks"
+)
+
+// chain serves a list of blocks, newest first, as pages of two, recording which pages were fetched.
+type chain struct {
+	mu      sync.Mutex
+	blocks  []api.Block
+	fetched []int
+}
+
+func (c *chain) set(blocks ...api.Block) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.blocks = blocks
+	c.fetched = nil
+}
+
+func (c *chain) pages(page int) ([]api.Block, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fetched = append(c.fetched, page)
+
+	start, end := page*2, page*2+2
+	if start > len(c.blocks) {
+		start = len(c.blocks)
+	}
+
+	if end > len(c.blocks) {
+		end = len(c.blocks)
+	}
+
+	return c.blocks[start:end], (len(c.blocks) + 1) / 2, nil
+}
+
+func TestTracker(t *testing.T) {
+	block := func(number uint, hash string, kind string, confirmed bool) api.Block {
+		return api.Block{Number: number, Hash: hash, Type: kind, Confirmed: confirmed, Timestamp: 1000 + number}
+	}
+
+	types := func(events []blocks.TrackerEvent) []string {
+		var types []string
+		for _, event := range events {
+			types = append(types, event.Type+" "+event.Block.Hash)
+		}
+
+		return types
+	}
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		c := &chain{}
+		tracker := blocks.NewTracker(c.pages, blocks.TrackerOptions{})
+
+		poll := func(now int64, want ...string) []blocks.TrackerEvent {
+			t.Helper()
+
+			events, err := tracker.Poll(time.Unix(now, 0))
+			if err != nil {
+				t.Fatalf("Poll failed with: %v", err)
+			}
+
+			if got := types(events); !reflect.DeepEqual(got, want) {
+				t.Fatalf("Poll at %d = %v, want %v", now, got, want)
+			}
+
+			return events
+		}
+
+		// Confirmed blocks were never seen unconfirmed, so aren't tracked
+		c.set(block(3, "0x3", "block", false), block(2, "0x2", "block", false), block(1, "0x1", "block", true))
+		poll(1100, "seen 0x3", "seen 0x2")
+
+		c.set(block(3, "0x3", "block", true), block(2, "0x2", "uncle", false), block(1, "0x1", "block", true))
+		events := poll(1203, "confirmed 0x3", "reclassified 0x2")
+
+		if events[0].TimeToConfirm != 200 || events[0].Time != 1203 {
+			t.Errorf("confirmed event = %+v, want 200 seconds to confirm", events[0])
+		}
+
+		if events[1].PreviousType != "block" || events[1].Block.Type != "uncle" {
+			t.Errorf("reclassified event = %+v, want block to uncle", events[1])
+		}
+
+		// 0x2 disappears, but it's only orphaned once it's been missing for two polls
+		c.set(block(4, "0x4", "block", false), block(3, "0x3", "block", true), block(1, "0x1", "block", true))
+		poll(1300, "seen 0x4")
+		events = poll(1400, "orphaned 0x2")
+
+		if events[0].Block.Type != "uncle" {
+			t.Errorf("orphaned event = %+v, want the block as last seen", events[0])
+		}
+
+		if pending := tracker.Pending(); len(pending) != 1 || pending[0].Hash != "0x4" {
+			t.Errorf("Pending = %+v, want only 0x4", pending)
+		}
+
+		want := blocks.ConfirmationStats{Seen: 3, Pending: 1, Confirmed: 1, Orphaned: 1, Reclassified: 1, OrphanRate: 0.5,
+			AverageTimeToConfirm: 200, MinTimeToConfirm: 200, MaxTimeToConfirm: 200}
+		if stats := tracker.Stats(); stats != want {
+			t.Errorf("Stats = %+v, want %+v", stats, want)
+		}
+	})
+
+	t.Run("Reappearing", func(t *testing.T) {
+		c := &chain{}
+		tracker := blocks.NewTracker(c.pages, blocks.TrackerOptions{})
+
+		c.set(block(2, "0x2", "block", false), block(1, "0x1", "block", true))
+		tracker.Poll(time.Unix(1100, 0))
+
+		// Missing for one poll, then back, which resets the count
+		for _, present := range []bool{false, true, false} {
+			if present {
+				c.set(block(2, "0x2", "block", false), block(1, "0x1", "block", true))
+			} else {
+				c.set(block(1, "0x1", "block", true))
+			}
+
+			if events, _ := tracker.Poll(time.Unix(1200, 0)); len(events) != 0 {
+				t.Errorf("Poll = %v, want no events", types(events))
+			}
+		}
+	})
+
+	t.Run("Paging", func(t *testing.T) {
+		c := &chain{}
+		tracker := blocks.NewTracker(c.pages, blocks.TrackerOptions{MaxPages: 2})
+
+		// Only the first page is fetched with nothing tracked
+		c.set(block(8, "0x8", "block", false), block(7, "0x7", "block", true), block(6, "0x6", "block", true),
+			block(5, "0x5", "block", true), block(4, "0x4", "block", true))
+		tracker.Poll(time.Unix(1100, 0))
+
+		if !reflect.DeepEqual(c.fetched, []int{0}) {
+			t.Errorf("fetched pages %v, want only the first", c.fetched)
+		}
+
+		// 0x8 has been pushed back to page 1 by newer blocks, so paging continues until it's passed
+		c.set(block(10, "0xa", "block", true), block(9, "0x9", "block", true), block(8, "0x8", "block", false),
+			block(7, "0x7", "block", true), block(6, "0x6", "block", true))
+		tracker.Poll(time.Unix(1200, 0))
+
+		if !reflect.DeepEqual(c.fetched, []int{0, 1}) {
+			t.Errorf("fetched pages %v, want the first two", c.fetched)
+		}
+
+		// Beyond MaxPages, 0x8 can't be checked, so it isn't orphaned
+		c.set(block(12, "0xc", "block", true), block(11, "0xb", "block", true), block(10, "0xa", "block", true),
+			block(9, "0x9", "block", true), block(8, "0x8", "block", false), block(7, "0x7", "block", true))
+
+		for i := 0; i < 3; i++ {
+			if events, _ := tracker.Poll(time.Unix(1300, 0)); len(events) != 0 {
+				t.Errorf("Poll = %v, want no events", types(events))
+			}
+		}
+
+		if stats := tracker.Stats(); stats.Pending != 1 || stats.Orphaned != 0 {
+			t.Errorf("Stats = %+v, want 0x8 still pending", stats)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		fail := false
+		c := &chain{}
+		c.set(block(1, "0x1", "block", false))
+
+		tracker := blocks.NewTracker(func(page int) ([]api.Block, int, error) {
+			if fail {
+				return nil, 0, errors.New("down")
+			}
+
+			return c.pages(page)
+		}, blocks.TrackerOptions{OrphanPolls: 1})
+
+		tracker.Poll(time.Unix(1100, 0))
+		fail = true
+
+		if events, err := tracker.Poll(time.Unix(1200, 0)); err == nil || events != nil {
+			t.Errorf("Poll = %v, %v, want an error", events, err)
+		}
+
+		if stats := tracker.Stats(); stats.Pending != 1 || stats.Orphaned != 0 {
+			t.Errorf("Stats = %+v, want the failed poll to change nothing", stats)
+		}
+	})
+
+	t.Run("Outage", func(t *testing.T) {
+		c := &chain{}
+		outage := false
+
+		tracker := blocks.NewTracker(func(page int) ([]api.Block, int, error) {
+			if outage {
+				return nil, 0, nil
+			}
+
+			return c.pages(page)
+		}, blocks.TrackerOptions{OrphanPolls: 1})
+
+		c.set(block(2, "0x2", "block", false), block(1, "0x1", "block", true))
+		tracker.Poll(time.Unix(1100, 0))
+
+		// An empty first page looks like an outage, however many polls it lasts, not like every block being orphaned
+		outage = true
+
+		for i := 0; i < 3; i++ {
+			if events, err := tracker.Poll(time.Unix(1200, 0)); err != blocks.ErrEmptyPoll || events != nil {
+				t.Errorf("Poll = %v, %v, want ErrEmptyPoll", types(events), err)
+			}
+		}
+
+		// As does a successful response with no blocks at all
+		outage = false
+		c.set()
+
+		if _, err := tracker.Poll(time.Unix(1300, 0)); err != blocks.ErrEmptyPoll {
+			t.Errorf("Poll returned %v for an empty page, want ErrEmptyPoll", err)
+		}
+
+		if stats := tracker.Stats(); stats.Pending != 1 || stats.Orphaned != 0 {
+			t.Errorf("Stats = %+v, want 0x2 still pending", stats)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		c := &chain{}
+		c.set(block(1, "0x1", "block", false))
+
+		entered := make(chan bool)
+		release := make(chan bool)
+
+		tracker := blocks.NewTracker(func(page int) ([]api.Block, int, error) {
+			entered <- true
+			<-release
+
+			return c.pages(page)
+		}, blocks.TrackerOptions{})
+
+		polled := make(chan error)
+		go func() {
+			_, err := tracker.Poll(time.Unix(1100, 0))
+			polled <- err
+		}()
+
+		<-entered
+
+		// Reads don't wait on a poll's fetch
+		if stats := tracker.Stats(); stats.Seen != 0 {
+			t.Errorf("Stats = %+v during the first poll, want nothing seen yet", stats)
+		}
+
+		if pending := tracker.Pending(); len(pending) != 0 {
+			t.Errorf("Pending = %+v during the first poll, want none", pending)
+		}
+
+		// A second poll waits for the first, rather than fetching alongside it
+		second := make(chan error)
+		go func() {
+			_, err := tracker.Poll(time.Unix(1200, 0))
+			second <- err
+		}()
+
+		select {
+		case <-entered:
+			t.Fatal("second poll fetched before the first finished")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		release <- true
+		if err := <-polled; err != nil {
+			t.Fatalf("Poll failed with: %v", err)
+		}
+
+		<-entered
+		release <- true
+
+		if err := <-second; err != nil {
+			t.Fatalf("Poll failed with: %v", err)
+		}
+
+		if stats := tracker.Stats(); stats.Seen != 1 || stats.Pending != 1 {
+			t.Errorf("Stats = %+v, want 0x1 seen once", stats)
+		}
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		c := &chain{}
+		c.set(block(1, "0x1", "block", false))
+
+		var (
+			mu     sync.Mutex
+			errs   int
+			failed = true
+		)
+
+		tracker := blocks.NewTracker(func(page int) ([]api.Block, int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if failed {
+				failed = false
+				return nil, 0, errors.New("down")
+			}
+
+			return c.pages(page)
+		}, blocks.TrackerOptions{Interval: 5 * time.Millisecond, OnError: func(err error) {
+			mu.Lock()
+			errs++
+			mu.Unlock()
+		}})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := make(chan blocks.TrackerEvent)
+		done := make(chan error)
+
+		go func() { done <- tracker.Run(ctx, events) }()
+
+		select {
+		case event := <-events:
+			if event.Type != blocks.EventSeen || event.Block.Hash != "0x1" {
+				t.Errorf("event = %+v, want 0x1 seen", event)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for an event")
+		}
+
+		c.set(block(1, "0x1", "block", true))
+
+		select {
+		case event := <-events:
+			if event.Type != blocks.EventConfirmed {
+				t.Errorf("event = %+v, want 0x1 confirmed", event)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for an event")
+		}
+
+		cancel()
+
+		if err := <-done; err != context.Canceled {
+			t.Errorf("Run returned %v, want context.Canceled", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if errs != 1 {
+			t.Errorf("OnError was called %d times, want once", errs)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cryptogenic/goflexpool/pkg/utils/variance"
+)
+
+func TestVarianceSimulation(t *testing.T) {
+	// A pool with 1% of the network finds roughly 65 blocks a day, with the miner taking 0.1% of each
+	config := variance.Config{
+		PoolHashrate:    1_000_000_000_000,
+		NetworkHashrate: 100_000_000_000_000,
+		BlockRewardGwei: 2_000_000_000,
+		RoundShare:      0.1,
+		BalanceGwei:     10_000_000,
+		ThresholdGwei:   50_000_000,
+		Days:            365,
+		Runs:            500,
+		Seed:            7,
+	}
+
+	t.Run("Simulate", func(t *testing.T) {
+		result, err := variance.Simulate(config)
+		if err != nil {
+			t.Fatalf("Simulate failed with: %v", err)
+		}
+
+		if math.Abs(result.ExpectedBlocksPerDay-86400/variance.DefaultBlockTime/100) > 1e-9 {
+			t.Errorf("ExpectedBlocksPerDay = %v", result.ExpectedBlocksPerDay)
+		}
+
+		if daily := result.DailyEarningsGwei; math.Abs(daily.Mean-result.ExpectedDailyGwei)/result.ExpectedDailyGwei > 0.05 {
+			t.Errorf("simulated daily mean %v too far from expected %v", daily.Mean, result.ExpectedDailyGwei)
+		}
+
+		// 40M gwei remaining at roughly 131M gwei a day should take somewhere around 7 hours
+		if median := result.TimeToThresholdHours.Median; median < 5 || median > 10 {
+			t.Errorf("time to threshold median = %v hours", median)
+		}
+
+		again, _ := variance.Simulate(config)
+		if !reflect.DeepEqual(again, result) {
+			t.Errorf("simulation with the same seed was not reproducible")
+		}
+	})
+
+	t.Run("SimulateInvalid", func(t *testing.T) {
+		invalid := config
+		invalid.PoolHashrate = 0
+
+		if _, err := variance.Simulate(invalid); err != variance.ErrInvalidHashrate {
+			t.Errorf("Simulate error = %v, want ErrInvalidHashrate", err)
+		}
+
+		invalid = config
+		invalid.Days = 0
+
+		if _, err := variance.Simulate(invalid); err != variance.ErrInvalidConfig {
+			t.Errorf("Simulate error = %v, want ErrInvalidConfig", err)
+		}
+	})
+
+	t.Run("NewDistribution", func(t *testing.T) {
+		dist := variance.NewDistribution([]float64{1, 2, 3, 4, 5}, 2)
+
+		if dist.Mean != 3 || dist.Median != 3 || dist.P25 != 2 || dist.P75 != 4 {
+			t.Errorf("unexpected distribution: %+v", dist)
+		}
+
+		if len(dist.Histogram) != 2 || dist.Histogram[0].Count != 2 || dist.Histogram[1].Count != 3 {
+			t.Errorf("unexpected histogram: %+v", dist.Histogram)
+		}
+
+		if empty := variance.NewDistribution(nil, 2); empty.Count != 0 || empty.Histogram != nil {
+			t.Errorf("NewDistribution(nil) = %+v, want empty", empty)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptogenic/goflexpool/pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/web"
+)
+
+func TestWebServer(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	// Stand in for the flexpool API, counting requests so caching can be checked
+	var upstreamRequests int64
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&upstreamRequests, 1)
+
+		result := "null"
+		if r.URL.Path == "/miner/"+address+"/workers" {
+			result = `[{"name": "rig01", "online": true, "duplicate_workers_merged": 0, "reported_hashrate": 100,
+				"effective_hashrate": 90, "valid_shares": 10, "stale_shares": 1, "invalid_shares": 0, "last_seen": 1600000000}]`
+		}
+
+		w.Write([]byte(`{"error": null, "result": ` + result + `}`))
+	}))
+	defer upstream.Close()
+
+	previousHost := api.DefaultClient.Host
+	api.DefaultClient.Host = upstream.URL
+	defer func() { api.DefaultClient.Host = previousHost }()
+
+	server := httptest.NewServer(web.NewServer(web.Options{
+		Addresses: map[string]string{"home": address},
+		Username:  "admin",
+		Password:  "secret",
+		CacheTTL:  time.Minute,
+	}))
+	defer server.Close()
+
+	get := func(path string, authenticated bool) *http.Response {
+		req, _ := http.NewRequest("GET", server.URL+path, nil)
+		if authenticated {
+			req.SetBasicAuth("admin", "secret")
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s failed with: %v", path, err)
+		}
+
+		return resp
+	}
+
+	t.Run("BasicAuth", func(t *testing.T) {
+		if resp := get("/api/addresses", false); resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("unauthenticated request status = %d, want 401", resp.StatusCode)
+		}
+	})
+
+	t.Run("Workers", func(t *testing.T) {
+		for i, path := range []string{"/api/miner/home/workers", "/api/miner/" + address + "/workers"} {
+			resp := get(path, true)
+
+			var workers []api.MinerWorker
+			if err := json.NewDecoder(resp.Body).Decode(&workers); err != nil || len(workers) != 1 || workers[0].Name != "rig01" {
+				t.Fatalf("GET %s = %+v, %v", path, workers, err)
+			}
+
+			wantCache := []string{"MISS", "HIT"}[i]
+			if resp.Header.Get("X-Cache") != wantCache {
+				t.Errorf("GET %s X-Cache = %q, want %q", path, resp.Header.Get("X-Cache"), wantCache)
+			}
+		}
+
+		if requests := atomic.LoadInt64(&upstreamRequests); requests != 1 {
+			t.Errorf("upstream received %d requests, want 1", requests)
+		}
+	})
+
+	t.Run("UnknownAddress", func(t *testing.T) {
+		if resp := get("/api/miner/0x0000000000000000000000000000000000000002", true); resp.StatusCode != http.StatusNotFound {
+			t.Errorf("status = %d, want 404", resp.StatusCode)
+		}
+	})
+
+	t.Run("InvalidPage", func(t *testing.T) {
+		if resp := get("/api/miner/home/payments?page=-1", true); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("status = %d, want 400", resp.StatusCode)
+		}
+	})
+
+	t.Run("UI", func(t *testing.T) {
+		resp := get("/", true)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "flexpool dashboard") {
+			t.Errorf("index status = %d, body = %q", resp.StatusCode, body)
+		}
+	})
+}
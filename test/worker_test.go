@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"testing"
 
-	"../pkg/api"
+	"github.com/cryptogenic/goflexpool/pkg/api"
 )
 
 func TestWorkerEndpoints(t *testing.T) {